@@ -77,6 +77,8 @@ const (
 	OrderType_ORDER_TYPE_UNSPECIFIED OrderType = 0
 	OrderType_ORDER_TYPE_LIMIT       OrderType = 1
 	OrderType_ORDER_TYPE_MARKET      OrderType = 2
+	OrderType_ORDER_TYPE_STOP_MARKET OrderType = 3
+	OrderType_ORDER_TYPE_STOP_LIMIT  OrderType = 4
 )
 
 // Enum value maps for OrderType.
@@ -85,11 +87,15 @@ var (
 		0: "ORDER_TYPE_UNSPECIFIED",
 		1: "ORDER_TYPE_LIMIT",
 		2: "ORDER_TYPE_MARKET",
+		3: "ORDER_TYPE_STOP_MARKET",
+		4: "ORDER_TYPE_STOP_LIMIT",
 	}
 	OrderType_value = map[string]int32{
 		"ORDER_TYPE_UNSPECIFIED": 0,
 		"ORDER_TYPE_LIMIT":       1,
 		"ORDER_TYPE_MARKET":      2,
+		"ORDER_TYPE_STOP_MARKET": 3,
+		"ORDER_TYPE_STOP_LIMIT":  4,
 	}
 )
 
@@ -120,14 +126,123 @@ func (OrderType) EnumDescriptor() ([]byte, []int) {
 	return file_api_proto_oms_proto_rawDescGZIP(), []int{1}
 }
 
+// The price a stop order watches. Unspecified means LAST.
+type TriggerPriceType int32
+
+const (
+	TriggerPriceType_TRIGGER_PRICE_TYPE_UNSPECIFIED TriggerPriceType = 0
+	TriggerPriceType_TRIGGER_PRICE_TYPE_LAST        TriggerPriceType = 1
+	TriggerPriceType_TRIGGER_PRICE_TYPE_MARK        TriggerPriceType = 2
+)
+
+// Enum value maps for TriggerPriceType.
+var (
+	TriggerPriceType_name = map[int32]string{
+		0: "TRIGGER_PRICE_TYPE_UNSPECIFIED",
+		1: "TRIGGER_PRICE_TYPE_LAST",
+		2: "TRIGGER_PRICE_TYPE_MARK",
+	}
+	TriggerPriceType_value = map[string]int32{
+		"TRIGGER_PRICE_TYPE_UNSPECIFIED": 0,
+		"TRIGGER_PRICE_TYPE_LAST":        1,
+		"TRIGGER_PRICE_TYPE_MARK":        2,
+	}
+)
+
+func (x TriggerPriceType) Enum() *TriggerPriceType {
+	p := new(TriggerPriceType)
+	*p = x
+	return p
+}
+
+func (x TriggerPriceType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TriggerPriceType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_oms_proto_enumTypes[2].Descriptor()
+}
+
+func (TriggerPriceType) Type() protoreflect.EnumType {
+	return &file_api_proto_oms_proto_enumTypes[2]
+}
+
+func (x TriggerPriceType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TriggerPriceType.Descriptor instead.
+func (TriggerPriceType) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{2}
+}
+
+type StopOrderStatus int32
+
+const (
+	StopOrderStatus_STOP_ORDER_STATUS_UNSPECIFIED StopOrderStatus = 0
+	StopOrderStatus_STOP_ORDER_STATUS_PENDING     StopOrderStatus = 1
+	StopOrderStatus_STOP_ORDER_STATUS_TRIGGERED   StopOrderStatus = 2
+	StopOrderStatus_STOP_ORDER_STATUS_ACTIVATED   StopOrderStatus = 3
+	StopOrderStatus_STOP_ORDER_STATUS_REJECTED    StopOrderStatus = 4
+	StopOrderStatus_STOP_ORDER_STATUS_CANCELED    StopOrderStatus = 5
+)
+
+// Enum value maps for StopOrderStatus.
+var (
+	StopOrderStatus_name = map[int32]string{
+		0: "STOP_ORDER_STATUS_UNSPECIFIED",
+		1: "STOP_ORDER_STATUS_PENDING",
+		2: "STOP_ORDER_STATUS_TRIGGERED",
+		3: "STOP_ORDER_STATUS_ACTIVATED",
+		4: "STOP_ORDER_STATUS_REJECTED",
+		5: "STOP_ORDER_STATUS_CANCELED",
+	}
+	StopOrderStatus_value = map[string]int32{
+		"STOP_ORDER_STATUS_UNSPECIFIED": 0,
+		"STOP_ORDER_STATUS_PENDING":     1,
+		"STOP_ORDER_STATUS_TRIGGERED":   2,
+		"STOP_ORDER_STATUS_ACTIVATED":   3,
+		"STOP_ORDER_STATUS_REJECTED":    4,
+		"STOP_ORDER_STATUS_CANCELED":    5,
+	}
+)
+
+func (x StopOrderStatus) Enum() *StopOrderStatus {
+	p := new(StopOrderStatus)
+	*p = x
+	return p
+}
+
+func (x StopOrderStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StopOrderStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_oms_proto_enumTypes[3].Descriptor()
+}
+
+func (StopOrderStatus) Type() protoreflect.EnumType {
+	return &file_api_proto_oms_proto_enumTypes[3]
+}
+
+func (x StopOrderStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StopOrderStatus.Descriptor instead.
+func (StopOrderStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{3}
+}
+
 type OrderStatus int32
 
 const (
-	OrderStatus_ORDER_STATUS_UNSPECIFIED OrderStatus = 0
-	OrderStatus_ORDER_STATUS_SUBMITTED   OrderStatus = 1
-	OrderStatus_ORDER_STATUS_FILLED      OrderStatus = 2
-	OrderStatus_ORDER_STATUS_CANCELED    OrderStatus = 3
-	OrderStatus_ORDER_STATUS_REJECTED    OrderStatus = 4
+	OrderStatus_ORDER_STATUS_UNSPECIFIED      OrderStatus = 0
+	OrderStatus_ORDER_STATUS_SUBMITTED        OrderStatus = 1
+	OrderStatus_ORDER_STATUS_FILLED           OrderStatus = 2
+	OrderStatus_ORDER_STATUS_CANCELED         OrderStatus = 3
+	OrderStatus_ORDER_STATUS_REJECTED         OrderStatus = 4
+	OrderStatus_ORDER_STATUS_PARTIALLY_FILLED OrderStatus = 5
 )
 
 // Enum value maps for OrderStatus.
@@ -138,13 +253,15 @@ var (
 		2: "ORDER_STATUS_FILLED",
 		3: "ORDER_STATUS_CANCELED",
 		4: "ORDER_STATUS_REJECTED",
+		5: "ORDER_STATUS_PARTIALLY_FILLED",
 	}
 	OrderStatus_value = map[string]int32{
-		"ORDER_STATUS_UNSPECIFIED": 0,
-		"ORDER_STATUS_SUBMITTED":   1,
-		"ORDER_STATUS_FILLED":      2,
-		"ORDER_STATUS_CANCELED":    3,
-		"ORDER_STATUS_REJECTED":    4,
+		"ORDER_STATUS_UNSPECIFIED":      0,
+		"ORDER_STATUS_SUBMITTED":        1,
+		"ORDER_STATUS_FILLED":           2,
+		"ORDER_STATUS_CANCELED":         3,
+		"ORDER_STATUS_REJECTED":         4,
+		"ORDER_STATUS_PARTIALLY_FILLED": 5,
 	}
 )
 
@@ -159,11 +276,11 @@ func (x OrderStatus) String() string {
 }
 
 func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_proto_oms_proto_enumTypes[2].Descriptor()
+	return file_api_proto_oms_proto_enumTypes[4].Descriptor()
 }
 
 func (OrderStatus) Type() protoreflect.EnumType {
-	return &file_api_proto_oms_proto_enumTypes[2]
+	return &file_api_proto_oms_proto_enumTypes[4]
 }
 
 func (x OrderStatus) Number() protoreflect.EnumNumber {
@@ -172,17 +289,310 @@ func (x OrderStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use OrderStatus.Descriptor instead.
 func (OrderStatus) EnumDescriptor() ([]byte, []int) {
-	return file_api_proto_oms_proto_rawDescGZIP(), []int{2}
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{4}
+}
+
+// BOTH is the net position of one-way mode; LONG and SHORT are the two
+// positions of hedge mode. Unspecified means BOTH.
+type PositionSide int32
+
+const (
+	PositionSide_POSITION_SIDE_UNSPECIFIED PositionSide = 0
+	PositionSide_POSITION_SIDE_BOTH        PositionSide = 1
+	PositionSide_POSITION_SIDE_LONG        PositionSide = 2
+	PositionSide_POSITION_SIDE_SHORT       PositionSide = 3
+)
+
+// Enum value maps for PositionSide.
+var (
+	PositionSide_name = map[int32]string{
+		0: "POSITION_SIDE_UNSPECIFIED",
+		1: "POSITION_SIDE_BOTH",
+		2: "POSITION_SIDE_LONG",
+		3: "POSITION_SIDE_SHORT",
+	}
+	PositionSide_value = map[string]int32{
+		"POSITION_SIDE_UNSPECIFIED": 0,
+		"POSITION_SIDE_BOTH":        1,
+		"POSITION_SIDE_LONG":        2,
+		"POSITION_SIDE_SHORT":       3,
+	}
+)
+
+func (x PositionSide) Enum() *PositionSide {
+	p := new(PositionSide)
+	*p = x
+	return p
+}
+
+func (x PositionSide) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PositionSide) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_oms_proto_enumTypes[5].Descriptor()
+}
+
+func (PositionSide) Type() protoreflect.EnumType {
+	return &file_api_proto_oms_proto_enumTypes[5]
+}
+
+func (x PositionSide) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PositionSide.Descriptor instead.
+func (PositionSide) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{5}
+}
+
+type PositionMode int32
+
+const (
+	PositionMode_POSITION_MODE_UNSPECIFIED PositionMode = 0
+	PositionMode_POSITION_MODE_ONE_WAY     PositionMode = 1
+	PositionMode_POSITION_MODE_HEDGE       PositionMode = 2
+)
+
+// Enum value maps for PositionMode.
+var (
+	PositionMode_name = map[int32]string{
+		0: "POSITION_MODE_UNSPECIFIED",
+		1: "POSITION_MODE_ONE_WAY",
+		2: "POSITION_MODE_HEDGE",
+	}
+	PositionMode_value = map[string]int32{
+		"POSITION_MODE_UNSPECIFIED": 0,
+		"POSITION_MODE_ONE_WAY":     1,
+		"POSITION_MODE_HEDGE":       2,
+	}
+)
+
+func (x PositionMode) Enum() *PositionMode {
+	p := new(PositionMode)
+	*p = x
+	return p
+}
+
+func (x PositionMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PositionMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_oms_proto_enumTypes[6].Descriptor()
+}
+
+func (PositionMode) Type() protoreflect.EnumType {
+	return &file_api_proto_oms_proto_enumTypes[6]
+}
+
+func (x PositionMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PositionMode.Descriptor instead.
+func (PositionMode) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{6}
+}
+
+type MarginMode int32
+
+const (
+	MarginMode_MARGIN_MODE_UNSPECIFIED MarginMode = 0
+	MarginMode_MARGIN_MODE_ISOLATED    MarginMode = 1
+	MarginMode_MARGIN_MODE_CROSS       MarginMode = 2
+)
+
+// Enum value maps for MarginMode.
+var (
+	MarginMode_name = map[int32]string{
+		0: "MARGIN_MODE_UNSPECIFIED",
+		1: "MARGIN_MODE_ISOLATED",
+		2: "MARGIN_MODE_CROSS",
+	}
+	MarginMode_value = map[string]int32{
+		"MARGIN_MODE_UNSPECIFIED": 0,
+		"MARGIN_MODE_ISOLATED":    1,
+		"MARGIN_MODE_CROSS":       2,
+	}
+)
+
+func (x MarginMode) Enum() *MarginMode {
+	p := new(MarginMode)
+	*p = x
+	return p
+}
+
+func (x MarginMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MarginMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_oms_proto_enumTypes[7].Descriptor()
+}
+
+func (MarginMode) Type() protoreflect.EnumType {
+	return &file_api_proto_oms_proto_enumTypes[7]
+}
+
+func (x MarginMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MarginMode.Descriptor instead.
+func (MarginMode) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{7}
+}
+
+// Why an order was rejected before reaching the book: the pre-trade check
+// that turned it away
+type RejectCode int32
+
+const (
+	RejectCode_REJECT_CODE_UNSPECIFIED        RejectCode = 0
+	RejectCode_REJECT_CODE_INSTRUMENT         RejectCode = 1
+	RejectCode_REJECT_CODE_POSITION_SIDE      RejectCode = 2
+	RejectCode_REJECT_CODE_REDUCE_ONLY        RejectCode = 3
+	RejectCode_REJECT_CODE_MAX_ORDER_QTY      RejectCode = 4
+	RejectCode_REJECT_CODE_MAX_ORDER_NOTIONAL RejectCode = 5
+	RejectCode_REJECT_CODE_PRICE_BAND         RejectCode = 6
+	RejectCode_REJECT_CODE_MAX_OPEN_ORDERS    RejectCode = 7
+	RejectCode_REJECT_CODE_RISK_LIMIT         RejectCode = 8
+	RejectCode_REJECT_CODE_MARGIN             RejectCode = 9
+	RejectCode_REJECT_CODE_OTHER              RejectCode = 10
+	RejectCode_REJECT_CODE_STOP_PRICE         RejectCode = 11
+)
+
+// Enum value maps for RejectCode.
+var (
+	RejectCode_name = map[int32]string{
+		0:  "REJECT_CODE_UNSPECIFIED",
+		1:  "REJECT_CODE_INSTRUMENT",
+		2:  "REJECT_CODE_POSITION_SIDE",
+		3:  "REJECT_CODE_REDUCE_ONLY",
+		4:  "REJECT_CODE_MAX_ORDER_QTY",
+		5:  "REJECT_CODE_MAX_ORDER_NOTIONAL",
+		6:  "REJECT_CODE_PRICE_BAND",
+		7:  "REJECT_CODE_MAX_OPEN_ORDERS",
+		8:  "REJECT_CODE_RISK_LIMIT",
+		9:  "REJECT_CODE_MARGIN",
+		10: "REJECT_CODE_OTHER",
+		11: "REJECT_CODE_STOP_PRICE",
+	}
+	RejectCode_value = map[string]int32{
+		"REJECT_CODE_UNSPECIFIED":        0,
+		"REJECT_CODE_INSTRUMENT":         1,
+		"REJECT_CODE_POSITION_SIDE":      2,
+		"REJECT_CODE_REDUCE_ONLY":        3,
+		"REJECT_CODE_MAX_ORDER_QTY":      4,
+		"REJECT_CODE_MAX_ORDER_NOTIONAL": 5,
+		"REJECT_CODE_PRICE_BAND":         6,
+		"REJECT_CODE_MAX_OPEN_ORDERS":    7,
+		"REJECT_CODE_RISK_LIMIT":         8,
+		"REJECT_CODE_MARGIN":             9,
+		"REJECT_CODE_OTHER":              10,
+		"REJECT_CODE_STOP_PRICE":         11,
+	}
+)
+
+func (x RejectCode) Enum() *RejectCode {
+	p := new(RejectCode)
+	*p = x
+	return p
+}
+
+func (x RejectCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RejectCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_oms_proto_enumTypes[8].Descriptor()
+}
+
+func (RejectCode) Type() protoreflect.EnumType {
+	return &file_api_proto_oms_proto_enumTypes[8]
+}
+
+func (x RejectCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RejectCode.Descriptor instead.
+func (RejectCode) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{8}
+}
+
+type InstrumentStatus int32
+
+const (
+	InstrumentStatus_INSTRUMENT_STATUS_UNSPECIFIED InstrumentStatus = 0
+	InstrumentStatus_INSTRUMENT_STATUS_TRADING     InstrumentStatus = 1
+	InstrumentStatus_INSTRUMENT_STATUS_HALTED      InstrumentStatus = 2
+	InstrumentStatus_INSTRUMENT_STATUS_DELISTED    InstrumentStatus = 3
+)
+
+// Enum value maps for InstrumentStatus.
+var (
+	InstrumentStatus_name = map[int32]string{
+		0: "INSTRUMENT_STATUS_UNSPECIFIED",
+		1: "INSTRUMENT_STATUS_TRADING",
+		2: "INSTRUMENT_STATUS_HALTED",
+		3: "INSTRUMENT_STATUS_DELISTED",
+	}
+	InstrumentStatus_value = map[string]int32{
+		"INSTRUMENT_STATUS_UNSPECIFIED": 0,
+		"INSTRUMENT_STATUS_TRADING":     1,
+		"INSTRUMENT_STATUS_HALTED":      2,
+		"INSTRUMENT_STATUS_DELISTED":    3,
+	}
+)
+
+func (x InstrumentStatus) Enum() *InstrumentStatus {
+	p := new(InstrumentStatus)
+	*p = x
+	return p
+}
+
+func (x InstrumentStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InstrumentStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_oms_proto_enumTypes[9].Descriptor()
+}
+
+func (InstrumentStatus) Type() protoreflect.EnumType {
+	return &file_api_proto_oms_proto_enumTypes[9]
+}
+
+func (x InstrumentStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InstrumentStatus.Descriptor instead.
+func (InstrumentStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{9}
 }
 
 type CreateOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Side          Side                   `protobuf:"varint,3,opt,name=side,proto3,enum=oms.v1.Side" json:"side,omitempty"`
-	Type          OrderType              `protobuf:"varint,4,opt,name=type,proto3,enum=oms.v1.OrderType" json:"type,omitempty"`
-	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
-	Quantity      float64                `protobuf:"fixed64,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol   string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side     Side                   `protobuf:"varint,3,opt,name=side,proto3,enum=oms.v1.Side" json:"side,omitempty"`
+	Type     OrderType              `protobuf:"varint,4,opt,name=type,proto3,enum=oms.v1.OrderType" json:"type,omitempty"`
+	Price    string                 `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity string                 `protobuf:"bytes,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Required in hedge mode: the position the order opens or closes
+	PositionSide PositionSide `protobuf:"varint,7,opt,name=position_side,json=positionSide,proto3,enum=oms.v1.PositionSide" json:"position_side,omitempty"`
+	// Only reduce the position; a resting order is cut down or canceled as
+	// the position shrinks
+	ReduceOnly bool `protobuf:"varint,8,opt,name=reduce_only,json=reduceOnly,proto3" json:"reduce_only,omitempty"`
+	// Reduce-only for the whole position; quantity is ignored
+	ClosePosition bool `protobuf:"varint,9,opt,name=close_position,json=closePosition,proto3" json:"close_position,omitempty"`
+	// Required for stop orders: the order is submitted, as a market order
+	// or a limit order at price, once the trigger price reaches it
+	StopPrice     string           `protobuf:"bytes,10,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`
+	TriggerBy     TriggerPriceType `protobuf:"varint,11,opt,name=trigger_by,json=triggerBy,proto3,enum=oms.v1.TriggerPriceType" json:"trigger_by,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -245,24 +655,62 @@ func (x *CreateOrderRequest) GetType() OrderType {
 	return OrderType_ORDER_TYPE_UNSPECIFIED
 }
 
-func (x *CreateOrderRequest) GetPrice() float64 {
+func (x *CreateOrderRequest) GetPrice() string {
 	if x != nil {
 		return x.Price
 	}
-	return 0
+	return ""
 }
 
-func (x *CreateOrderRequest) GetQuantity() float64 {
+func (x *CreateOrderRequest) GetQuantity() string {
 	if x != nil {
 		return x.Quantity
 	}
-	return 0
+	return ""
+}
+
+func (x *CreateOrderRequest) GetPositionSide() PositionSide {
+	if x != nil {
+		return x.PositionSide
+	}
+	return PositionSide_POSITION_SIDE_UNSPECIFIED
+}
+
+func (x *CreateOrderRequest) GetReduceOnly() bool {
+	if x != nil {
+		return x.ReduceOnly
+	}
+	return false
+}
+
+func (x *CreateOrderRequest) GetClosePosition() bool {
+	if x != nil {
+		return x.ClosePosition
+	}
+	return false
+}
+
+func (x *CreateOrderRequest) GetStopPrice() string {
+	if x != nil {
+		return x.StopPrice
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetTriggerBy() TriggerPriceType {
+	if x != nil {
+		return x.TriggerBy
+	}
+	return TriggerPriceType_TRIGGER_PRICE_TYPE_UNSPECIFIED
 }
 
 type CreateOrderResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Status        OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=oms.v1.OrderStatus" json:"status,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status  OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=oms.v1.OrderStatus" json:"status,omitempty"`
+	// Set when status is ORDER_STATUS_REJECTED
+	RejectReason  string     `protobuf:"bytes,3,opt,name=reject_reason,json=rejectReason,proto3" json:"reject_reason,omitempty"`
+	RejectCode    RejectCode `protobuf:"varint,4,opt,name=reject_code,json=rejectCode,proto3,enum=oms.v1.RejectCode" json:"reject_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -311,9 +759,24 @@ func (x *CreateOrderResponse) GetStatus() OrderStatus {
 	return OrderStatus_ORDER_STATUS_UNSPECIFIED
 }
 
+func (x *CreateOrderResponse) GetRejectReason() string {
+	if x != nil {
+		return x.RejectReason
+	}
+	return ""
+}
+
+func (x *CreateOrderResponse) GetRejectCode() RejectCode {
+	if x != nil {
+		return x.RejectCode
+	}
+	return RejectCode_REJECT_CODE_UNSPECIFIED
+}
+
 type CancelOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -355,6 +818,13 @@ func (x *CancelOrderRequest) GetOrderId() int64 {
 	return 0
 }
 
+func (x *CancelOrderRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
 type CancelOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -399,27 +869,33 @@ func (x *CancelOrderResponse) GetSuccess() bool {
 	return false
 }
 
-type GetOrderRequest struct {
+// Amend (cancel-replace) a resting order.
+// Reducing quantity at the same price keeps time priority;
+// changing price or increasing quantity loses it.
+type AmendOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Price         string                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      string                 `protobuf:"bytes,4,opt,name=quantity,proto3" json:"quantity,omitempty"` // new total quantity, including any filled part
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrderRequest) Reset() {
-	*x = GetOrderRequest{}
+func (x *AmendOrderRequest) Reset() {
+	*x = AmendOrderRequest{}
 	mi := &file_api_proto_oms_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderRequest) String() string {
+func (x *AmendOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderRequest) ProtoMessage() {}
+func (*AmendOrderRequest) ProtoMessage() {}
 
-func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+func (x *AmendOrderRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_oms_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -431,49 +907,2761 @@ func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
-func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AmendOrderRequest.ProtoReflect.Descriptor instead.
+func (*AmendOrderRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_oms_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetOrderRequest) GetOrderId() int64 {
+func (x *AmendOrderRequest) GetOrderId() int64 {
 	if x != nil {
 		return x.OrderId
 	}
 	return 0
 }
 
-type GetOrderResponse struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	OrderId          int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	UserId           int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Symbol           string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Side             Side                   `protobuf:"varint,4,opt,name=side,proto3,enum=oms.v1.Side" json:"side,omitempty"`
-	Type             OrderType              `protobuf:"varint,5,opt,name=type,proto3,enum=oms.v1.OrderType" json:"type,omitempty"`
-	Price            float64                `protobuf:"fixed64,6,opt,name=price,proto3" json:"price,omitempty"`
-	Quantity         float64                `protobuf:"fixed64,7,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	ExecutedQuantity float64                `protobuf:"fixed64,8,opt,name=executed_quantity,json=executedQuantity,proto3" json:"executed_quantity,omitempty"`
-	Status           OrderStatus            `protobuf:"varint,9,opt,name=status,proto3,enum=oms.v1.OrderStatus" json:"status,omitempty"`
-	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
-}
-
+func (x *AmendOrderRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AmendOrderRequest) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *AmendOrderRequest) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+type AmendOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status        OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=oms.v1.OrderStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AmendOrderResponse) Reset() {
+	*x = AmendOrderResponse{}
+	mi := &file_api_proto_oms_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AmendOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmendOrderResponse) ProtoMessage() {}
+
+func (x *AmendOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmendOrderResponse.ProtoReflect.Descriptor instead.
+func (*AmendOrderResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AmendOrderResponse) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *AmendOrderResponse) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_api_proto_oms_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetOrderRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+type GetOrderResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OrderId          int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId           int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol           string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side             Side                   `protobuf:"varint,4,opt,name=side,proto3,enum=oms.v1.Side" json:"side,omitempty"`
+	Type             OrderType              `protobuf:"varint,5,opt,name=type,proto3,enum=oms.v1.OrderType" json:"type,omitempty"`
+	Price            string                 `protobuf:"bytes,6,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity         string                 `protobuf:"bytes,7,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ExecutedQuantity string                 `protobuf:"bytes,8,opt,name=executed_quantity,json=executedQuantity,proto3" json:"executed_quantity,omitempty"`
+	Status           OrderStatus            `protobuf:"varint,9,opt,name=status,proto3,enum=oms.v1.OrderStatus" json:"status,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
 func (x *GetOrderResponse) Reset() {
 	*x = GetOrderResponse{}
-	mi := &file_api_proto_oms_proto_msgTypes[5]
+	mi := &file_api_proto_oms_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderResponse) ProtoMessage() {}
+
+func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetOrderResponse) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *GetOrderResponse) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetOrderResponse) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetOrderResponse) GetSide() Side {
+	if x != nil {
+		return x.Side
+	}
+	return Side_SIDE_UNSPECIFIED
+}
+
+func (x *GetOrderResponse) GetType() OrderType {
+	if x != nil {
+		return x.Type
+	}
+	return OrderType_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *GetOrderResponse) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *GetOrderResponse) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *GetOrderResponse) GetExecutedQuantity() string {
+	if x != nil {
+		return x.ExecutedQuantity
+	}
+	return ""
+}
+
+func (x *GetOrderResponse) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *GetOrderResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Stop orders still waiting for their trigger price
+type GetStopOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"` // empty for all symbols
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStopOrdersRequest) Reset() {
+	*x = GetStopOrdersRequest{}
+	mi := &file_api_proto_oms_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStopOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStopOrdersRequest) ProtoMessage() {}
+
+func (x *GetStopOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStopOrdersRequest.ProtoReflect.Descriptor instead.
+func (*GetStopOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetStopOrdersRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetStopOrdersRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type StopOrder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StopId        int64                  `protobuf:"varint,1,opt,name=stop_id,json=stopId,proto3" json:"stop_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side          Side                   `protobuf:"varint,4,opt,name=side,proto3,enum=oms.v1.Side" json:"side,omitempty"`
+	Type          OrderType              `protobuf:"varint,5,opt,name=type,proto3,enum=oms.v1.OrderType" json:"type,omitempty"`
+	PositionSide  PositionSide           `protobuf:"varint,6,opt,name=position_side,json=positionSide,proto3,enum=oms.v1.PositionSide" json:"position_side,omitempty"`
+	StopPrice     string                 `protobuf:"bytes,7,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`
+	TriggerBy     TriggerPriceType       `protobuf:"varint,8,opt,name=trigger_by,json=triggerBy,proto3,enum=oms.v1.TriggerPriceType" json:"trigger_by,omitempty"`
+	Price         string                 `protobuf:"bytes,9,opt,name=price,proto3" json:"price,omitempty"` // limit price of a stop-limit order
+	Quantity      string                 `protobuf:"bytes,10,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ReduceOnly    bool                   `protobuf:"varint,11,opt,name=reduce_only,json=reduceOnly,proto3" json:"reduce_only,omitempty"`
+	ClosePosition bool                   `protobuf:"varint,12,opt,name=close_position,json=closePosition,proto3" json:"close_position,omitempty"`
+	Status        StopOrderStatus        `protobuf:"varint,13,opt,name=status,proto3,enum=oms.v1.StopOrderStatus" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopOrder) Reset() {
+	*x = StopOrder{}
+	mi := &file_api_proto_oms_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopOrder) ProtoMessage() {}
+
+func (x *StopOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopOrder.ProtoReflect.Descriptor instead.
+func (*StopOrder) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StopOrder) GetStopId() int64 {
+	if x != nil {
+		return x.StopId
+	}
+	return 0
+}
+
+func (x *StopOrder) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *StopOrder) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *StopOrder) GetSide() Side {
+	if x != nil {
+		return x.Side
+	}
+	return Side_SIDE_UNSPECIFIED
+}
+
+func (x *StopOrder) GetType() OrderType {
+	if x != nil {
+		return x.Type
+	}
+	return OrderType_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *StopOrder) GetPositionSide() PositionSide {
+	if x != nil {
+		return x.PositionSide
+	}
+	return PositionSide_POSITION_SIDE_UNSPECIFIED
+}
+
+func (x *StopOrder) GetStopPrice() string {
+	if x != nil {
+		return x.StopPrice
+	}
+	return ""
+}
+
+func (x *StopOrder) GetTriggerBy() TriggerPriceType {
+	if x != nil {
+		return x.TriggerBy
+	}
+	return TriggerPriceType_TRIGGER_PRICE_TYPE_UNSPECIFIED
+}
+
+func (x *StopOrder) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *StopOrder) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *StopOrder) GetReduceOnly() bool {
+	if x != nil {
+		return x.ReduceOnly
+	}
+	return false
+}
+
+func (x *StopOrder) GetClosePosition() bool {
+	if x != nil {
+		return x.ClosePosition
+	}
+	return false
+}
+
+func (x *StopOrder) GetStatus() StopOrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return StopOrderStatus_STOP_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *StopOrder) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetStopOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StopOrders    []*StopOrder           `protobuf:"bytes,1,rep,name=stop_orders,json=stopOrders,proto3" json:"stop_orders,omitempty"` // oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStopOrdersResponse) Reset() {
+	*x = GetStopOrdersResponse{}
+	mi := &file_api_proto_oms_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStopOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStopOrdersResponse) ProtoMessage() {}
+
+func (x *GetStopOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStopOrdersResponse.ProtoReflect.Descriptor instead.
+func (*GetStopOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetStopOrdersResponse) GetStopOrders() []*StopOrder {
+	if x != nil {
+		return x.StopOrders
+	}
+	return nil
+}
+
+type GetPositionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	PositionSide  PositionSide           `protobuf:"varint,3,opt,name=position_side,json=positionSide,proto3,enum=oms.v1.PositionSide" json:"position_side,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPositionRequest) Reset() {
+	*x = GetPositionRequest{}
+	mi := &file_api_proto_oms_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPositionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPositionRequest) ProtoMessage() {}
+
+func (x *GetPositionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPositionRequest.ProtoReflect.Descriptor instead.
+func (*GetPositionRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetPositionRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetPositionRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetPositionRequest) GetPositionSide() PositionSide {
+	if x != nil {
+		return x.PositionSide
+	}
+	return PositionSide_POSITION_SIDE_UNSPECIFIED
+}
+
+type GetPositionResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	UserId           int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol           string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Quantity         string                 `protobuf:"bytes,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	EntryPrice       string                 `protobuf:"bytes,4,opt,name=entry_price,json=entryPrice,proto3" json:"entry_price,omitempty"`
+	Margin           string                 `protobuf:"bytes,5,opt,name=margin,proto3" json:"margin,omitempty"`
+	Leverage         string                 `protobuf:"bytes,6,opt,name=leverage,proto3" json:"leverage,omitempty"`
+	UnrealizedPnl    string                 `protobuf:"bytes,7,opt,name=unrealized_pnl,json=unrealizedPnl,proto3" json:"unrealized_pnl,omitempty"`
+	RealizedPnl      string                 `protobuf:"bytes,8,opt,name=realized_pnl,json=realizedPnl,proto3" json:"realized_pnl,omitempty"`
+	PositionSide     PositionSide           `protobuf:"varint,9,opt,name=position_side,json=positionSide,proto3,enum=oms.v1.PositionSide" json:"position_side,omitempty"`
+	MarkPrice        string                 `protobuf:"bytes,10,opt,name=mark_price,json=markPrice,proto3" json:"mark_price,omitempty"` // unrealized_pnl is valued at this price
+	LiquidationPrice string                 `protobuf:"bytes,11,opt,name=liquidation_price,json=liquidationPrice,proto3" json:"liquidation_price,omitempty"`
+	BankruptcyPrice  string                 `protobuf:"bytes,12,opt,name=bankruptcy_price,json=bankruptcyPrice,proto3" json:"bankruptcy_price,omitempty"`
+	AdlQuantile      int32                  `protobuf:"varint,13,opt,name=adl_quantile,json=adlQuantile,proto3" json:"adl_quantile,omitempty"`               // 1-5, 5 is deleveraged first; 0 when not in the queue
+	LiquidationState string                 `protobuf:"bytes,14,opt,name=liquidation_state,json=liquidationState,proto3" json:"liquidation_state,omitempty"` // NORMAL, LIQUIDATING (only reducing orders accepted), ADL or CLOSED
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetPositionResponse) Reset() {
+	*x = GetPositionResponse{}
+	mi := &file_api_proto_oms_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPositionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPositionResponse) ProtoMessage() {}
+
+func (x *GetPositionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPositionResponse.ProtoReflect.Descriptor instead.
+func (*GetPositionResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetPositionResponse) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetPositionResponse) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetPositionResponse) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *GetPositionResponse) GetEntryPrice() string {
+	if x != nil {
+		return x.EntryPrice
+	}
+	return ""
+}
+
+func (x *GetPositionResponse) GetMargin() string {
+	if x != nil {
+		return x.Margin
+	}
+	return ""
+}
+
+func (x *GetPositionResponse) GetLeverage() string {
+	if x != nil {
+		return x.Leverage
+	}
+	return ""
+}
+
+func (x *GetPositionResponse) GetUnrealizedPnl() string {
+	if x != nil {
+		return x.UnrealizedPnl
+	}
+	return ""
+}
+
+func (x *GetPositionResponse) GetRealizedPnl() string {
+	if x != nil {
+		return x.RealizedPnl
+	}
+	return ""
+}
+
+func (x *GetPositionResponse) GetPositionSide() PositionSide {
+	if x != nil {
+		return x.PositionSide
+	}
+	return PositionSide_POSITION_SIDE_UNSPECIFIED
+}
+
+func (x *GetPositionResponse) GetMarkPrice() string {
+	if x != nil {
+		return x.MarkPrice
+	}
+	return ""
+}
+
+func (x *GetPositionResponse) GetLiquidationPrice() string {
+	if x != nil {
+		return x.LiquidationPrice
+	}
+	return ""
+}
+
+func (x *GetPositionResponse) GetBankruptcyPrice() string {
+	if x != nil {
+		return x.BankruptcyPrice
+	}
+	return ""
+}
+
+func (x *GetPositionResponse) GetAdlQuantile() int32 {
+	if x != nil {
+		return x.AdlQuantile
+	}
+	return 0
+}
+
+func (x *GetPositionResponse) GetLiquidationState() string {
+	if x != nil {
+		return x.LiquidationState
+	}
+	return ""
+}
+
+type SetLeverageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Leverage      string                 `protobuf:"bytes,3,opt,name=leverage,proto3" json:"leverage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLeverageRequest) Reset() {
+	*x = SetLeverageRequest{}
+	mi := &file_api_proto_oms_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLeverageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLeverageRequest) ProtoMessage() {}
+
+func (x *SetLeverageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLeverageRequest.ProtoReflect.Descriptor instead.
+func (*SetLeverageRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SetLeverageRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetLeverageRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *SetLeverageRequest) GetLeverage() string {
+	if x != nil {
+		return x.Leverage
+	}
+	return ""
+}
+
+type SetLeverageResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol   string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Leverage string                 `protobuf:"bytes,3,opt,name=leverage,proto3" json:"leverage,omitempty"`
+	// Position margin after the change, both sides combined in hedge mode
+	Margin        string `protobuf:"bytes,4,opt,name=margin,proto3" json:"margin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLeverageResponse) Reset() {
+	*x = SetLeverageResponse{}
+	mi := &file_api_proto_oms_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLeverageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLeverageResponse) ProtoMessage() {}
+
+func (x *SetLeverageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLeverageResponse.ProtoReflect.Descriptor instead.
+func (*SetLeverageResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SetLeverageResponse) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetLeverageResponse) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *SetLeverageResponse) GetLeverage() string {
+	if x != nil {
+		return x.Leverage
+	}
+	return ""
+}
+
+func (x *SetLeverageResponse) GetMargin() string {
+	if x != nil {
+		return x.Margin
+	}
+	return ""
+}
+
+type SetPositionModeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Mode          PositionMode           `protobuf:"varint,2,opt,name=mode,proto3,enum=oms.v1.PositionMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPositionModeRequest) Reset() {
+	*x = SetPositionModeRequest{}
+	mi := &file_api_proto_oms_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPositionModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPositionModeRequest) ProtoMessage() {}
+
+func (x *SetPositionModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPositionModeRequest.ProtoReflect.Descriptor instead.
+func (*SetPositionModeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetPositionModeRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetPositionModeRequest) GetMode() PositionMode {
+	if x != nil {
+		return x.Mode
+	}
+	return PositionMode_POSITION_MODE_UNSPECIFIED
+}
+
+type SetPositionModeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Mode          PositionMode           `protobuf:"varint,2,opt,name=mode,proto3,enum=oms.v1.PositionMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPositionModeResponse) Reset() {
+	*x = SetPositionModeResponse{}
+	mi := &file_api_proto_oms_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPositionModeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPositionModeResponse) ProtoMessage() {}
+
+func (x *SetPositionModeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPositionModeResponse.ProtoReflect.Descriptor instead.
+func (*SetPositionModeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetPositionModeResponse) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetPositionModeResponse) GetMode() PositionMode {
+	if x != nil {
+		return x.Mode
+	}
+	return PositionMode_POSITION_MODE_UNSPECIFIED
+}
+
+type SetMarginModeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Mode          MarginMode             `protobuf:"varint,2,opt,name=mode,proto3,enum=oms.v1.MarginMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMarginModeRequest) Reset() {
+	*x = SetMarginModeRequest{}
+	mi := &file_api_proto_oms_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMarginModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMarginModeRequest) ProtoMessage() {}
+
+func (x *SetMarginModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMarginModeRequest.ProtoReflect.Descriptor instead.
+func (*SetMarginModeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SetMarginModeRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetMarginModeRequest) GetMode() MarginMode {
+	if x != nil {
+		return x.Mode
+	}
+	return MarginMode_MARGIN_MODE_UNSPECIFIED
+}
+
+type SetMarginModeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Mode          MarginMode             `protobuf:"varint,2,opt,name=mode,proto3,enum=oms.v1.MarginMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMarginModeResponse) Reset() {
+	*x = SetMarginModeResponse{}
+	mi := &file_api_proto_oms_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMarginModeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMarginModeResponse) ProtoMessage() {}
+
+func (x *SetMarginModeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMarginModeResponse.ProtoReflect.Descriptor instead.
+func (*SetMarginModeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetMarginModeResponse) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetMarginModeResponse) GetMode() MarginMode {
+	if x != nil {
+		return x.Mode
+	}
+	return MarginMode_MARGIN_MODE_UNSPECIFIED
+}
+
+// Notional bracket of a symbol's risk limits
+type RiskTier struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Tier                  int32                  `protobuf:"varint,1,opt,name=tier,proto3" json:"tier,omitempty"`                                 // 1-based
+	MaxNotional           string                 `protobuf:"bytes,2,opt,name=max_notional,json=maxNotional,proto3" json:"max_notional,omitempty"` // 0 = unlimited
+	MaintenanceMarginRate string                 `protobuf:"bytes,3,opt,name=maintenance_margin_rate,json=maintenanceMarginRate,proto3" json:"maintenance_margin_rate,omitempty"`
+	MaintenanceAmount     string                 `protobuf:"bytes,4,opt,name=maintenance_amount,json=maintenanceAmount,proto3" json:"maintenance_amount,omitempty"`
+	MaxLeverage           string                 `protobuf:"bytes,5,opt,name=max_leverage,json=maxLeverage,proto3" json:"max_leverage,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *RiskTier) Reset() {
+	*x = RiskTier{}
+	mi := &file_api_proto_oms_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RiskTier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RiskTier) ProtoMessage() {}
+
+func (x *RiskTier) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RiskTier.ProtoReflect.Descriptor instead.
+func (*RiskTier) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RiskTier) GetTier() int32 {
+	if x != nil {
+		return x.Tier
+	}
+	return 0
+}
+
+func (x *RiskTier) GetMaxNotional() string {
+	if x != nil {
+		return x.MaxNotional
+	}
+	return ""
+}
+
+func (x *RiskTier) GetMaintenanceMarginRate() string {
+	if x != nil {
+		return x.MaintenanceMarginRate
+	}
+	return ""
+}
+
+func (x *RiskTier) GetMaintenanceAmount() string {
+	if x != nil {
+		return x.MaintenanceAmount
+	}
+	return ""
+}
+
+func (x *RiskTier) GetMaxLeverage() string {
+	if x != nil {
+		return x.MaxLeverage
+	}
+	return ""
+}
+
+type GetRiskLimitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRiskLimitRequest) Reset() {
+	*x = GetRiskLimitRequest{}
+	mi := &file_api_proto_oms_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRiskLimitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRiskLimitRequest) ProtoMessage() {}
+
+func (x *GetRiskLimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRiskLimitRequest.ProtoReflect.Descriptor instead.
+func (*GetRiskLimitRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetRiskLimitRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetRiskLimitRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type GetRiskLimitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Tiers         []*RiskTier            `protobuf:"bytes,3,rep,name=tiers,proto3" json:"tiers,omitempty"`
+	SelectedTier  int32                  `protobuf:"varint,4,opt,name=selected_tier,json=selectedTier,proto3" json:"selected_tier,omitempty"` // tier positions are capped at, 0 = uncapped
+	CurrentTier   int32                  `protobuf:"varint,5,opt,name=current_tier,json=currentTier,proto3" json:"current_tier,omitempty"`    // tier the largest position falls in at the mark price
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRiskLimitResponse) Reset() {
+	*x = GetRiskLimitResponse{}
+	mi := &file_api_proto_oms_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRiskLimitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRiskLimitResponse) ProtoMessage() {}
+
+func (x *GetRiskLimitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRiskLimitResponse.ProtoReflect.Descriptor instead.
+func (*GetRiskLimitResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetRiskLimitResponse) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetRiskLimitResponse) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetRiskLimitResponse) GetTiers() []*RiskTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+func (x *GetRiskLimitResponse) GetSelectedTier() int32 {
+	if x != nil {
+		return x.SelectedTier
+	}
+	return 0
+}
+
+func (x *GetRiskLimitResponse) GetCurrentTier() int32 {
+	if x != nil {
+		return x.CurrentTier
+	}
+	return 0
+}
+
+type SetRiskLimitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Tier          int32                  `protobuf:"varint,3,opt,name=tier,proto3" json:"tier,omitempty"` // 0 lifts the cap
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRiskLimitRequest) Reset() {
+	*x = SetRiskLimitRequest{}
+	mi := &file_api_proto_oms_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRiskLimitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRiskLimitRequest) ProtoMessage() {}
+
+func (x *SetRiskLimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRiskLimitRequest.ProtoReflect.Descriptor instead.
+func (*SetRiskLimitRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SetRiskLimitRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetRiskLimitRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *SetRiskLimitRequest) GetTier() int32 {
+	if x != nil {
+		return x.Tier
+	}
+	return 0
+}
+
+type SetRiskLimitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Tier          int32                  `protobuf:"varint,3,opt,name=tier,proto3" json:"tier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRiskLimitResponse) Reset() {
+	*x = SetRiskLimitResponse{}
+	mi := &file_api_proto_oms_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRiskLimitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRiskLimitResponse) ProtoMessage() {}
+
+func (x *SetRiskLimitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRiskLimitResponse.ProtoReflect.Descriptor instead.
+func (*SetRiskLimitResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SetRiskLimitResponse) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetRiskLimitResponse) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *SetRiskLimitResponse) GetTier() int32 {
+	if x != nil {
+		return x.Tier
+	}
+	return 0
+}
+
+// Contract specification of a tradable symbol
+type Instrument struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Symbol                string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	BaseAsset             string                 `protobuf:"bytes,2,opt,name=base_asset,json=baseAsset,proto3" json:"base_asset,omitempty"`
+	SettleAsset           string                 `protobuf:"bytes,3,opt,name=settle_asset,json=settleAsset,proto3" json:"settle_asset,omitempty"`
+	TickSize              string                 `protobuf:"bytes,4,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	LotSize               string                 `protobuf:"bytes,5,opt,name=lot_size,json=lotSize,proto3" json:"lot_size,omitempty"`
+	MinQty                string                 `protobuf:"bytes,6,opt,name=min_qty,json=minQty,proto3" json:"min_qty,omitempty"`
+	MaxQty                string                 `protobuf:"bytes,7,opt,name=max_qty,json=maxQty,proto3" json:"max_qty,omitempty"`
+	MinNotional           string                 `protobuf:"bytes,8,opt,name=min_notional,json=minNotional,proto3" json:"min_notional,omitempty"`
+	MaxLeverage           string                 `protobuf:"bytes,9,opt,name=max_leverage,json=maxLeverage,proto3" json:"max_leverage,omitempty"`
+	MaintenanceMarginRate string                 `protobuf:"bytes,10,opt,name=maintenance_margin_rate,json=maintenanceMarginRate,proto3" json:"maintenance_margin_rate,omitempty"`
+	Status                InstrumentStatus       `protobuf:"varint,11,opt,name=status,proto3,enum=oms.v1.InstrumentStatus" json:"status,omitempty"`
+	LiquidationStep       string                 `protobuf:"bytes,12,opt,name=liquidation_step,json=liquidationStep,proto3" json:"liquidation_step,omitempty"` // max quantity per liquidation order; empty or 0 closes at once
+	RiskTiers             []*RiskTier            `protobuf:"bytes,13,rep,name=risk_tiers,json=riskTiers,proto3" json:"risk_tiers,omitempty"`                   // empty = one unbounded tier at maintenance_margin_rate and max_leverage
+	FundingRateCap        string                 `protobuf:"bytes,14,opt,name=funding_rate_cap,json=fundingRateCap,proto3" json:"funding_rate_cap,omitempty"`  // max absolute funding rate per interval; empty or 0 uses the default
+	MakerFeeRate          string                 `protobuf:"bytes,15,opt,name=maker_fee_rate,json=makerFeeRate,proto3" json:"maker_fee_rate,omitempty"`        // VIP 0 rate; negative = rebate. Higher VIP levels pay the lower of this and their own; both empty or 0 follows the VIP schedule
+	TakerFeeRate          string                 `protobuf:"bytes,16,opt,name=taker_fee_rate,json=takerFeeRate,proto3" json:"taker_fee_rate,omitempty"`
+	MaxPrice              string                 `protobuf:"bytes,17,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`                 // highest limit price; empty or 0 for no cap
+	PriceDecimals         int32                  `protobuf:"varint,18,opt,name=price_decimals,json=priceDecimals,proto3" json:"price_decimals,omitempty"` // decimals prices are quoted in; 0 = those of tick_size
+	QtyDecimals           int32                  `protobuf:"varint,19,opt,name=qty_decimals,json=qtyDecimals,proto3" json:"qty_decimals,omitempty"`       // decimals quantities are quoted in; 0 = those of lot_size
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Instrument) Reset() {
+	*x = Instrument{}
+	mi := &file_api_proto_oms_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Instrument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Instrument) ProtoMessage() {}
+
+func (x *Instrument) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Instrument.ProtoReflect.Descriptor instead.
+func (*Instrument) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Instrument) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Instrument) GetBaseAsset() string {
+	if x != nil {
+		return x.BaseAsset
+	}
+	return ""
+}
+
+func (x *Instrument) GetSettleAsset() string {
+	if x != nil {
+		return x.SettleAsset
+	}
+	return ""
+}
+
+func (x *Instrument) GetTickSize() string {
+	if x != nil {
+		return x.TickSize
+	}
+	return ""
+}
+
+func (x *Instrument) GetLotSize() string {
+	if x != nil {
+		return x.LotSize
+	}
+	return ""
+}
+
+func (x *Instrument) GetMinQty() string {
+	if x != nil {
+		return x.MinQty
+	}
+	return ""
+}
+
+func (x *Instrument) GetMaxQty() string {
+	if x != nil {
+		return x.MaxQty
+	}
+	return ""
+}
+
+func (x *Instrument) GetMinNotional() string {
+	if x != nil {
+		return x.MinNotional
+	}
+	return ""
+}
+
+func (x *Instrument) GetMaxLeverage() string {
+	if x != nil {
+		return x.MaxLeverage
+	}
+	return ""
+}
+
+func (x *Instrument) GetMaintenanceMarginRate() string {
+	if x != nil {
+		return x.MaintenanceMarginRate
+	}
+	return ""
+}
+
+func (x *Instrument) GetStatus() InstrumentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return InstrumentStatus_INSTRUMENT_STATUS_UNSPECIFIED
+}
+
+func (x *Instrument) GetLiquidationStep() string {
+	if x != nil {
+		return x.LiquidationStep
+	}
+	return ""
+}
+
+func (x *Instrument) GetRiskTiers() []*RiskTier {
+	if x != nil {
+		return x.RiskTiers
+	}
+	return nil
+}
+
+func (x *Instrument) GetFundingRateCap() string {
+	if x != nil {
+		return x.FundingRateCap
+	}
+	return ""
+}
+
+func (x *Instrument) GetMakerFeeRate() string {
+	if x != nil {
+		return x.MakerFeeRate
+	}
+	return ""
+}
+
+func (x *Instrument) GetTakerFeeRate() string {
+	if x != nil {
+		return x.TakerFeeRate
+	}
+	return ""
+}
+
+func (x *Instrument) GetMaxPrice() string {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return ""
+}
+
+func (x *Instrument) GetPriceDecimals() int32 {
+	if x != nil {
+		return x.PriceDecimals
+	}
+	return 0
+}
+
+func (x *Instrument) GetQtyDecimals() int32 {
+	if x != nil {
+		return x.QtyDecimals
+	}
+	return 0
+}
+
+type UpsertInstrumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instrument    *Instrument            `protobuf:"bytes,1,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertInstrumentRequest) Reset() {
+	*x = UpsertInstrumentRequest{}
+	mi := &file_api_proto_oms_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertInstrumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertInstrumentRequest) ProtoMessage() {}
+
+func (x *UpsertInstrumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertInstrumentRequest.ProtoReflect.Descriptor instead.
+func (*UpsertInstrumentRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UpsertInstrumentRequest) GetInstrument() *Instrument {
+	if x != nil {
+		return x.Instrument
+	}
+	return nil
+}
+
+type UpsertInstrumentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instrument    *Instrument            `protobuf:"bytes,1,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertInstrumentResponse) Reset() {
+	*x = UpsertInstrumentResponse{}
+	mi := &file_api_proto_oms_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertInstrumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertInstrumentResponse) ProtoMessage() {}
+
+func (x *UpsertInstrumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertInstrumentResponse.ProtoReflect.Descriptor instead.
+func (*UpsertInstrumentResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpsertInstrumentResponse) GetInstrument() *Instrument {
+	if x != nil {
+		return x.Instrument
+	}
+	return nil
+}
+
+type GetInstrumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInstrumentRequest) Reset() {
+	*x = GetInstrumentRequest{}
+	mi := &file_api_proto_oms_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInstrumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstrumentRequest) ProtoMessage() {}
+
+func (x *GetInstrumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstrumentRequest.ProtoReflect.Descriptor instead.
+func (*GetInstrumentRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetInstrumentRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type GetInstrumentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instrument    *Instrument            `protobuf:"bytes,1,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInstrumentResponse) Reset() {
+	*x = GetInstrumentResponse{}
+	mi := &file_api_proto_oms_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInstrumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstrumentResponse) ProtoMessage() {}
+
+func (x *GetInstrumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstrumentResponse.ProtoReflect.Descriptor instead.
+func (*GetInstrumentResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetInstrumentResponse) GetInstrument() *Instrument {
+	if x != nil {
+		return x.Instrument
+	}
+	return nil
+}
+
+type ListInstrumentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInstrumentsRequest) Reset() {
+	*x = ListInstrumentsRequest{}
+	mi := &file_api_proto_oms_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInstrumentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInstrumentsRequest) ProtoMessage() {}
+
+func (x *ListInstrumentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInstrumentsRequest.ProtoReflect.Descriptor instead.
+func (*ListInstrumentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{29}
+}
+
+type ListInstrumentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instruments   []*Instrument          `protobuf:"bytes,1,rep,name=instruments,proto3" json:"instruments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInstrumentsResponse) Reset() {
+	*x = ListInstrumentsResponse{}
+	mi := &file_api_proto_oms_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInstrumentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInstrumentsResponse) ProtoMessage() {}
+
+func (x *ListInstrumentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInstrumentsResponse.ProtoReflect.Descriptor instead.
+func (*ListInstrumentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListInstrumentsResponse) GetInstruments() []*Instrument {
+	if x != nil {
+		return x.Instruments
+	}
+	return nil
+}
+
+type SetInstrumentStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Status        InstrumentStatus       `protobuf:"varint,2,opt,name=status,proto3,enum=oms.v1.InstrumentStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetInstrumentStatusRequest) Reset() {
+	*x = SetInstrumentStatusRequest{}
+	mi := &file_api_proto_oms_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetInstrumentStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetInstrumentStatusRequest) ProtoMessage() {}
+
+func (x *SetInstrumentStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetInstrumentStatusRequest.ProtoReflect.Descriptor instead.
+func (*SetInstrumentStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SetInstrumentStatusRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *SetInstrumentStatusRequest) GetStatus() InstrumentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return InstrumentStatus_INSTRUMENT_STATUS_UNSPECIFIED
+}
+
+type SetInstrumentStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instrument    *Instrument            `protobuf:"bytes,1,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetInstrumentStatusResponse) Reset() {
+	*x = SetInstrumentStatusResponse{}
+	mi := &file_api_proto_oms_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetInstrumentStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetInstrumentStatusResponse) ProtoMessage() {}
+
+func (x *SetInstrumentStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetInstrumentStatusResponse.ProtoReflect.Descriptor instead.
+func (*SetInstrumentStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SetInstrumentStatusResponse) GetInstrument() *Instrument {
+	if x != nil {
+		return x.Instrument
+	}
+	return nil
+}
+
+type Balance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asset         string                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	Available     string                 `protobuf:"bytes,2,opt,name=available,proto3" json:"available,omitempty"`
+	Frozen        string                 `protobuf:"bytes,3,opt,name=frozen,proto3" json:"frozen,omitempty"`
+	Margin        string                 `protobuf:"bytes,4,opt,name=margin,proto3" json:"margin,omitempty"`
+	Total         string                 `protobuf:"bytes,5,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Balance) Reset() {
+	*x = Balance{}
+	mi := &file_api_proto_oms_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Balance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Balance) ProtoMessage() {}
+
+func (x *Balance) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Balance.ProtoReflect.Descriptor instead.
+func (*Balance) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Balance) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *Balance) GetAvailable() string {
+	if x != nil {
+		return x.Available
+	}
+	return ""
+}
+
+func (x *Balance) GetFrozen() string {
+	if x != nil {
+		return x.Frozen
+	}
+	return ""
+}
+
+func (x *Balance) GetMargin() string {
+	if x != nil {
+		return x.Margin
+	}
+	return ""
+}
+
+func (x *Balance) GetTotal() string {
+	if x != nil {
+		return x.Total
+	}
+	return ""
+}
+
+// One double-entry ledger movement; accounts are rendered as
+// "user:<id>:<bucket>" or "system:<bucket>"
+type JournalEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Asset         string                 `protobuf:"bytes,3,opt,name=asset,proto3" json:"asset,omitempty"`
+	Amount        string                 `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Debit         string                 `protobuf:"bytes,5,opt,name=debit,proto3" json:"debit,omitempty"`
+	Credit        string                 `protobuf:"bytes,6,opt,name=credit,proto3" json:"credit,omitempty"`
+	RefId         int64                  `protobuf:"varint,7,opt,name=ref_id,json=refId,proto3" json:"ref_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JournalEntry) Reset() {
+	*x = JournalEntry{}
+	mi := &file_api_proto_oms_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JournalEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JournalEntry) ProtoMessage() {}
+
+func (x *JournalEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JournalEntry.ProtoReflect.Descriptor instead.
+func (*JournalEntry) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *JournalEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *JournalEntry) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *JournalEntry) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *JournalEntry) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *JournalEntry) GetDebit() string {
+	if x != nil {
+		return x.Debit
+	}
+	return ""
+}
+
+func (x *JournalEntry) GetCredit() string {
+	if x != nil {
+		return x.Credit
+	}
+	return ""
+}
+
+func (x *JournalEntry) GetRefId() int64 {
+	if x != nil {
+		return x.RefId
+	}
+	return 0
+}
+
+func (x *JournalEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	JournalLimit  int32                  `protobuf:"varint,2,opt,name=journal_limit,json=journalLimit,proto3" json:"journal_limit,omitempty"` // most recent entries to return, 0 for none
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountRequest) Reset() {
+	*x = GetAccountRequest{}
+	mi := &file_api_proto_oms_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountRequest) ProtoMessage() {}
+
+func (x *GetAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetAccountRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetAccountRequest) GetJournalLimit() int32 {
+	if x != nil {
+		return x.JournalLimit
+	}
+	return 0
+}
+
+type GetAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Balances      []*Balance             `protobuf:"bytes,2,rep,name=balances,proto3" json:"balances,omitempty"`
+	Journal       []*JournalEntry        `protobuf:"bytes,3,rep,name=journal,proto3" json:"journal,omitempty"`
+	MarginMode    MarginMode             `protobuf:"varint,4,opt,name=margin_mode,json=marginMode,proto3,enum=oms.v1.MarginMode" json:"margin_mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountResponse) Reset() {
+	*x = GetAccountResponse{}
+	mi := &file_api_proto_oms_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountResponse) ProtoMessage() {}
+
+func (x *GetAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountResponse.ProtoReflect.Descriptor instead.
+func (*GetAccountResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetAccountResponse) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetAccountResponse) GetBalances() []*Balance {
+	if x != nil {
+		return x.Balances
+	}
+	return nil
+}
+
+func (x *GetAccountResponse) GetJournal() []*JournalEntry {
+	if x != nil {
+		return x.Journal
+	}
+	return nil
+}
+
+func (x *GetAccountResponse) GetMarginMode() MarginMode {
+	if x != nil {
+		return x.MarginMode
+	}
+	return MarginMode_MARGIN_MODE_UNSPECIFIED
+}
+
+type GetMarkPriceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMarkPriceRequest) Reset() {
+	*x = GetMarkPriceRequest{}
+	mi := &file_api_proto_oms_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMarkPriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMarkPriceRequest) ProtoMessage() {}
+
+func (x *GetMarkPriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMarkPriceRequest.ProtoReflect.Descriptor instead.
+func (*GetMarkPriceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetMarkPriceRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type GetMarkPriceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	IndexPrice    string                 `protobuf:"bytes,2,opt,name=index_price,json=indexPrice,proto3" json:"index_price,omitempty"`
+	MarkPrice     string                 `protobuf:"bytes,3,opt,name=mark_price,json=markPrice,proto3" json:"mark_price,omitempty"`
+	Basis         string                 `protobuf:"bytes,4,opt,name=basis,proto3" json:"basis,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMarkPriceResponse) Reset() {
+	*x = GetMarkPriceResponse{}
+	mi := &file_api_proto_oms_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMarkPriceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMarkPriceResponse) ProtoMessage() {}
+
+func (x *GetMarkPriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMarkPriceResponse.ProtoReflect.Descriptor instead.
+func (*GetMarkPriceResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetMarkPriceResponse) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetMarkPriceResponse) GetIndexPrice() string {
+	if x != nil {
+		return x.IndexPrice
+	}
+	return ""
+}
+
+func (x *GetMarkPriceResponse) GetMarkPrice() string {
+	if x != nil {
+		return x.MarkPrice
+	}
+	return ""
+}
+
+func (x *GetMarkPriceResponse) GetBasis() string {
+	if x != nil {
+		return x.Basis
+	}
+	return ""
+}
+
+func (x *GetMarkPriceResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Trade struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TradeId       int64                  `protobuf:"varint,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	OrderId       int64                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // 0 for auto-deleveraging
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side          Side                   `protobuf:"varint,4,opt,name=side,proto3,enum=oms.v1.Side" json:"side,omitempty"`
+	PositionSide  PositionSide           `protobuf:"varint,5,opt,name=position_side,json=positionSide,proto3,enum=oms.v1.PositionSide" json:"position_side,omitempty"`
+	Price         string                 `protobuf:"bytes,6,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      string                 `protobuf:"bytes,7,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	IsMaker       bool                   `protobuf:"varint,8,opt,name=is_maker,json=isMaker,proto3" json:"is_maker,omitempty"`
+	Fee           string                 `protobuf:"bytes,9,opt,name=fee,proto3" json:"fee,omitempty"` // negative = maker rebate
+	FeeAsset      string                 `protobuf:"bytes,10,opt,name=fee_asset,json=feeAsset,proto3" json:"fee_asset,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Trade) Reset() {
+	*x = Trade{}
+	mi := &file_api_proto_oms_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trade) ProtoMessage() {}
+
+func (x *Trade) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trade.ProtoReflect.Descriptor instead.
+func (*Trade) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *Trade) GetTradeId() int64 {
+	if x != nil {
+		return x.TradeId
+	}
+	return 0
+}
+
+func (x *Trade) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *Trade) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Trade) GetSide() Side {
+	if x != nil {
+		return x.Side
+	}
+	return Side_SIDE_UNSPECIFIED
+}
+
+func (x *Trade) GetPositionSide() PositionSide {
+	if x != nil {
+		return x.PositionSide
+	}
+	return PositionSide_POSITION_SIDE_UNSPECIFIED
+}
+
+func (x *Trade) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Trade) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *Trade) GetIsMaker() bool {
+	if x != nil {
+		return x.IsMaker
+	}
+	return false
+}
+
+func (x *Trade) GetFee() string {
+	if x != nil {
+		return x.Fee
+	}
+	return ""
+}
+
+func (x *Trade) GetFeeAsset() string {
+	if x != nil {
+		return x.FeeAsset
+	}
+	return ""
+}
+
+func (x *Trade) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetTradesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"` // empty for all symbols
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`  // 0 for all retained trades
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTradesRequest) Reset() {
+	*x = GetTradesRequest{}
+	mi := &file_api_proto_oms_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTradesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTradesRequest) ProtoMessage() {}
+
+func (x *GetTradesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTradesRequest.ProtoReflect.Descriptor instead.
+func (*GetTradesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetTradesRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetTradesRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetTradesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetTradesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trades        []*Trade               `protobuf:"bytes,1,rep,name=trades,proto3" json:"trades,omitempty"` // newest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTradesResponse) Reset() {
+	*x = GetTradesResponse{}
+	mi := &file_api_proto_oms_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTradesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTradesResponse) ProtoMessage() {}
+
+func (x *GetTradesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTradesResponse.ProtoReflect.Descriptor instead.
+func (*GetTradesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetTradesResponse) GetTrades() []*Trade {
+	if x != nil {
+		return x.Trades
+	}
+	return nil
+}
+
+type GetFeeRateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFeeRateRequest) Reset() {
+	*x = GetFeeRateRequest{}
+	mi := &file_api_proto_oms_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeeRateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeeRateRequest) ProtoMessage() {}
+
+func (x *GetFeeRateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeeRateRequest.ProtoReflect.Descriptor instead.
+func (*GetFeeRateRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetFeeRateRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetFeeRateRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type GetFeeRateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	VipLevel      int32                  `protobuf:"varint,3,opt,name=vip_level,json=vipLevel,proto3" json:"vip_level,omitempty"`
+	Volume_30D    string                 `protobuf:"bytes,4,opt,name=volume_30d,json=volume30d,proto3" json:"volume_30d,omitempty"` // traded notional over the last 30 UTC days
+	MakerFeeRate  string                 `protobuf:"bytes,5,opt,name=maker_fee_rate,json=makerFeeRate,proto3" json:"maker_fee_rate,omitempty"`
+	TakerFeeRate  string                 `protobuf:"bytes,6,opt,name=taker_fee_rate,json=takerFeeRate,proto3" json:"taker_fee_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFeeRateResponse) Reset() {
+	*x = GetFeeRateResponse{}
+	mi := &file_api_proto_oms_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeeRateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeeRateResponse) ProtoMessage() {}
+
+func (x *GetFeeRateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeeRateResponse.ProtoReflect.Descriptor instead.
+func (*GetFeeRateResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetFeeRateResponse) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetFeeRateResponse) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetFeeRateResponse) GetVipLevel() int32 {
+	if x != nil {
+		return x.VipLevel
+	}
+	return 0
+}
+
+func (x *GetFeeRateResponse) GetVolume_30D() string {
+	if x != nil {
+		return x.Volume_30D
+	}
+	return ""
+}
+
+func (x *GetFeeRateResponse) GetMakerFeeRate() string {
+	if x != nil {
+		return x.MakerFeeRate
+	}
+	return ""
+}
+
+func (x *GetFeeRateResponse) GetTakerFeeRate() string {
+	if x != nil {
+		return x.TakerFeeRate
+	}
+	return ""
+}
+
+type GetFundingRateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFundingRateRequest) Reset() {
+	*x = GetFundingRateRequest{}
+	mi := &file_api_proto_oms_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFundingRateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFundingRateRequest) ProtoMessage() {}
+
+func (x *GetFundingRateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFundingRateRequest.ProtoReflect.Descriptor instead.
+func (*GetFundingRateRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetFundingRateRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type GetFundingRateResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Symbol          string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	PredictedRate   string                 `protobuf:"bytes,2,opt,name=predicted_rate,json=predictedRate,proto3" json:"predicted_rate,omitempty"` // rate the current interval would settle at; positive = longs pay shorts
+	PremiumIndex    string                 `protobuf:"bytes,3,opt,name=premium_index,json=premiumIndex,proto3" json:"premium_index,omitempty"`    // average (mark - index) / index sampled this interval
+	NextFundingTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=next_funding_time,json=nextFundingTime,proto3" json:"next_funding_time,omitempty"`
+	LastRate        string                 `protobuf:"bytes,5,opt,name=last_rate,json=lastRate,proto3" json:"last_rate,omitempty"` // empty before the first settlement
+	LastFundingTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_funding_time,json=lastFundingTime,proto3" json:"last_funding_time,omitempty"`
+	LastMarkPrice   string                 `protobuf:"bytes,7,opt,name=last_mark_price,json=lastMarkPrice,proto3" json:"last_mark_price,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetFundingRateResponse) Reset() {
+	*x = GetFundingRateResponse{}
+	mi := &file_api_proto_oms_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderResponse) String() string {
+func (x *GetFundingRateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderResponse) ProtoMessage() {}
+func (*GetFundingRateResponse) ProtoMessage() {}
 
-func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_oms_proto_msgTypes[5]
+func (x *GetFundingRateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -484,104 +3672,142 @@ func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
-func (*GetOrderResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_oms_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use GetFundingRateResponse.ProtoReflect.Descriptor instead.
+func (*GetFundingRateResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *GetOrderResponse) GetOrderId() int64 {
+func (x *GetFundingRateResponse) GetSymbol() string {
 	if x != nil {
-		return x.OrderId
+		return x.Symbol
 	}
-	return 0
+	return ""
 }
 
-func (x *GetOrderResponse) GetUserId() int64 {
+func (x *GetFundingRateResponse) GetPredictedRate() string {
 	if x != nil {
-		return x.UserId
+		return x.PredictedRate
 	}
-	return 0
+	return ""
 }
 
-func (x *GetOrderResponse) GetSymbol() string {
+func (x *GetFundingRateResponse) GetPremiumIndex() string {
 	if x != nil {
-		return x.Symbol
+		return x.PremiumIndex
 	}
 	return ""
 }
 
-func (x *GetOrderResponse) GetSide() Side {
+func (x *GetFundingRateResponse) GetNextFundingTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Side
+		return x.NextFundingTime
 	}
-	return Side_SIDE_UNSPECIFIED
+	return nil
 }
 
-func (x *GetOrderResponse) GetType() OrderType {
+func (x *GetFundingRateResponse) GetLastRate() string {
 	if x != nil {
-		return x.Type
+		return x.LastRate
 	}
-	return OrderType_ORDER_TYPE_UNSPECIFIED
+	return ""
 }
 
-func (x *GetOrderResponse) GetPrice() float64 {
+func (x *GetFundingRateResponse) GetLastFundingTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Price
+		return x.LastFundingTime
 	}
-	return 0
+	return nil
 }
 
-func (x *GetOrderResponse) GetQuantity() float64 {
+func (x *GetFundingRateResponse) GetLastMarkPrice() string {
 	if x != nil {
-		return x.Quantity
+		return x.LastMarkPrice
 	}
-	return 0
+	return ""
+}
+
+type TransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Asset         string                 `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"`
+	Amount        string                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrderResponse) GetExecutedQuantity() float64 {
+func (x *TransferRequest) Reset() {
+	*x = TransferRequest{}
+	mi := &file_api_proto_oms_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferRequest) ProtoMessage() {}
+
+func (x *TransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[46]
 	if x != nil {
-		return x.ExecutedQuantity
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferRequest.ProtoReflect.Descriptor instead.
+func (*TransferRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *TransferRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *GetOrderResponse) GetStatus() OrderStatus {
+func (x *TransferRequest) GetAsset() string {
 	if x != nil {
-		return x.Status
+		return x.Asset
 	}
-	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+	return ""
 }
 
-func (x *GetOrderResponse) GetCreatedAt() *timestamppb.Timestamp {
+func (x *TransferRequest) GetAmount() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.Amount
 	}
-	return nil
+	return ""
 }
 
-type GetPositionRequest struct {
+type TransferResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Balance       *Balance               `protobuf:"bytes,1,opt,name=balance,proto3" json:"balance,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPositionRequest) Reset() {
-	*x = GetPositionRequest{}
-	mi := &file_api_proto_oms_proto_msgTypes[6]
+func (x *TransferResponse) Reset() {
+	*x = TransferResponse{}
+	mi := &file_api_proto_oms_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPositionRequest) String() string {
+func (x *TransferResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPositionRequest) ProtoMessage() {}
+func (*TransferResponse) ProtoMessage() {}
 
-func (x *GetPositionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_oms_proto_msgTypes[6]
+func (x *TransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -592,53 +3818,94 @@ func (x *GetPositionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPositionRequest.ProtoReflect.Descriptor instead.
-func (*GetPositionRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_oms_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use TransferResponse.ProtoReflect.Descriptor instead.
+func (*TransferResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *GetPositionRequest) GetUserId() int64 {
+func (x *TransferResponse) GetBalance() *Balance {
 	if x != nil {
-		return x.UserId
+		return x.Balance
 	}
-	return 0
+	return nil
 }
 
-func (x *GetPositionRequest) GetSymbol() string {
+type GetInsuranceFundRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asset         string                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // most recent movements to return, 0 for none
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInsuranceFundRequest) Reset() {
+	*x = GetInsuranceFundRequest{}
+	mi := &file_api_proto_oms_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInsuranceFundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInsuranceFundRequest) ProtoMessage() {}
+
+func (x *GetInsuranceFundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[48]
 	if x != nil {
-		return x.Symbol
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInsuranceFundRequest.ProtoReflect.Descriptor instead.
+func (*GetInsuranceFundRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetInsuranceFundRequest) GetAsset() string {
+	if x != nil {
+		return x.Asset
 	}
 	return ""
 }
 
-type GetPositionResponse struct {
+func (x *GetInsuranceFundRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetInsuranceFundResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Quantity      float64                `protobuf:"fixed64,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	EntryPrice    float64                `protobuf:"fixed64,4,opt,name=entry_price,json=entryPrice,proto3" json:"entry_price,omitempty"`
-	Margin        float64                `protobuf:"fixed64,5,opt,name=margin,proto3" json:"margin,omitempty"`
-	Leverage      float64                `protobuf:"fixed64,6,opt,name=leverage,proto3" json:"leverage,omitempty"`
-	UnrealizedPnl float64                `protobuf:"fixed64,7,opt,name=unrealized_pnl,json=unrealizedPnl,proto3" json:"unrealized_pnl,omitempty"`
+	Asset         string                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	Balance       string                 `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	History       []*JournalEntry        `protobuf:"bytes,3,rep,name=history,proto3" json:"history,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPositionResponse) Reset() {
-	*x = GetPositionResponse{}
-	mi := &file_api_proto_oms_proto_msgTypes[7]
+func (x *GetInsuranceFundResponse) Reset() {
+	*x = GetInsuranceFundResponse{}
+	mi := &file_api_proto_oms_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPositionResponse) String() string {
+func (x *GetInsuranceFundResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPositionResponse) ProtoMessage() {}
+func (*GetInsuranceFundResponse) ProtoMessage() {}
 
-func (x *GetPositionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_oms_proto_msgTypes[7]
+func (x *GetInsuranceFundResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -649,79 +3916,184 @@ func (x *GetPositionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPositionResponse.ProtoReflect.Descriptor instead.
-func (*GetPositionResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_oms_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use GetInsuranceFundResponse.ProtoReflect.Descriptor instead.
+func (*GetInsuranceFundResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *GetPositionResponse) GetUserId() int64 {
+func (x *GetInsuranceFundResponse) GetAsset() string {
 	if x != nil {
-		return x.UserId
+		return x.Asset
 	}
-	return 0
+	return ""
 }
 
-func (x *GetPositionResponse) GetSymbol() string {
+func (x *GetInsuranceFundResponse) GetBalance() string {
 	if x != nil {
-		return x.Symbol
+		return x.Balance
 	}
 	return ""
 }
 
-func (x *GetPositionResponse) GetQuantity() float64 {
+func (x *GetInsuranceFundResponse) GetHistory() []*JournalEntry {
 	if x != nil {
-		return x.Quantity
+		return x.History
 	}
-	return 0
+	return nil
+}
+
+type GetFeeIncomeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asset         string                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // most recent movements to return, 0 for none
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFeeIncomeRequest) Reset() {
+	*x = GetFeeIncomeRequest{}
+	mi := &file_api_proto_oms_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeeIncomeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *GetPositionResponse) GetEntryPrice() float64 {
+func (*GetFeeIncomeRequest) ProtoMessage() {}
+
+func (x *GetFeeIncomeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[50]
 	if x != nil {
-		return x.EntryPrice
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeeIncomeRequest.ProtoReflect.Descriptor instead.
+func (*GetFeeIncomeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *GetPositionResponse) GetMargin() float64 {
+func (x *GetFeeIncomeRequest) GetAsset() string {
 	if x != nil {
-		return x.Margin
+		return x.Asset
 	}
-	return 0
+	return ""
 }
 
-func (x *GetPositionResponse) GetLeverage() float64 {
+func (x *GetFeeIncomeRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Leverage
+		return x.Limit
 	}
 	return 0
 }
 
-func (x *GetPositionResponse) GetUnrealizedPnl() float64 {
+type GetFeeIncomeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asset         string                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	Balance       string                 `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"` // fees charged less maker rebates paid
+	History       []*JournalEntry        `protobuf:"bytes,3,rep,name=history,proto3" json:"history,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFeeIncomeResponse) Reset() {
+	*x = GetFeeIncomeResponse{}
+	mi := &file_api_proto_oms_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeeIncomeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeeIncomeResponse) ProtoMessage() {}
+
+func (x *GetFeeIncomeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_oms_proto_msgTypes[51]
 	if x != nil {
-		return x.UnrealizedPnl
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeeIncomeResponse.ProtoReflect.Descriptor instead.
+func (*GetFeeIncomeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_oms_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetFeeIncomeResponse) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *GetFeeIncomeResponse) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *GetFeeIncomeResponse) GetHistory() []*JournalEntry {
+	if x != nil {
+		return x.History
+	}
+	return nil
 }
 
 var File_api_proto_oms_proto protoreflect.FileDescriptor
 
 const file_api_proto_oms_proto_rawDesc = "" +
 	"\n" +
-	"\x13api/proto/oms.proto\x12\x06oms.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc0\x01\n" +
+	"\x13api/proto/oms.proto\x12\x06oms.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9b\x03\n" +
 	"\x12CreateOrderRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
 	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12 \n" +
 	"\x04side\x18\x03 \x01(\x0e2\f.oms.v1.SideR\x04side\x12%\n" +
 	"\x04type\x18\x04 \x01(\x0e2\x11.oms.v1.OrderTypeR\x04type\x12\x14\n" +
-	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bquantity\x18\x06 \x01(\x01R\bquantity\"]\n" +
+	"\x05price\x18\x05 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\tR\bquantity\x129\n" +
+	"\rposition_side\x18\a \x01(\x0e2\x14.oms.v1.PositionSideR\fpositionSide\x12\x1f\n" +
+	"\vreduce_only\x18\b \x01(\bR\n" +
+	"reduceOnly\x12%\n" +
+	"\x0eclose_position\x18\t \x01(\bR\rclosePosition\x12\x1d\n" +
+	"\n" +
+	"stop_price\x18\n" +
+	" \x01(\tR\tstopPrice\x127\n" +
+	"\n" +
+	"trigger_by\x18\v \x01(\x0e2\x18.oms.v1.TriggerPriceTypeR\ttriggerBy\"\xb7\x01\n" +
 	"\x13CreateOrderResponse\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\x03R\aorderId\x12+\n" +
-	"\x06status\x18\x02 \x01(\x0e2\x13.oms.v1.OrderStatusR\x06status\"/\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x13.oms.v1.OrderStatusR\x06status\x12#\n" +
+	"\rreject_reason\x18\x03 \x01(\tR\frejectReason\x123\n" +
+	"\vreject_code\x18\x04 \x01(\x0e2\x12.oms.v1.RejectCodeR\n" +
+	"rejectCode\"H\n" +
 	"\x12CancelOrderRequest\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\x03R\aorderId\"/\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"/\n" +
 	"\x13CancelOrderResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\",\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"y\n" +
+	"\x11AmendOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\tR\bquantity\"\\\n" +
+	"\x12AmendOrderResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12+\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x13.oms.v1.OrderStatusR\x06status\",\n" +
 	"\x0fGetOrderRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\x03R\aorderId\"\xee\x02\n" +
 	"\x10GetOrderResponse\x12\x19\n" +
@@ -730,44 +4102,341 @@ const file_api_proto_oms_proto_rawDesc = "" +
 	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12 \n" +
 	"\x04side\x18\x04 \x01(\x0e2\f.oms.v1.SideR\x04side\x12%\n" +
 	"\x04type\x18\x05 \x01(\x0e2\x11.oms.v1.OrderTypeR\x04type\x12\x14\n" +
-	"\x05price\x18\x06 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bquantity\x18\a \x01(\x01R\bquantity\x12+\n" +
-	"\x11executed_quantity\x18\b \x01(\x01R\x10executedQuantity\x12+\n" +
+	"\x05price\x18\x06 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\a \x01(\tR\bquantity\x12+\n" +
+	"\x11executed_quantity\x18\b \x01(\tR\x10executedQuantity\x12+\n" +
 	"\x06status\x18\t \x01(\x0e2\x13.oms.v1.OrderStatusR\x06status\x129\n" +
 	"\n" +
 	"created_at\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"E\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"G\n" +
+	"\x14GetStopOrdersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\"\x97\x04\n" +
+	"\tStopOrder\x12\x17\n" +
+	"\astop_id\x18\x01 \x01(\x03R\x06stopId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12 \n" +
+	"\x04side\x18\x04 \x01(\x0e2\f.oms.v1.SideR\x04side\x12%\n" +
+	"\x04type\x18\x05 \x01(\x0e2\x11.oms.v1.OrderTypeR\x04type\x129\n" +
+	"\rposition_side\x18\x06 \x01(\x0e2\x14.oms.v1.PositionSideR\fpositionSide\x12\x1d\n" +
+	"\n" +
+	"stop_price\x18\a \x01(\tR\tstopPrice\x127\n" +
+	"\n" +
+	"trigger_by\x18\b \x01(\x0e2\x18.oms.v1.TriggerPriceTypeR\ttriggerBy\x12\x14\n" +
+	"\x05price\x18\t \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\n" +
+	" \x01(\tR\bquantity\x12\x1f\n" +
+	"\vreduce_only\x18\v \x01(\bR\n" +
+	"reduceOnly\x12%\n" +
+	"\x0eclose_position\x18\f \x01(\bR\rclosePosition\x12/\n" +
+	"\x06status\x18\r \x01(\x0e2\x17.oms.v1.StopOrderStatusR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"K\n" +
+	"\x15GetStopOrdersResponse\x122\n" +
+	"\vstop_orders\x18\x01 \x03(\v2\x11.oms.v1.StopOrderR\n" +
+	"stopOrders\"\x80\x01\n" +
 	"\x12GetPositionRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
-	"\x06symbol\x18\x02 \x01(\tR\x06symbol\"\xde\x01\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x129\n" +
+	"\rposition_side\x18\x03 \x01(\x0e2\x14.oms.v1.PositionSideR\fpositionSide\"\x83\x04\n" +
 	"\x13GetPositionResponse\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
 	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x1a\n" +
-	"\bquantity\x18\x03 \x01(\x01R\bquantity\x12\x1f\n" +
-	"\ventry_price\x18\x04 \x01(\x01R\n" +
+	"\bquantity\x18\x03 \x01(\tR\bquantity\x12\x1f\n" +
+	"\ventry_price\x18\x04 \x01(\tR\n" +
 	"entryPrice\x12\x16\n" +
-	"\x06margin\x18\x05 \x01(\x01R\x06margin\x12\x1a\n" +
-	"\bleverage\x18\x06 \x01(\x01R\bleverage\x12%\n" +
-	"\x0eunrealized_pnl\x18\a \x01(\x01R\runrealizedPnl*9\n" +
+	"\x06margin\x18\x05 \x01(\tR\x06margin\x12\x1a\n" +
+	"\bleverage\x18\x06 \x01(\tR\bleverage\x12%\n" +
+	"\x0eunrealized_pnl\x18\a \x01(\tR\runrealizedPnl\x12!\n" +
+	"\frealized_pnl\x18\b \x01(\tR\vrealizedPnl\x129\n" +
+	"\rposition_side\x18\t \x01(\x0e2\x14.oms.v1.PositionSideR\fpositionSide\x12\x1d\n" +
+	"\n" +
+	"mark_price\x18\n" +
+	" \x01(\tR\tmarkPrice\x12+\n" +
+	"\x11liquidation_price\x18\v \x01(\tR\x10liquidationPrice\x12)\n" +
+	"\x10bankruptcy_price\x18\f \x01(\tR\x0fbankruptcyPrice\x12!\n" +
+	"\fadl_quantile\x18\r \x01(\x05R\vadlQuantile\x12+\n" +
+	"\x11liquidation_state\x18\x0e \x01(\tR\x10liquidationState\"a\n" +
+	"\x12SetLeverageRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bleverage\x18\x03 \x01(\tR\bleverage\"z\n" +
+	"\x13SetLeverageResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bleverage\x18\x03 \x01(\tR\bleverage\x12\x16\n" +
+	"\x06margin\x18\x04 \x01(\tR\x06margin\"[\n" +
+	"\x16SetPositionModeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12(\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x14.oms.v1.PositionModeR\x04mode\"\\\n" +
+	"\x17SetPositionModeResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12(\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x14.oms.v1.PositionModeR\x04mode\"W\n" +
+	"\x14SetMarginModeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12&\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x12.oms.v1.MarginModeR\x04mode\"X\n" +
+	"\x15SetMarginModeResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12&\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x12.oms.v1.MarginModeR\x04mode\"\xcb\x01\n" +
+	"\bRiskTier\x12\x12\n" +
+	"\x04tier\x18\x01 \x01(\x05R\x04tier\x12!\n" +
+	"\fmax_notional\x18\x02 \x01(\tR\vmaxNotional\x126\n" +
+	"\x17maintenance_margin_rate\x18\x03 \x01(\tR\x15maintenanceMarginRate\x12-\n" +
+	"\x12maintenance_amount\x18\x04 \x01(\tR\x11maintenanceAmount\x12!\n" +
+	"\fmax_leverage\x18\x05 \x01(\tR\vmaxLeverage\"F\n" +
+	"\x13GetRiskLimitRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\"\xb7\x01\n" +
+	"\x14GetRiskLimitResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12&\n" +
+	"\x05tiers\x18\x03 \x03(\v2\x10.oms.v1.RiskTierR\x05tiers\x12#\n" +
+	"\rselected_tier\x18\x04 \x01(\x05R\fselectedTier\x12!\n" +
+	"\fcurrent_tier\x18\x05 \x01(\x05R\vcurrentTier\"Z\n" +
+	"\x13SetRiskLimitRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04tier\x18\x03 \x01(\x05R\x04tier\"[\n" +
+	"\x14SetRiskLimitResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04tier\x18\x03 \x01(\x05R\x04tier\"\xb9\x05\n" +
+	"\n" +
+	"Instrument\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x1d\n" +
+	"\n" +
+	"base_asset\x18\x02 \x01(\tR\tbaseAsset\x12!\n" +
+	"\fsettle_asset\x18\x03 \x01(\tR\vsettleAsset\x12\x1b\n" +
+	"\ttick_size\x18\x04 \x01(\tR\btickSize\x12\x19\n" +
+	"\blot_size\x18\x05 \x01(\tR\alotSize\x12\x17\n" +
+	"\amin_qty\x18\x06 \x01(\tR\x06minQty\x12\x17\n" +
+	"\amax_qty\x18\a \x01(\tR\x06maxQty\x12!\n" +
+	"\fmin_notional\x18\b \x01(\tR\vminNotional\x12!\n" +
+	"\fmax_leverage\x18\t \x01(\tR\vmaxLeverage\x126\n" +
+	"\x17maintenance_margin_rate\x18\n" +
+	" \x01(\tR\x15maintenanceMarginRate\x120\n" +
+	"\x06status\x18\v \x01(\x0e2\x18.oms.v1.InstrumentStatusR\x06status\x12)\n" +
+	"\x10liquidation_step\x18\f \x01(\tR\x0fliquidationStep\x12/\n" +
+	"\n" +
+	"risk_tiers\x18\r \x03(\v2\x10.oms.v1.RiskTierR\triskTiers\x12(\n" +
+	"\x10funding_rate_cap\x18\x0e \x01(\tR\x0efundingRateCap\x12$\n" +
+	"\x0emaker_fee_rate\x18\x0f \x01(\tR\fmakerFeeRate\x12$\n" +
+	"\x0etaker_fee_rate\x18\x10 \x01(\tR\ftakerFeeRate\x12\x1b\n" +
+	"\tmax_price\x18\x11 \x01(\tR\bmaxPrice\x12%\n" +
+	"\x0eprice_decimals\x18\x12 \x01(\x05R\rpriceDecimals\x12!\n" +
+	"\fqty_decimals\x18\x13 \x01(\x05R\vqtyDecimals\"M\n" +
+	"\x17UpsertInstrumentRequest\x122\n" +
+	"\n" +
+	"instrument\x18\x01 \x01(\v2\x12.oms.v1.InstrumentR\n" +
+	"instrument\"N\n" +
+	"\x18UpsertInstrumentResponse\x122\n" +
+	"\n" +
+	"instrument\x18\x01 \x01(\v2\x12.oms.v1.InstrumentR\n" +
+	"instrument\".\n" +
+	"\x14GetInstrumentRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"K\n" +
+	"\x15GetInstrumentResponse\x122\n" +
+	"\n" +
+	"instrument\x18\x01 \x01(\v2\x12.oms.v1.InstrumentR\n" +
+	"instrument\"\x18\n" +
+	"\x16ListInstrumentsRequest\"O\n" +
+	"\x17ListInstrumentsResponse\x124\n" +
+	"\vinstruments\x18\x01 \x03(\v2\x12.oms.v1.InstrumentR\vinstruments\"f\n" +
+	"\x1aSetInstrumentStatusRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x120\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x18.oms.v1.InstrumentStatusR\x06status\"Q\n" +
+	"\x1bSetInstrumentStatusResponse\x122\n" +
+	"\n" +
+	"instrument\x18\x01 \x01(\v2\x12.oms.v1.InstrumentR\n" +
+	"instrument\"\x83\x01\n" +
+	"\aBalance\x12\x14\n" +
+	"\x05asset\x18\x01 \x01(\tR\x05asset\x12\x1c\n" +
+	"\tavailable\x18\x02 \x01(\tR\tavailable\x12\x16\n" +
+	"\x06frozen\x18\x03 \x01(\tR\x06frozen\x12\x16\n" +
+	"\x06margin\x18\x04 \x01(\tR\x06margin\x12\x14\n" +
+	"\x05total\x18\x05 \x01(\tR\x05total\"\xe0\x01\n" +
+	"\fJournalEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05asset\x18\x03 \x01(\tR\x05asset\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\tR\x06amount\x12\x14\n" +
+	"\x05debit\x18\x05 \x01(\tR\x05debit\x12\x16\n" +
+	"\x06credit\x18\x06 \x01(\tR\x06credit\x12\x15\n" +
+	"\x06ref_id\x18\a \x01(\x03R\x05refId\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"Q\n" +
+	"\x11GetAccountRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12#\n" +
+	"\rjournal_limit\x18\x02 \x01(\x05R\fjournalLimit\"\xbf\x01\n" +
+	"\x12GetAccountResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12+\n" +
+	"\bbalances\x18\x02 \x03(\v2\x0f.oms.v1.BalanceR\bbalances\x12.\n" +
+	"\ajournal\x18\x03 \x03(\v2\x14.oms.v1.JournalEntryR\ajournal\x123\n" +
+	"\vmargin_mode\x18\x04 \x01(\x0e2\x12.oms.v1.MarginModeR\n" +
+	"marginMode\"-\n" +
+	"\x13GetMarkPriceRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"\xbf\x01\n" +
+	"\x14GetMarkPriceResponse\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x1f\n" +
+	"\vindex_price\x18\x02 \x01(\tR\n" +
+	"indexPrice\x12\x1d\n" +
+	"\n" +
+	"mark_price\x18\x03 \x01(\tR\tmarkPrice\x12\x14\n" +
+	"\x05basis\x18\x04 \x01(\tR\x05basis\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xe9\x02\n" +
+	"\x05Trade\x12\x19\n" +
+	"\btrade_id\x18\x01 \x01(\x03R\atradeId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\x03R\aorderId\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12 \n" +
+	"\x04side\x18\x04 \x01(\x0e2\f.oms.v1.SideR\x04side\x129\n" +
+	"\rposition_side\x18\x05 \x01(\x0e2\x14.oms.v1.PositionSideR\fpositionSide\x12\x14\n" +
+	"\x05price\x18\x06 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\a \x01(\tR\bquantity\x12\x19\n" +
+	"\bis_maker\x18\b \x01(\bR\aisMaker\x12\x10\n" +
+	"\x03fee\x18\t \x01(\tR\x03fee\x12\x1b\n" +
+	"\tfee_asset\x18\n" +
+	" \x01(\tR\bfeeAsset\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"Y\n" +
+	"\x10GetTradesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\":\n" +
+	"\x11GetTradesResponse\x12%\n" +
+	"\x06trades\x18\x01 \x03(\v2\r.oms.v1.TradeR\x06trades\"D\n" +
+	"\x11GetFeeRateRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\"\xcd\x01\n" +
+	"\x12GetFeeRateResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x1b\n" +
+	"\tvip_level\x18\x03 \x01(\x05R\bvipLevel\x12\x1d\n" +
+	"\n" +
+	"volume_30d\x18\x04 \x01(\tR\tvolume30d\x12$\n" +
+	"\x0emaker_fee_rate\x18\x05 \x01(\tR\fmakerFeeRate\x12$\n" +
+	"\x0etaker_fee_rate\x18\x06 \x01(\tR\ftakerFeeRate\"/\n" +
+	"\x15GetFundingRateRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"\xd1\x02\n" +
+	"\x16GetFundingRateResponse\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12%\n" +
+	"\x0epredicted_rate\x18\x02 \x01(\tR\rpredictedRate\x12#\n" +
+	"\rpremium_index\x18\x03 \x01(\tR\fpremiumIndex\x12F\n" +
+	"\x11next_funding_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0fnextFundingTime\x12\x1b\n" +
+	"\tlast_rate\x18\x05 \x01(\tR\blastRate\x12F\n" +
+	"\x11last_funding_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x0flastFundingTime\x12&\n" +
+	"\x0flast_mark_price\x18\a \x01(\tR\rlastMarkPrice\"X\n" +
+	"\x0fTransferRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05asset\x18\x02 \x01(\tR\x05asset\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\"=\n" +
+	"\x10TransferResponse\x12)\n" +
+	"\abalance\x18\x01 \x01(\v2\x0f.oms.v1.BalanceR\abalance\"E\n" +
+	"\x17GetInsuranceFundRequest\x12\x14\n" +
+	"\x05asset\x18\x01 \x01(\tR\x05asset\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"z\n" +
+	"\x18GetInsuranceFundResponse\x12\x14\n" +
+	"\x05asset\x18\x01 \x01(\tR\x05asset\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\tR\abalance\x12.\n" +
+	"\ahistory\x18\x03 \x03(\v2\x14.oms.v1.JournalEntryR\ahistory\"A\n" +
+	"\x13GetFeeIncomeRequest\x12\x14\n" +
+	"\x05asset\x18\x01 \x01(\tR\x05asset\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"v\n" +
+	"\x14GetFeeIncomeResponse\x12\x14\n" +
+	"\x05asset\x18\x01 \x01(\tR\x05asset\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\tR\abalance\x12.\n" +
+	"\ahistory\x18\x03 \x03(\v2\x14.oms.v1.JournalEntryR\ahistory*9\n" +
 	"\x04Side\x12\x14\n" +
 	"\x10SIDE_UNSPECIFIED\x10\x00\x12\f\n" +
 	"\bSIDE_BUY\x10\x01\x12\r\n" +
-	"\tSIDE_SELL\x10\x02*T\n" +
+	"\tSIDE_SELL\x10\x02*\x8b\x01\n" +
 	"\tOrderType\x12\x1a\n" +
 	"\x16ORDER_TYPE_UNSPECIFIED\x10\x00\x12\x14\n" +
 	"\x10ORDER_TYPE_LIMIT\x10\x01\x12\x15\n" +
-	"\x11ORDER_TYPE_MARKET\x10\x02*\x96\x01\n" +
+	"\x11ORDER_TYPE_MARKET\x10\x02\x12\x1a\n" +
+	"\x16ORDER_TYPE_STOP_MARKET\x10\x03\x12\x19\n" +
+	"\x15ORDER_TYPE_STOP_LIMIT\x10\x04*p\n" +
+	"\x10TriggerPriceType\x12\"\n" +
+	"\x1eTRIGGER_PRICE_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17TRIGGER_PRICE_TYPE_LAST\x10\x01\x12\x1b\n" +
+	"\x17TRIGGER_PRICE_TYPE_MARK\x10\x02*\xd5\x01\n" +
+	"\x0fStopOrderStatus\x12!\n" +
+	"\x1dSTOP_ORDER_STATUS_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19STOP_ORDER_STATUS_PENDING\x10\x01\x12\x1f\n" +
+	"\x1bSTOP_ORDER_STATUS_TRIGGERED\x10\x02\x12\x1f\n" +
+	"\x1bSTOP_ORDER_STATUS_ACTIVATED\x10\x03\x12\x1e\n" +
+	"\x1aSTOP_ORDER_STATUS_REJECTED\x10\x04\x12\x1e\n" +
+	"\x1aSTOP_ORDER_STATUS_CANCELED\x10\x05*\xb9\x01\n" +
 	"\vOrderStatus\x12\x1c\n" +
 	"\x18ORDER_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16ORDER_STATUS_SUBMITTED\x10\x01\x12\x17\n" +
 	"\x13ORDER_STATUS_FILLED\x10\x02\x12\x19\n" +
 	"\x15ORDER_STATUS_CANCELED\x10\x03\x12\x19\n" +
-	"\x15ORDER_STATUS_REJECTED\x10\x042\x9c\x02\n" +
+	"\x15ORDER_STATUS_REJECTED\x10\x04\x12!\n" +
+	"\x1dORDER_STATUS_PARTIALLY_FILLED\x10\x05*v\n" +
+	"\fPositionSide\x12\x1d\n" +
+	"\x19POSITION_SIDE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12POSITION_SIDE_BOTH\x10\x01\x12\x16\n" +
+	"\x12POSITION_SIDE_LONG\x10\x02\x12\x17\n" +
+	"\x13POSITION_SIDE_SHORT\x10\x03*a\n" +
+	"\fPositionMode\x12\x1d\n" +
+	"\x19POSITION_MODE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15POSITION_MODE_ONE_WAY\x10\x01\x12\x17\n" +
+	"\x13POSITION_MODE_HEDGE\x10\x02*Z\n" +
+	"\n" +
+	"MarginMode\x12\x1b\n" +
+	"\x17MARGIN_MODE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14MARGIN_MODE_ISOLATED\x10\x01\x12\x15\n" +
+	"\x11MARGIN_MODE_CROSS\x10\x02*\xe8\x02\n" +
+	"\n" +
+	"RejectCode\x12\x1b\n" +
+	"\x17REJECT_CODE_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16REJECT_CODE_INSTRUMENT\x10\x01\x12\x1d\n" +
+	"\x19REJECT_CODE_POSITION_SIDE\x10\x02\x12\x1b\n" +
+	"\x17REJECT_CODE_REDUCE_ONLY\x10\x03\x12\x1d\n" +
+	"\x19REJECT_CODE_MAX_ORDER_QTY\x10\x04\x12\"\n" +
+	"\x1eREJECT_CODE_MAX_ORDER_NOTIONAL\x10\x05\x12\x1a\n" +
+	"\x16REJECT_CODE_PRICE_BAND\x10\x06\x12\x1f\n" +
+	"\x1bREJECT_CODE_MAX_OPEN_ORDERS\x10\a\x12\x1a\n" +
+	"\x16REJECT_CODE_RISK_LIMIT\x10\b\x12\x16\n" +
+	"\x12REJECT_CODE_MARGIN\x10\t\x12\x15\n" +
+	"\x11REJECT_CODE_OTHER\x10\n" +
+	"\x12\x1a\n" +
+	"\x16REJECT_CODE_STOP_PRICE\x10\v*\x92\x01\n" +
+	"\x10InstrumentStatus\x12!\n" +
+	"\x1dINSTRUMENT_STATUS_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19INSTRUMENT_STATUS_TRADING\x10\x01\x12\x1c\n" +
+	"\x18INSTRUMENT_STATUS_HALTED\x10\x02\x12\x1e\n" +
+	"\x1aINSTRUMENT_STATUS_DELISTED\x10\x032\x97\t\n" +
 	"\x03OMS\x12F\n" +
 	"\vCreateOrder\x12\x1a.oms.v1.CreateOrderRequest\x1a\x1b.oms.v1.CreateOrderResponse\x12F\n" +
-	"\vCancelOrder\x12\x1a.oms.v1.CancelOrderRequest\x1a\x1b.oms.v1.CancelOrderResponse\x12=\n" +
-	"\bGetOrder\x12\x17.oms.v1.GetOrderRequest\x1a\x18.oms.v1.GetOrderResponse\x12F\n" +
-	"\vGetPosition\x12\x1a.oms.v1.GetPositionRequest\x1a\x1b.oms.v1.GetPositionResponseB\x1eZ\x1coms-contract/api/proto;omsv1b\x06proto3"
+	"\vCancelOrder\x12\x1a.oms.v1.CancelOrderRequest\x1a\x1b.oms.v1.CancelOrderResponse\x12C\n" +
+	"\n" +
+	"AmendOrder\x12\x19.oms.v1.AmendOrderRequest\x1a\x1a.oms.v1.AmendOrderResponse\x12=\n" +
+	"\bGetOrder\x12\x17.oms.v1.GetOrderRequest\x1a\x18.oms.v1.GetOrderResponse\x12L\n" +
+	"\rGetStopOrders\x12\x1c.oms.v1.GetStopOrdersRequest\x1a\x1d.oms.v1.GetStopOrdersResponse\x12F\n" +
+	"\vGetPosition\x12\x1a.oms.v1.GetPositionRequest\x1a\x1b.oms.v1.GetPositionResponse\x12F\n" +
+	"\vSetLeverage\x12\x1a.oms.v1.SetLeverageRequest\x1a\x1b.oms.v1.SetLeverageResponse\x12R\n" +
+	"\x0fSetPositionMode\x12\x1e.oms.v1.SetPositionModeRequest\x1a\x1f.oms.v1.SetPositionModeResponse\x12L\n" +
+	"\rSetMarginMode\x12\x1c.oms.v1.SetMarginModeRequest\x1a\x1d.oms.v1.SetMarginModeResponse\x12I\n" +
+	"\fGetRiskLimit\x12\x1b.oms.v1.GetRiskLimitRequest\x1a\x1c.oms.v1.GetRiskLimitResponse\x12I\n" +
+	"\fSetRiskLimit\x12\x1b.oms.v1.SetRiskLimitRequest\x1a\x1c.oms.v1.SetRiskLimitResponse\x12C\n" +
+	"\n" +
+	"GetAccount\x12\x19.oms.v1.GetAccountRequest\x1a\x1a.oms.v1.GetAccountResponse\x12@\n" +
+	"\tGetTrades\x12\x18.oms.v1.GetTradesRequest\x1a\x19.oms.v1.GetTradesResponse\x12C\n" +
+	"\n" +
+	"GetFeeRate\x12\x19.oms.v1.GetFeeRateRequest\x1a\x1a.oms.v1.GetFeeRateResponse\x12I\n" +
+	"\fGetMarkPrice\x12\x1b.oms.v1.GetMarkPriceRequest\x1a\x1c.oms.v1.GetMarkPriceResponse\x12O\n" +
+	"\x0eGetFundingRate\x12\x1d.oms.v1.GetFundingRateRequest\x1a\x1e.oms.v1.GetFundingRateResponse2\x82\x05\n" +
+	"\bOMSAdmin\x12U\n" +
+	"\x10UpsertInstrument\x12\x1f.oms.v1.UpsertInstrumentRequest\x1a .oms.v1.UpsertInstrumentResponse\x12L\n" +
+	"\rGetInstrument\x12\x1c.oms.v1.GetInstrumentRequest\x1a\x1d.oms.v1.GetInstrumentResponse\x12R\n" +
+	"\x0fListInstruments\x12\x1e.oms.v1.ListInstrumentsRequest\x1a\x1f.oms.v1.ListInstrumentsResponse\x12^\n" +
+	"\x13SetInstrumentStatus\x12\".oms.v1.SetInstrumentStatusRequest\x1a#.oms.v1.SetInstrumentStatusResponse\x12<\n" +
+	"\aDeposit\x12\x17.oms.v1.TransferRequest\x1a\x18.oms.v1.TransferResponse\x12=\n" +
+	"\bWithdraw\x12\x17.oms.v1.TransferRequest\x1a\x18.oms.v1.TransferResponse\x12U\n" +
+	"\x10GetInsuranceFund\x12\x1f.oms.v1.GetInsuranceFundRequest\x1a .oms.v1.GetInsuranceFundResponse\x12I\n" +
+	"\fGetFeeIncome\x12\x1b.oms.v1.GetFeeIncomeRequest\x1a\x1c.oms.v1.GetFeeIncomeResponseB\x1eZ\x1coms-contract/api/proto;omsv1b\x06proto3"
 
 var (
 	file_api_proto_oms_proto_rawDescOnce sync.Once
@@ -781,43 +4450,174 @@ func file_api_proto_oms_proto_rawDescGZIP() []byte {
 	return file_api_proto_oms_proto_rawDescData
 }
 
-var file_api_proto_oms_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_api_proto_oms_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_api_proto_oms_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
+var file_api_proto_oms_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
 var file_api_proto_oms_proto_goTypes = []any{
-	(Side)(0),                     // 0: oms.v1.Side
-	(OrderType)(0),                // 1: oms.v1.OrderType
-	(OrderStatus)(0),              // 2: oms.v1.OrderStatus
-	(*CreateOrderRequest)(nil),    // 3: oms.v1.CreateOrderRequest
-	(*CreateOrderResponse)(nil),   // 4: oms.v1.CreateOrderResponse
-	(*CancelOrderRequest)(nil),    // 5: oms.v1.CancelOrderRequest
-	(*CancelOrderResponse)(nil),   // 6: oms.v1.CancelOrderResponse
-	(*GetOrderRequest)(nil),       // 7: oms.v1.GetOrderRequest
-	(*GetOrderResponse)(nil),      // 8: oms.v1.GetOrderResponse
-	(*GetPositionRequest)(nil),    // 9: oms.v1.GetPositionRequest
-	(*GetPositionResponse)(nil),   // 10: oms.v1.GetPositionResponse
-	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(Side)(0),                           // 0: oms.v1.Side
+	(OrderType)(0),                      // 1: oms.v1.OrderType
+	(TriggerPriceType)(0),               // 2: oms.v1.TriggerPriceType
+	(StopOrderStatus)(0),                // 3: oms.v1.StopOrderStatus
+	(OrderStatus)(0),                    // 4: oms.v1.OrderStatus
+	(PositionSide)(0),                   // 5: oms.v1.PositionSide
+	(PositionMode)(0),                   // 6: oms.v1.PositionMode
+	(MarginMode)(0),                     // 7: oms.v1.MarginMode
+	(RejectCode)(0),                     // 8: oms.v1.RejectCode
+	(InstrumentStatus)(0),               // 9: oms.v1.InstrumentStatus
+	(*CreateOrderRequest)(nil),          // 10: oms.v1.CreateOrderRequest
+	(*CreateOrderResponse)(nil),         // 11: oms.v1.CreateOrderResponse
+	(*CancelOrderRequest)(nil),          // 12: oms.v1.CancelOrderRequest
+	(*CancelOrderResponse)(nil),         // 13: oms.v1.CancelOrderResponse
+	(*AmendOrderRequest)(nil),           // 14: oms.v1.AmendOrderRequest
+	(*AmendOrderResponse)(nil),          // 15: oms.v1.AmendOrderResponse
+	(*GetOrderRequest)(nil),             // 16: oms.v1.GetOrderRequest
+	(*GetOrderResponse)(nil),            // 17: oms.v1.GetOrderResponse
+	(*GetStopOrdersRequest)(nil),        // 18: oms.v1.GetStopOrdersRequest
+	(*StopOrder)(nil),                   // 19: oms.v1.StopOrder
+	(*GetStopOrdersResponse)(nil),       // 20: oms.v1.GetStopOrdersResponse
+	(*GetPositionRequest)(nil),          // 21: oms.v1.GetPositionRequest
+	(*GetPositionResponse)(nil),         // 22: oms.v1.GetPositionResponse
+	(*SetLeverageRequest)(nil),          // 23: oms.v1.SetLeverageRequest
+	(*SetLeverageResponse)(nil),         // 24: oms.v1.SetLeverageResponse
+	(*SetPositionModeRequest)(nil),      // 25: oms.v1.SetPositionModeRequest
+	(*SetPositionModeResponse)(nil),     // 26: oms.v1.SetPositionModeResponse
+	(*SetMarginModeRequest)(nil),        // 27: oms.v1.SetMarginModeRequest
+	(*SetMarginModeResponse)(nil),       // 28: oms.v1.SetMarginModeResponse
+	(*RiskTier)(nil),                    // 29: oms.v1.RiskTier
+	(*GetRiskLimitRequest)(nil),         // 30: oms.v1.GetRiskLimitRequest
+	(*GetRiskLimitResponse)(nil),        // 31: oms.v1.GetRiskLimitResponse
+	(*SetRiskLimitRequest)(nil),         // 32: oms.v1.SetRiskLimitRequest
+	(*SetRiskLimitResponse)(nil),        // 33: oms.v1.SetRiskLimitResponse
+	(*Instrument)(nil),                  // 34: oms.v1.Instrument
+	(*UpsertInstrumentRequest)(nil),     // 35: oms.v1.UpsertInstrumentRequest
+	(*UpsertInstrumentResponse)(nil),    // 36: oms.v1.UpsertInstrumentResponse
+	(*GetInstrumentRequest)(nil),        // 37: oms.v1.GetInstrumentRequest
+	(*GetInstrumentResponse)(nil),       // 38: oms.v1.GetInstrumentResponse
+	(*ListInstrumentsRequest)(nil),      // 39: oms.v1.ListInstrumentsRequest
+	(*ListInstrumentsResponse)(nil),     // 40: oms.v1.ListInstrumentsResponse
+	(*SetInstrumentStatusRequest)(nil),  // 41: oms.v1.SetInstrumentStatusRequest
+	(*SetInstrumentStatusResponse)(nil), // 42: oms.v1.SetInstrumentStatusResponse
+	(*Balance)(nil),                     // 43: oms.v1.Balance
+	(*JournalEntry)(nil),                // 44: oms.v1.JournalEntry
+	(*GetAccountRequest)(nil),           // 45: oms.v1.GetAccountRequest
+	(*GetAccountResponse)(nil),          // 46: oms.v1.GetAccountResponse
+	(*GetMarkPriceRequest)(nil),         // 47: oms.v1.GetMarkPriceRequest
+	(*GetMarkPriceResponse)(nil),        // 48: oms.v1.GetMarkPriceResponse
+	(*Trade)(nil),                       // 49: oms.v1.Trade
+	(*GetTradesRequest)(nil),            // 50: oms.v1.GetTradesRequest
+	(*GetTradesResponse)(nil),           // 51: oms.v1.GetTradesResponse
+	(*GetFeeRateRequest)(nil),           // 52: oms.v1.GetFeeRateRequest
+	(*GetFeeRateResponse)(nil),          // 53: oms.v1.GetFeeRateResponse
+	(*GetFundingRateRequest)(nil),       // 54: oms.v1.GetFundingRateRequest
+	(*GetFundingRateResponse)(nil),      // 55: oms.v1.GetFundingRateResponse
+	(*TransferRequest)(nil),             // 56: oms.v1.TransferRequest
+	(*TransferResponse)(nil),            // 57: oms.v1.TransferResponse
+	(*GetInsuranceFundRequest)(nil),     // 58: oms.v1.GetInsuranceFundRequest
+	(*GetInsuranceFundResponse)(nil),    // 59: oms.v1.GetInsuranceFundResponse
+	(*GetFeeIncomeRequest)(nil),         // 60: oms.v1.GetFeeIncomeRequest
+	(*GetFeeIncomeResponse)(nil),        // 61: oms.v1.GetFeeIncomeResponse
+	(*timestamppb.Timestamp)(nil),       // 62: google.protobuf.Timestamp
 }
 var file_api_proto_oms_proto_depIdxs = []int32{
 	0,  // 0: oms.v1.CreateOrderRequest.side:type_name -> oms.v1.Side
 	1,  // 1: oms.v1.CreateOrderRequest.type:type_name -> oms.v1.OrderType
-	2,  // 2: oms.v1.CreateOrderResponse.status:type_name -> oms.v1.OrderStatus
-	0,  // 3: oms.v1.GetOrderResponse.side:type_name -> oms.v1.Side
-	1,  // 4: oms.v1.GetOrderResponse.type:type_name -> oms.v1.OrderType
-	2,  // 5: oms.v1.GetOrderResponse.status:type_name -> oms.v1.OrderStatus
-	11, // 6: oms.v1.GetOrderResponse.created_at:type_name -> google.protobuf.Timestamp
-	3,  // 7: oms.v1.OMS.CreateOrder:input_type -> oms.v1.CreateOrderRequest
-	5,  // 8: oms.v1.OMS.CancelOrder:input_type -> oms.v1.CancelOrderRequest
-	7,  // 9: oms.v1.OMS.GetOrder:input_type -> oms.v1.GetOrderRequest
-	9,  // 10: oms.v1.OMS.GetPosition:input_type -> oms.v1.GetPositionRequest
-	4,  // 11: oms.v1.OMS.CreateOrder:output_type -> oms.v1.CreateOrderResponse
-	6,  // 12: oms.v1.OMS.CancelOrder:output_type -> oms.v1.CancelOrderResponse
-	8,  // 13: oms.v1.OMS.GetOrder:output_type -> oms.v1.GetOrderResponse
-	10, // 14: oms.v1.OMS.GetPosition:output_type -> oms.v1.GetPositionResponse
-	11, // [11:15] is the sub-list for method output_type
-	7,  // [7:11] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	5,  // 2: oms.v1.CreateOrderRequest.position_side:type_name -> oms.v1.PositionSide
+	2,  // 3: oms.v1.CreateOrderRequest.trigger_by:type_name -> oms.v1.TriggerPriceType
+	4,  // 4: oms.v1.CreateOrderResponse.status:type_name -> oms.v1.OrderStatus
+	8,  // 5: oms.v1.CreateOrderResponse.reject_code:type_name -> oms.v1.RejectCode
+	4,  // 6: oms.v1.AmendOrderResponse.status:type_name -> oms.v1.OrderStatus
+	0,  // 7: oms.v1.GetOrderResponse.side:type_name -> oms.v1.Side
+	1,  // 8: oms.v1.GetOrderResponse.type:type_name -> oms.v1.OrderType
+	4,  // 9: oms.v1.GetOrderResponse.status:type_name -> oms.v1.OrderStatus
+	62, // 10: oms.v1.GetOrderResponse.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 11: oms.v1.StopOrder.side:type_name -> oms.v1.Side
+	1,  // 12: oms.v1.StopOrder.type:type_name -> oms.v1.OrderType
+	5,  // 13: oms.v1.StopOrder.position_side:type_name -> oms.v1.PositionSide
+	2,  // 14: oms.v1.StopOrder.trigger_by:type_name -> oms.v1.TriggerPriceType
+	3,  // 15: oms.v1.StopOrder.status:type_name -> oms.v1.StopOrderStatus
+	62, // 16: oms.v1.StopOrder.created_at:type_name -> google.protobuf.Timestamp
+	19, // 17: oms.v1.GetStopOrdersResponse.stop_orders:type_name -> oms.v1.StopOrder
+	5,  // 18: oms.v1.GetPositionRequest.position_side:type_name -> oms.v1.PositionSide
+	5,  // 19: oms.v1.GetPositionResponse.position_side:type_name -> oms.v1.PositionSide
+	6,  // 20: oms.v1.SetPositionModeRequest.mode:type_name -> oms.v1.PositionMode
+	6,  // 21: oms.v1.SetPositionModeResponse.mode:type_name -> oms.v1.PositionMode
+	7,  // 22: oms.v1.SetMarginModeRequest.mode:type_name -> oms.v1.MarginMode
+	7,  // 23: oms.v1.SetMarginModeResponse.mode:type_name -> oms.v1.MarginMode
+	29, // 24: oms.v1.GetRiskLimitResponse.tiers:type_name -> oms.v1.RiskTier
+	9,  // 25: oms.v1.Instrument.status:type_name -> oms.v1.InstrumentStatus
+	29, // 26: oms.v1.Instrument.risk_tiers:type_name -> oms.v1.RiskTier
+	34, // 27: oms.v1.UpsertInstrumentRequest.instrument:type_name -> oms.v1.Instrument
+	34, // 28: oms.v1.UpsertInstrumentResponse.instrument:type_name -> oms.v1.Instrument
+	34, // 29: oms.v1.GetInstrumentResponse.instrument:type_name -> oms.v1.Instrument
+	34, // 30: oms.v1.ListInstrumentsResponse.instruments:type_name -> oms.v1.Instrument
+	9,  // 31: oms.v1.SetInstrumentStatusRequest.status:type_name -> oms.v1.InstrumentStatus
+	34, // 32: oms.v1.SetInstrumentStatusResponse.instrument:type_name -> oms.v1.Instrument
+	62, // 33: oms.v1.JournalEntry.created_at:type_name -> google.protobuf.Timestamp
+	43, // 34: oms.v1.GetAccountResponse.balances:type_name -> oms.v1.Balance
+	44, // 35: oms.v1.GetAccountResponse.journal:type_name -> oms.v1.JournalEntry
+	7,  // 36: oms.v1.GetAccountResponse.margin_mode:type_name -> oms.v1.MarginMode
+	62, // 37: oms.v1.GetMarkPriceResponse.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 38: oms.v1.Trade.side:type_name -> oms.v1.Side
+	5,  // 39: oms.v1.Trade.position_side:type_name -> oms.v1.PositionSide
+	62, // 40: oms.v1.Trade.created_at:type_name -> google.protobuf.Timestamp
+	49, // 41: oms.v1.GetTradesResponse.trades:type_name -> oms.v1.Trade
+	62, // 42: oms.v1.GetFundingRateResponse.next_funding_time:type_name -> google.protobuf.Timestamp
+	62, // 43: oms.v1.GetFundingRateResponse.last_funding_time:type_name -> google.protobuf.Timestamp
+	43, // 44: oms.v1.TransferResponse.balance:type_name -> oms.v1.Balance
+	44, // 45: oms.v1.GetInsuranceFundResponse.history:type_name -> oms.v1.JournalEntry
+	44, // 46: oms.v1.GetFeeIncomeResponse.history:type_name -> oms.v1.JournalEntry
+	10, // 47: oms.v1.OMS.CreateOrder:input_type -> oms.v1.CreateOrderRequest
+	12, // 48: oms.v1.OMS.CancelOrder:input_type -> oms.v1.CancelOrderRequest
+	14, // 49: oms.v1.OMS.AmendOrder:input_type -> oms.v1.AmendOrderRequest
+	16, // 50: oms.v1.OMS.GetOrder:input_type -> oms.v1.GetOrderRequest
+	18, // 51: oms.v1.OMS.GetStopOrders:input_type -> oms.v1.GetStopOrdersRequest
+	21, // 52: oms.v1.OMS.GetPosition:input_type -> oms.v1.GetPositionRequest
+	23, // 53: oms.v1.OMS.SetLeverage:input_type -> oms.v1.SetLeverageRequest
+	25, // 54: oms.v1.OMS.SetPositionMode:input_type -> oms.v1.SetPositionModeRequest
+	27, // 55: oms.v1.OMS.SetMarginMode:input_type -> oms.v1.SetMarginModeRequest
+	30, // 56: oms.v1.OMS.GetRiskLimit:input_type -> oms.v1.GetRiskLimitRequest
+	32, // 57: oms.v1.OMS.SetRiskLimit:input_type -> oms.v1.SetRiskLimitRequest
+	45, // 58: oms.v1.OMS.GetAccount:input_type -> oms.v1.GetAccountRequest
+	50, // 59: oms.v1.OMS.GetTrades:input_type -> oms.v1.GetTradesRequest
+	52, // 60: oms.v1.OMS.GetFeeRate:input_type -> oms.v1.GetFeeRateRequest
+	47, // 61: oms.v1.OMS.GetMarkPrice:input_type -> oms.v1.GetMarkPriceRequest
+	54, // 62: oms.v1.OMS.GetFundingRate:input_type -> oms.v1.GetFundingRateRequest
+	35, // 63: oms.v1.OMSAdmin.UpsertInstrument:input_type -> oms.v1.UpsertInstrumentRequest
+	37, // 64: oms.v1.OMSAdmin.GetInstrument:input_type -> oms.v1.GetInstrumentRequest
+	39, // 65: oms.v1.OMSAdmin.ListInstruments:input_type -> oms.v1.ListInstrumentsRequest
+	41, // 66: oms.v1.OMSAdmin.SetInstrumentStatus:input_type -> oms.v1.SetInstrumentStatusRequest
+	56, // 67: oms.v1.OMSAdmin.Deposit:input_type -> oms.v1.TransferRequest
+	56, // 68: oms.v1.OMSAdmin.Withdraw:input_type -> oms.v1.TransferRequest
+	58, // 69: oms.v1.OMSAdmin.GetInsuranceFund:input_type -> oms.v1.GetInsuranceFundRequest
+	60, // 70: oms.v1.OMSAdmin.GetFeeIncome:input_type -> oms.v1.GetFeeIncomeRequest
+	11, // 71: oms.v1.OMS.CreateOrder:output_type -> oms.v1.CreateOrderResponse
+	13, // 72: oms.v1.OMS.CancelOrder:output_type -> oms.v1.CancelOrderResponse
+	15, // 73: oms.v1.OMS.AmendOrder:output_type -> oms.v1.AmendOrderResponse
+	17, // 74: oms.v1.OMS.GetOrder:output_type -> oms.v1.GetOrderResponse
+	20, // 75: oms.v1.OMS.GetStopOrders:output_type -> oms.v1.GetStopOrdersResponse
+	22, // 76: oms.v1.OMS.GetPosition:output_type -> oms.v1.GetPositionResponse
+	24, // 77: oms.v1.OMS.SetLeverage:output_type -> oms.v1.SetLeverageResponse
+	26, // 78: oms.v1.OMS.SetPositionMode:output_type -> oms.v1.SetPositionModeResponse
+	28, // 79: oms.v1.OMS.SetMarginMode:output_type -> oms.v1.SetMarginModeResponse
+	31, // 80: oms.v1.OMS.GetRiskLimit:output_type -> oms.v1.GetRiskLimitResponse
+	33, // 81: oms.v1.OMS.SetRiskLimit:output_type -> oms.v1.SetRiskLimitResponse
+	46, // 82: oms.v1.OMS.GetAccount:output_type -> oms.v1.GetAccountResponse
+	51, // 83: oms.v1.OMS.GetTrades:output_type -> oms.v1.GetTradesResponse
+	53, // 84: oms.v1.OMS.GetFeeRate:output_type -> oms.v1.GetFeeRateResponse
+	48, // 85: oms.v1.OMS.GetMarkPrice:output_type -> oms.v1.GetMarkPriceResponse
+	55, // 86: oms.v1.OMS.GetFundingRate:output_type -> oms.v1.GetFundingRateResponse
+	36, // 87: oms.v1.OMSAdmin.UpsertInstrument:output_type -> oms.v1.UpsertInstrumentResponse
+	38, // 88: oms.v1.OMSAdmin.GetInstrument:output_type -> oms.v1.GetInstrumentResponse
+	40, // 89: oms.v1.OMSAdmin.ListInstruments:output_type -> oms.v1.ListInstrumentsResponse
+	42, // 90: oms.v1.OMSAdmin.SetInstrumentStatus:output_type -> oms.v1.SetInstrumentStatusResponse
+	57, // 91: oms.v1.OMSAdmin.Deposit:output_type -> oms.v1.TransferResponse
+	57, // 92: oms.v1.OMSAdmin.Withdraw:output_type -> oms.v1.TransferResponse
+	59, // 93: oms.v1.OMSAdmin.GetInsuranceFund:output_type -> oms.v1.GetInsuranceFundResponse
+	61, // 94: oms.v1.OMSAdmin.GetFeeIncome:output_type -> oms.v1.GetFeeIncomeResponse
+	71, // [71:95] is the sub-list for method output_type
+	47, // [47:71] is the sub-list for method input_type
+	47, // [47:47] is the sub-list for extension type_name
+	47, // [47:47] is the sub-list for extension extendee
+	0,  // [0:47] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_oms_proto_init() }
@@ -830,10 +4630,10 @@ func file_api_proto_oms_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_oms_proto_rawDesc), len(file_api_proto_oms_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   8,
+			NumEnums:      10,
+			NumMessages:   52,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_api_proto_oms_proto_goTypes,
 		DependencyIndexes: file_api_proto_oms_proto_depIdxs,
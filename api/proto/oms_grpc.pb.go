@@ -19,10 +19,22 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	OMS_CreateOrder_FullMethodName = "/oms.v1.OMS/CreateOrder"
-	OMS_CancelOrder_FullMethodName = "/oms.v1.OMS/CancelOrder"
-	OMS_GetOrder_FullMethodName    = "/oms.v1.OMS/GetOrder"
-	OMS_GetPosition_FullMethodName = "/oms.v1.OMS/GetPosition"
+	OMS_CreateOrder_FullMethodName     = "/oms.v1.OMS/CreateOrder"
+	OMS_CancelOrder_FullMethodName     = "/oms.v1.OMS/CancelOrder"
+	OMS_AmendOrder_FullMethodName      = "/oms.v1.OMS/AmendOrder"
+	OMS_GetOrder_FullMethodName        = "/oms.v1.OMS/GetOrder"
+	OMS_GetStopOrders_FullMethodName   = "/oms.v1.OMS/GetStopOrders"
+	OMS_GetPosition_FullMethodName     = "/oms.v1.OMS/GetPosition"
+	OMS_SetLeverage_FullMethodName     = "/oms.v1.OMS/SetLeverage"
+	OMS_SetPositionMode_FullMethodName = "/oms.v1.OMS/SetPositionMode"
+	OMS_SetMarginMode_FullMethodName   = "/oms.v1.OMS/SetMarginMode"
+	OMS_GetRiskLimit_FullMethodName    = "/oms.v1.OMS/GetRiskLimit"
+	OMS_SetRiskLimit_FullMethodName    = "/oms.v1.OMS/SetRiskLimit"
+	OMS_GetAccount_FullMethodName      = "/oms.v1.OMS/GetAccount"
+	OMS_GetTrades_FullMethodName       = "/oms.v1.OMS/GetTrades"
+	OMS_GetFeeRate_FullMethodName      = "/oms.v1.OMS/GetFeeRate"
+	OMS_GetMarkPrice_FullMethodName    = "/oms.v1.OMS/GetMarkPrice"
+	OMS_GetFundingRate_FullMethodName  = "/oms.v1.OMS/GetFundingRate"
 )
 
 // OMSClient is the client API for OMS service.
@@ -34,9 +46,23 @@ type OMSClient interface {
 	// Order Management
 	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*CreateOrderResponse, error)
 	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error)
+	AmendOrder(ctx context.Context, in *AmendOrderRequest, opts ...grpc.CallOption) (*AmendOrderResponse, error)
 	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*GetOrderResponse, error)
+	GetStopOrders(ctx context.Context, in *GetStopOrdersRequest, opts ...grpc.CallOption) (*GetStopOrdersResponse, error)
 	// Position Management
 	GetPosition(ctx context.Context, in *GetPositionRequest, opts ...grpc.CallOption) (*GetPositionResponse, error)
+	SetLeverage(ctx context.Context, in *SetLeverageRequest, opts ...grpc.CallOption) (*SetLeverageResponse, error)
+	SetPositionMode(ctx context.Context, in *SetPositionModeRequest, opts ...grpc.CallOption) (*SetPositionModeResponse, error)
+	SetMarginMode(ctx context.Context, in *SetMarginModeRequest, opts ...grpc.CallOption) (*SetMarginModeResponse, error)
+	GetRiskLimit(ctx context.Context, in *GetRiskLimitRequest, opts ...grpc.CallOption) (*GetRiskLimitResponse, error)
+	SetRiskLimit(ctx context.Context, in *SetRiskLimitRequest, opts ...grpc.CallOption) (*SetRiskLimitResponse, error)
+	// Account Management
+	GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*GetAccountResponse, error)
+	GetTrades(ctx context.Context, in *GetTradesRequest, opts ...grpc.CallOption) (*GetTradesResponse, error)
+	GetFeeRate(ctx context.Context, in *GetFeeRateRequest, opts ...grpc.CallOption) (*GetFeeRateResponse, error)
+	// Market Data
+	GetMarkPrice(ctx context.Context, in *GetMarkPriceRequest, opts ...grpc.CallOption) (*GetMarkPriceResponse, error)
+	GetFundingRate(ctx context.Context, in *GetFundingRateRequest, opts ...grpc.CallOption) (*GetFundingRateResponse, error)
 }
 
 type oMSClient struct {
@@ -67,6 +93,16 @@ func (c *oMSClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opt
 	return out, nil
 }
 
+func (c *oMSClient) AmendOrder(ctx context.Context, in *AmendOrderRequest, opts ...grpc.CallOption) (*AmendOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AmendOrderResponse)
+	err := c.cc.Invoke(ctx, OMS_AmendOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *oMSClient) GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*GetOrderResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetOrderResponse)
@@ -77,6 +113,16 @@ func (c *oMSClient) GetOrder(ctx context.Context, in *GetOrderRequest, opts ...g
 	return out, nil
 }
 
+func (c *oMSClient) GetStopOrders(ctx context.Context, in *GetStopOrdersRequest, opts ...grpc.CallOption) (*GetStopOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStopOrdersResponse)
+	err := c.cc.Invoke(ctx, OMS_GetStopOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *oMSClient) GetPosition(ctx context.Context, in *GetPositionRequest, opts ...grpc.CallOption) (*GetPositionResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetPositionResponse)
@@ -87,6 +133,106 @@ func (c *oMSClient) GetPosition(ctx context.Context, in *GetPositionRequest, opt
 	return out, nil
 }
 
+func (c *oMSClient) SetLeverage(ctx context.Context, in *SetLeverageRequest, opts ...grpc.CallOption) (*SetLeverageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetLeverageResponse)
+	err := c.cc.Invoke(ctx, OMS_SetLeverage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oMSClient) SetPositionMode(ctx context.Context, in *SetPositionModeRequest, opts ...grpc.CallOption) (*SetPositionModeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetPositionModeResponse)
+	err := c.cc.Invoke(ctx, OMS_SetPositionMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oMSClient) SetMarginMode(ctx context.Context, in *SetMarginModeRequest, opts ...grpc.CallOption) (*SetMarginModeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetMarginModeResponse)
+	err := c.cc.Invoke(ctx, OMS_SetMarginMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oMSClient) GetRiskLimit(ctx context.Context, in *GetRiskLimitRequest, opts ...grpc.CallOption) (*GetRiskLimitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRiskLimitResponse)
+	err := c.cc.Invoke(ctx, OMS_GetRiskLimit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oMSClient) SetRiskLimit(ctx context.Context, in *SetRiskLimitRequest, opts ...grpc.CallOption) (*SetRiskLimitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetRiskLimitResponse)
+	err := c.cc.Invoke(ctx, OMS_SetRiskLimit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oMSClient) GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*GetAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAccountResponse)
+	err := c.cc.Invoke(ctx, OMS_GetAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oMSClient) GetTrades(ctx context.Context, in *GetTradesRequest, opts ...grpc.CallOption) (*GetTradesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTradesResponse)
+	err := c.cc.Invoke(ctx, OMS_GetTrades_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oMSClient) GetFeeRate(ctx context.Context, in *GetFeeRateRequest, opts ...grpc.CallOption) (*GetFeeRateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFeeRateResponse)
+	err := c.cc.Invoke(ctx, OMS_GetFeeRate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oMSClient) GetMarkPrice(ctx context.Context, in *GetMarkPriceRequest, opts ...grpc.CallOption) (*GetMarkPriceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMarkPriceResponse)
+	err := c.cc.Invoke(ctx, OMS_GetMarkPrice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oMSClient) GetFundingRate(ctx context.Context, in *GetFundingRateRequest, opts ...grpc.CallOption) (*GetFundingRateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFundingRateResponse)
+	err := c.cc.Invoke(ctx, OMS_GetFundingRate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OMSServer is the server API for OMS service.
 // All implementations must embed UnimplementedOMSServer
 // for forward compatibility.
@@ -96,9 +242,23 @@ type OMSServer interface {
 	// Order Management
 	CreateOrder(context.Context, *CreateOrderRequest) (*CreateOrderResponse, error)
 	CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error)
+	AmendOrder(context.Context, *AmendOrderRequest) (*AmendOrderResponse, error)
 	GetOrder(context.Context, *GetOrderRequest) (*GetOrderResponse, error)
+	GetStopOrders(context.Context, *GetStopOrdersRequest) (*GetStopOrdersResponse, error)
 	// Position Management
 	GetPosition(context.Context, *GetPositionRequest) (*GetPositionResponse, error)
+	SetLeverage(context.Context, *SetLeverageRequest) (*SetLeverageResponse, error)
+	SetPositionMode(context.Context, *SetPositionModeRequest) (*SetPositionModeResponse, error)
+	SetMarginMode(context.Context, *SetMarginModeRequest) (*SetMarginModeResponse, error)
+	GetRiskLimit(context.Context, *GetRiskLimitRequest) (*GetRiskLimitResponse, error)
+	SetRiskLimit(context.Context, *SetRiskLimitRequest) (*SetRiskLimitResponse, error)
+	// Account Management
+	GetAccount(context.Context, *GetAccountRequest) (*GetAccountResponse, error)
+	GetTrades(context.Context, *GetTradesRequest) (*GetTradesResponse, error)
+	GetFeeRate(context.Context, *GetFeeRateRequest) (*GetFeeRateResponse, error)
+	// Market Data
+	GetMarkPrice(context.Context, *GetMarkPriceRequest) (*GetMarkPriceResponse, error)
+	GetFundingRate(context.Context, *GetFundingRateRequest) (*GetFundingRateResponse, error)
 	mustEmbedUnimplementedOMSServer()
 }
 
@@ -115,12 +275,48 @@ func (UnimplementedOMSServer) CreateOrder(context.Context, *CreateOrderRequest)
 func (UnimplementedOMSServer) CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CancelOrder not implemented")
 }
+func (UnimplementedOMSServer) AmendOrder(context.Context, *AmendOrderRequest) (*AmendOrderResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AmendOrder not implemented")
+}
 func (UnimplementedOMSServer) GetOrder(context.Context, *GetOrderRequest) (*GetOrderResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetOrder not implemented")
 }
+func (UnimplementedOMSServer) GetStopOrders(context.Context, *GetStopOrdersRequest) (*GetStopOrdersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStopOrders not implemented")
+}
 func (UnimplementedOMSServer) GetPosition(context.Context, *GetPositionRequest) (*GetPositionResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetPosition not implemented")
 }
+func (UnimplementedOMSServer) SetLeverage(context.Context, *SetLeverageRequest) (*SetLeverageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetLeverage not implemented")
+}
+func (UnimplementedOMSServer) SetPositionMode(context.Context, *SetPositionModeRequest) (*SetPositionModeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetPositionMode not implemented")
+}
+func (UnimplementedOMSServer) SetMarginMode(context.Context, *SetMarginModeRequest) (*SetMarginModeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetMarginMode not implemented")
+}
+func (UnimplementedOMSServer) GetRiskLimit(context.Context, *GetRiskLimitRequest) (*GetRiskLimitResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRiskLimit not implemented")
+}
+func (UnimplementedOMSServer) SetRiskLimit(context.Context, *SetRiskLimitRequest) (*SetRiskLimitResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetRiskLimit not implemented")
+}
+func (UnimplementedOMSServer) GetAccount(context.Context, *GetAccountRequest) (*GetAccountResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAccount not implemented")
+}
+func (UnimplementedOMSServer) GetTrades(context.Context, *GetTradesRequest) (*GetTradesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTrades not implemented")
+}
+func (UnimplementedOMSServer) GetFeeRate(context.Context, *GetFeeRateRequest) (*GetFeeRateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFeeRate not implemented")
+}
+func (UnimplementedOMSServer) GetMarkPrice(context.Context, *GetMarkPriceRequest) (*GetMarkPriceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMarkPrice not implemented")
+}
+func (UnimplementedOMSServer) GetFundingRate(context.Context, *GetFundingRateRequest) (*GetFundingRateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFundingRate not implemented")
+}
 func (UnimplementedOMSServer) mustEmbedUnimplementedOMSServer() {}
 func (UnimplementedOMSServer) testEmbeddedByValue()             {}
 
@@ -178,6 +374,24 @@ func _OMS_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OMS_AmendOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AmendOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OMSServer).AmendOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OMS_AmendOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OMSServer).AmendOrder(ctx, req.(*AmendOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _OMS_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetOrderRequest)
 	if err := dec(in); err != nil {
@@ -196,6 +410,24 @@ func _OMS_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OMS_GetStopOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStopOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OMSServer).GetStopOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OMS_GetStopOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OMSServer).GetStopOrders(ctx, req.(*GetStopOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _OMS_GetPosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetPositionRequest)
 	if err := dec(in); err != nil {
@@ -214,6 +446,186 @@ func _OMS_GetPosition_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OMS_SetLeverage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLeverageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OMSServer).SetLeverage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OMS_SetLeverage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OMSServer).SetLeverage(ctx, req.(*SetLeverageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OMS_SetPositionMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPositionModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OMSServer).SetPositionMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OMS_SetPositionMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OMSServer).SetPositionMode(ctx, req.(*SetPositionModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OMS_SetMarginMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMarginModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OMSServer).SetMarginMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OMS_SetMarginMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OMSServer).SetMarginMode(ctx, req.(*SetMarginModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OMS_GetRiskLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRiskLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OMSServer).GetRiskLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OMS_GetRiskLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OMSServer).GetRiskLimit(ctx, req.(*GetRiskLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OMS_SetRiskLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRiskLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OMSServer).SetRiskLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OMS_SetRiskLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OMSServer).SetRiskLimit(ctx, req.(*SetRiskLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OMS_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OMSServer).GetAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OMS_GetAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OMSServer).GetAccount(ctx, req.(*GetAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OMS_GetTrades_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTradesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OMSServer).GetTrades(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OMS_GetTrades_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OMSServer).GetTrades(ctx, req.(*GetTradesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OMS_GetFeeRate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeeRateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OMSServer).GetFeeRate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OMS_GetFeeRate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OMSServer).GetFeeRate(ctx, req.(*GetFeeRateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OMS_GetMarkPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMarkPriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OMSServer).GetMarkPrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OMS_GetMarkPrice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OMSServer).GetMarkPrice(ctx, req.(*GetMarkPriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OMS_GetFundingRate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFundingRateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OMSServer).GetFundingRate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OMS_GetFundingRate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OMSServer).GetFundingRate(ctx, req.(*GetFundingRateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OMS_ServiceDesc is the grpc.ServiceDesc for OMS service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,14 +641,442 @@ var OMS_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CancelOrder",
 			Handler:    _OMS_CancelOrder_Handler,
 		},
+		{
+			MethodName: "AmendOrder",
+			Handler:    _OMS_AmendOrder_Handler,
+		},
 		{
 			MethodName: "GetOrder",
 			Handler:    _OMS_GetOrder_Handler,
 		},
+		{
+			MethodName: "GetStopOrders",
+			Handler:    _OMS_GetStopOrders_Handler,
+		},
 		{
 			MethodName: "GetPosition",
 			Handler:    _OMS_GetPosition_Handler,
 		},
+		{
+			MethodName: "SetLeverage",
+			Handler:    _OMS_SetLeverage_Handler,
+		},
+		{
+			MethodName: "SetPositionMode",
+			Handler:    _OMS_SetPositionMode_Handler,
+		},
+		{
+			MethodName: "SetMarginMode",
+			Handler:    _OMS_SetMarginMode_Handler,
+		},
+		{
+			MethodName: "GetRiskLimit",
+			Handler:    _OMS_GetRiskLimit_Handler,
+		},
+		{
+			MethodName: "SetRiskLimit",
+			Handler:    _OMS_SetRiskLimit_Handler,
+		},
+		{
+			MethodName: "GetAccount",
+			Handler:    _OMS_GetAccount_Handler,
+		},
+		{
+			MethodName: "GetTrades",
+			Handler:    _OMS_GetTrades_Handler,
+		},
+		{
+			MethodName: "GetFeeRate",
+			Handler:    _OMS_GetFeeRate_Handler,
+		},
+		{
+			MethodName: "GetMarkPrice",
+			Handler:    _OMS_GetMarkPrice_Handler,
+		},
+		{
+			MethodName: "GetFundingRate",
+			Handler:    _OMS_GetFundingRate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/oms.proto",
+}
+
+const (
+	OMSAdmin_UpsertInstrument_FullMethodName    = "/oms.v1.OMSAdmin/UpsertInstrument"
+	OMSAdmin_GetInstrument_FullMethodName       = "/oms.v1.OMSAdmin/GetInstrument"
+	OMSAdmin_ListInstruments_FullMethodName     = "/oms.v1.OMSAdmin/ListInstruments"
+	OMSAdmin_SetInstrumentStatus_FullMethodName = "/oms.v1.OMSAdmin/SetInstrumentStatus"
+	OMSAdmin_Deposit_FullMethodName             = "/oms.v1.OMSAdmin/Deposit"
+	OMSAdmin_Withdraw_FullMethodName            = "/oms.v1.OMSAdmin/Withdraw"
+	OMSAdmin_GetInsuranceFund_FullMethodName    = "/oms.v1.OMSAdmin/GetInsuranceFund"
+	OMSAdmin_GetFeeIncome_FullMethodName        = "/oms.v1.OMSAdmin/GetFeeIncome"
+)
+
+// OMSAdminClient is the client API for OMSAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Admin operations, not exposed to trading clients
+type OMSAdminClient interface {
+	// Instrument registry
+	UpsertInstrument(ctx context.Context, in *UpsertInstrumentRequest, opts ...grpc.CallOption) (*UpsertInstrumentResponse, error)
+	GetInstrument(ctx context.Context, in *GetInstrumentRequest, opts ...grpc.CallOption) (*GetInstrumentResponse, error)
+	ListInstruments(ctx context.Context, in *ListInstrumentsRequest, opts ...grpc.CallOption) (*ListInstrumentsResponse, error)
+	SetInstrumentStatus(ctx context.Context, in *SetInstrumentStatusRequest, opts ...grpc.CallOption) (*SetInstrumentStatusResponse, error)
+	// Balance transfers in and out of the venue
+	Deposit(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	Withdraw(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	// Insurance fund balance and movements of a settlement asset
+	GetInsuranceFund(ctx context.Context, in *GetInsuranceFundRequest, opts ...grpc.CallOption) (*GetInsuranceFundResponse, error)
+	// Trading fee revenue net of maker rebates
+	GetFeeIncome(ctx context.Context, in *GetFeeIncomeRequest, opts ...grpc.CallOption) (*GetFeeIncomeResponse, error)
+}
+
+type oMSAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOMSAdminClient(cc grpc.ClientConnInterface) OMSAdminClient {
+	return &oMSAdminClient{cc}
+}
+
+func (c *oMSAdminClient) UpsertInstrument(ctx context.Context, in *UpsertInstrumentRequest, opts ...grpc.CallOption) (*UpsertInstrumentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertInstrumentResponse)
+	err := c.cc.Invoke(ctx, OMSAdmin_UpsertInstrument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oMSAdminClient) GetInstrument(ctx context.Context, in *GetInstrumentRequest, opts ...grpc.CallOption) (*GetInstrumentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInstrumentResponse)
+	err := c.cc.Invoke(ctx, OMSAdmin_GetInstrument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oMSAdminClient) ListInstruments(ctx context.Context, in *ListInstrumentsRequest, opts ...grpc.CallOption) (*ListInstrumentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInstrumentsResponse)
+	err := c.cc.Invoke(ctx, OMSAdmin_ListInstruments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oMSAdminClient) SetInstrumentStatus(ctx context.Context, in *SetInstrumentStatusRequest, opts ...grpc.CallOption) (*SetInstrumentStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetInstrumentStatusResponse)
+	err := c.cc.Invoke(ctx, OMSAdmin_SetInstrumentStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oMSAdminClient) Deposit(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, OMSAdmin_Deposit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oMSAdminClient) Withdraw(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, OMSAdmin_Withdraw_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oMSAdminClient) GetInsuranceFund(ctx context.Context, in *GetInsuranceFundRequest, opts ...grpc.CallOption) (*GetInsuranceFundResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInsuranceFundResponse)
+	err := c.cc.Invoke(ctx, OMSAdmin_GetInsuranceFund_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oMSAdminClient) GetFeeIncome(ctx context.Context, in *GetFeeIncomeRequest, opts ...grpc.CallOption) (*GetFeeIncomeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFeeIncomeResponse)
+	err := c.cc.Invoke(ctx, OMSAdmin_GetFeeIncome_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OMSAdminServer is the server API for OMSAdmin service.
+// All implementations must embed UnimplementedOMSAdminServer
+// for forward compatibility.
+//
+// Admin operations, not exposed to trading clients
+type OMSAdminServer interface {
+	// Instrument registry
+	UpsertInstrument(context.Context, *UpsertInstrumentRequest) (*UpsertInstrumentResponse, error)
+	GetInstrument(context.Context, *GetInstrumentRequest) (*GetInstrumentResponse, error)
+	ListInstruments(context.Context, *ListInstrumentsRequest) (*ListInstrumentsResponse, error)
+	SetInstrumentStatus(context.Context, *SetInstrumentStatusRequest) (*SetInstrumentStatusResponse, error)
+	// Balance transfers in and out of the venue
+	Deposit(context.Context, *TransferRequest) (*TransferResponse, error)
+	Withdraw(context.Context, *TransferRequest) (*TransferResponse, error)
+	// Insurance fund balance and movements of a settlement asset
+	GetInsuranceFund(context.Context, *GetInsuranceFundRequest) (*GetInsuranceFundResponse, error)
+	// Trading fee revenue net of maker rebates
+	GetFeeIncome(context.Context, *GetFeeIncomeRequest) (*GetFeeIncomeResponse, error)
+	mustEmbedUnimplementedOMSAdminServer()
+}
+
+// UnimplementedOMSAdminServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOMSAdminServer struct{}
+
+func (UnimplementedOMSAdminServer) UpsertInstrument(context.Context, *UpsertInstrumentRequest) (*UpsertInstrumentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpsertInstrument not implemented")
+}
+func (UnimplementedOMSAdminServer) GetInstrument(context.Context, *GetInstrumentRequest) (*GetInstrumentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetInstrument not implemented")
+}
+func (UnimplementedOMSAdminServer) ListInstruments(context.Context, *ListInstrumentsRequest) (*ListInstrumentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListInstruments not implemented")
+}
+func (UnimplementedOMSAdminServer) SetInstrumentStatus(context.Context, *SetInstrumentStatusRequest) (*SetInstrumentStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetInstrumentStatus not implemented")
+}
+func (UnimplementedOMSAdminServer) Deposit(context.Context, *TransferRequest) (*TransferResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Deposit not implemented")
+}
+func (UnimplementedOMSAdminServer) Withdraw(context.Context, *TransferRequest) (*TransferResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Withdraw not implemented")
+}
+func (UnimplementedOMSAdminServer) GetInsuranceFund(context.Context, *GetInsuranceFundRequest) (*GetInsuranceFundResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetInsuranceFund not implemented")
+}
+func (UnimplementedOMSAdminServer) GetFeeIncome(context.Context, *GetFeeIncomeRequest) (*GetFeeIncomeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFeeIncome not implemented")
+}
+func (UnimplementedOMSAdminServer) mustEmbedUnimplementedOMSAdminServer() {}
+func (UnimplementedOMSAdminServer) testEmbeddedByValue()                  {}
+
+// UnsafeOMSAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OMSAdminServer will
+// result in compilation errors.
+type UnsafeOMSAdminServer interface {
+	mustEmbedUnimplementedOMSAdminServer()
+}
+
+func RegisterOMSAdminServer(s grpc.ServiceRegistrar, srv OMSAdminServer) {
+	// If the following call panics, it indicates UnimplementedOMSAdminServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OMSAdmin_ServiceDesc, srv)
+}
+
+func _OMSAdmin_UpsertInstrument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertInstrumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OMSAdminServer).UpsertInstrument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OMSAdmin_UpsertInstrument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OMSAdminServer).UpsertInstrument(ctx, req.(*UpsertInstrumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OMSAdmin_GetInstrument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstrumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OMSAdminServer).GetInstrument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OMSAdmin_GetInstrument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OMSAdminServer).GetInstrument(ctx, req.(*GetInstrumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OMSAdmin_ListInstruments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInstrumentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OMSAdminServer).ListInstruments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OMSAdmin_ListInstruments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OMSAdminServer).ListInstruments(ctx, req.(*ListInstrumentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OMSAdmin_SetInstrumentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetInstrumentStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OMSAdminServer).SetInstrumentStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OMSAdmin_SetInstrumentStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OMSAdminServer).SetInstrumentStatus(ctx, req.(*SetInstrumentStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OMSAdmin_Deposit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OMSAdminServer).Deposit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OMSAdmin_Deposit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OMSAdminServer).Deposit(ctx, req.(*TransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OMSAdmin_Withdraw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OMSAdminServer).Withdraw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OMSAdmin_Withdraw_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OMSAdminServer).Withdraw(ctx, req.(*TransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OMSAdmin_GetInsuranceFund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInsuranceFundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OMSAdminServer).GetInsuranceFund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OMSAdmin_GetInsuranceFund_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OMSAdminServer).GetInsuranceFund(ctx, req.(*GetInsuranceFundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OMSAdmin_GetFeeIncome_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeeIncomeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OMSAdminServer).GetFeeIncome(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OMSAdmin_GetFeeIncome_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OMSAdminServer).GetFeeIncome(ctx, req.(*GetFeeIncomeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OMSAdmin_ServiceDesc is the grpc.ServiceDesc for OMSAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OMSAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "oms.v1.OMSAdmin",
+	HandlerType: (*OMSAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UpsertInstrument",
+			Handler:    _OMSAdmin_UpsertInstrument_Handler,
+		},
+		{
+			MethodName: "GetInstrument",
+			Handler:    _OMSAdmin_GetInstrument_Handler,
+		},
+		{
+			MethodName: "ListInstruments",
+			Handler:    _OMSAdmin_ListInstruments_Handler,
+		},
+		{
+			MethodName: "SetInstrumentStatus",
+			Handler:    _OMSAdmin_SetInstrumentStatus_Handler,
+		},
+		{
+			MethodName: "Deposit",
+			Handler:    _OMSAdmin_Deposit_Handler,
+		},
+		{
+			MethodName: "Withdraw",
+			Handler:    _OMSAdmin_Withdraw_Handler,
+		},
+		{
+			MethodName: "GetInsuranceFund",
+			Handler:    _OMSAdmin_GetInsuranceFund_Handler,
+		},
+		{
+			MethodName: "GetFeeIncome",
+			Handler:    _OMSAdmin_GetFeeIncome_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/oms.proto",
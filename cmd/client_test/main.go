@@ -30,8 +30,8 @@ func main() {
 		Symbol:   "BTCUSDT",
 		Side:     omsv1.Side_SIDE_BUY,
 		Type:     omsv1.OrderType_ORDER_TYPE_LIMIT,
-		Price:    50000,
-		Quantity: 1.5,
+		Price:    "50000",
+		Quantity: "1.5",
 	})
 	if err != nil {
 		log.Fatalf("could not create order: %v", err)
@@ -58,4 +58,15 @@ func main() {
 	} else {
 		fmt.Printf("Position: %+v\n", p)
 	}
+
+	fmt.Println("------------- Cancel Order -------------")
+	_, err = c.CancelOrder(ctx, &omsv1.CancelOrderRequest{
+		OrderId: r.OrderId,
+		UserId:  12345,
+	})
+	if err != nil {
+		fmt.Printf("CancelOrder failed: %v\n", err)
+	} else {
+		fmt.Printf("Order Canceled: ID=%d\n", r.OrderId)
+	}
 }
@@ -10,18 +10,24 @@ import (
 	"google.golang.org/grpc"
 
 	omsv1 "oms-contract/api/proto"
+	"oms-contract/infra/index"
 	"oms-contract/infra/matching"
 	"oms-contract/internal/domain"
 	"oms-contract/internal/engine"
 	"oms-contract/internal/service"
 	"oms-contract/internal/snapshot"
 	transport "oms-contract/internal/transport/grpc"
+	"oms-contract/pkg/decimal"
 	"oms-contract/pkg/idgen"
 )
 
 func main() {
 	demoMode := flag.Bool("demo", false, "Run the demo scenario")
 	port := flag.Int("port", 50051, "gRPC server port")
+	instrumentsFile := flag.String("instruments", "./configs/instruments.json", "Instrument spec config file")
+	indexFile := flag.String("index", "", "Index price replay file, polled once per second")
+	feeTiersFile := flag.String("fee-tiers", "", "VIP fee tier config file; the built-in schedule when empty")
+	riskRulesFile := flag.String("risk-rules", "", "Pre-trade risk rules config file; the built-in rules when empty")
 	flag.Parse()
 
 	fmt.Println("===========================================")
@@ -60,9 +66,10 @@ func main() {
 	}
 
 	// Print recovery stats
-	fmt.Printf("✓ State recovered: %d orders, %d positions, last_event_id=%d\n",
+	fmt.Printf("✓ State recovered: %d orders, %d positions, %d instruments, last_event_id=%d\n",
 		len(systemState.OrderBook.GetAll()),
 		len(systemState.PositionBook.GetAll()),
+		len(systemState.InstrumentBook.GetAll()),
 		systemState.LastEventID,
 	)
 
@@ -72,7 +79,15 @@ func main() {
 	orderBook := systemState.OrderBook
 	positionBook := systemState.PositionBook
 	dispatcher := engine.NewDispatcher(4)
-	idGen := idgen.New()
+
+	// Continue IDs after the highest recovered order so they never collide
+	var lastOrderID int64
+	for id := range orderBook.GetAll() {
+		if id > lastOrderID {
+			lastOrderID = id
+		}
+	}
+	idGen := idgen.NewFrom(lastOrderID)
 
 	fmt.Println("✓ Order Book initialized (linked to EventBus)")
 	fmt.Println("✓ Position Book initialized (linked to EventBus)")
@@ -80,25 +95,74 @@ func main() {
 	fmt.Println("✓ ID Generator initialized")
 
 	// Create services with proper dependency injection
-	positionSvc := service.NewPositionService(positionBook, eventBus)
+	instrumentSvc := service.NewInstrumentService(systemState.InstrumentBook, eventBus)
+	if err := instrumentSvc.LoadFile(*instrumentsFile); err != nil {
+		panic(fmt.Sprintf("Failed to load instruments: %v", err))
+	}
+	fmt.Printf("✓ Instrument Service created (%d instruments)\n", len(instrumentSvc.List()))
+
+	accountSvc := service.NewAccountService(systemState.AccountBook, eventBus)
+	fmt.Println("✓ Account Service created")
+
+	positionSvc := service.NewPositionService(positionBook, instrumentSvc, eventBus)
 	fmt.Println("✓ Position Service created")
 
-	// Placeholder for matching gateway (will be injected later)
-	var matchingGw service.MatchingGateway
+	// Connect the in-process matching engine and rest recovered orders on it
+	matchingEngine := engine.NewShardedMatchingEngine(8)
+	defer matchingEngine.Close()
+	matchingGw := matching.NewEngineGateway(matchingEngine)
+	restored, err := matchingGw.Restore(orderBook.GetAll())
+	if err != nil {
+		panic(fmt.Sprintf("Failed to restore matching book: %v", err))
+	}
+	fmt.Printf("✓ Matching Engine connected (8 shards, %d resting orders restored)\n", restored)
+
+	var indexSources []service.IndexSource
+	if *indexFile != "" {
+		src, err := index.LoadReplaySource(*indexFile)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to load index prices: %v", err))
+		}
+		indexSources = append(indexSources, src)
+	}
+	markSvc := service.NewMarkPriceService(systemState.MarkPriceBook, eventBus, indexSources...)
+	fmt.Printf("✓ Mark Price Service created (%d index sources)\n", len(indexSources))
 
-	liqSvc := service.NewLiquidationService(matchingGw, idGen)
+	liqSvc := service.NewLiquidationService(instrumentSvc, matchingGw, idGen)
 	fmt.Println("✓ Liquidation Service created")
 
-	orderSvc := service.NewOrderService(orderBook, positionSvc, liqSvc, eventBus, idGen)
-	fmt.Println("✓ Order Service created")
+	insuranceSvc := service.NewInsuranceService(accountSvc)
+	fmt.Printf("✓ Insurance Fund loaded (USDT balance %s)\n", insuranceSvc.Balance("USDT"))
 
-	// Inject OMS back into mock matching (circular dependency resolution)
-	matchingGw = matching.NewMockMatching(orderSvc)
-	liqSvc = service.NewLiquidationService(matchingGw, idGen)
+	fundingSvc := service.NewFundingService(systemState.FundingBook, instrumentSvc, positionSvc, accountSvc, markSvc, eventBus, idGen)
+	fmt.Printf("✓ Funding Service created (next funding %s)\n", fundingSvc.NextFundingTime(time.Now()).Format(time.RFC3339))
 
-	// Recreate order service with correct liquidation service
-	orderSvc = service.NewOrderService(orderBook, positionSvc, liqSvc, eventBus, idGen)
-	fmt.Println("✓ Mock Matching Engine connected")
+	feeSvc := service.NewFeeService(systemState.TradeBook, instrumentSvc, accountSvc)
+	if *feeTiersFile != "" {
+		if err := feeSvc.LoadFile(*feeTiersFile); err != nil {
+			panic(fmt.Sprintf("Failed to load fee tiers: %v", err))
+		}
+	}
+	fmt.Printf("✓ Fee Service created (%d VIP levels)\n", len(feeSvc.Tiers()))
+
+	riskSvc := service.NewRiskService(orderBook, instrumentSvc, positionSvc)
+	if *riskRulesFile != "" {
+		if err := riskSvc.LoadFile(*riskRulesFile); err != nil {
+			panic(fmt.Sprintf("Failed to load risk rules: %v", err))
+		}
+	}
+	fmt.Println("✓ Risk Service created")
+
+	stopSvc := service.NewStopService(systemState.StopBook, instrumentSvc, eventBus, idGen)
+	fmt.Println("✓ Stop Order Service created")
+
+	orderSvc := service.NewOrderService(orderBook, instrumentSvc, accountSvc, positionSvc, liqSvc, markSvc, fundingSvc, feeSvc, riskSvc, stopSvc, matchingGw, eventBus, idGen)
+	fmt.Println("✓ Order Service created")
+
+	orderSvc.SubscribeADL(func(r *domain.ADLRecord) {
+		fmt.Printf("🔔 ADL notice to user %d: %s %s position reduced by %s at %s\n",
+			r.UserID, r.Symbol, r.PositionSide, r.Qty, r.Price)
+	})
 
 	// Start periodic snapshots
 	stopSnapshots := make(chan struct{})
@@ -107,12 +171,70 @@ func main() {
 
 	// Start gRPC Server
 	if !*demoMode {
-		startGRPCServer(*port, orderSvc, positionSvc)
+		stopMarks := make(chan struct{})
+		go markSvc.Run(time.Second, stopMarks)
+		defer close(stopMarks)
+
+		stopFunding := make(chan struct{})
+		go orderSvc.RunFunding(stopFunding)
+		defer close(stopFunding)
+
+		startGRPCServer(*port, orderSvc, positionSvc, instrumentSvc, accountSvc, markSvc, fundingSvc, feeSvc, insuranceSvc)
 		return // Block forever in startGRPCServer? No, startGRPCServer should block.
 	}
 
 	time.Sleep(500 * time.Millisecond)
 
+	// placeOrder runs CreateOrder on the user's worker and waits for matching
+	placeOrder := func(o *domain.Order) {
+		done := make(chan struct{})
+		dispatcher.Dispatch(o.UserID, func() {
+			defer close(done)
+			if _, err := orderSvc.CreateOrder(o); err != nil {
+				fmt.Printf("❌ Order rejected: %v\n", err)
+				return
+			}
+			fmt.Printf("   → order %d %s, filled %s/%s\n", o.ID, o.Status, o.FilledQty, o.Quantity)
+		})
+		<-done
+	}
+
+	// A market maker provides the liquidity every scenario trades against
+	makerID := int64(9001)
+	quote := func(side domain.Side, qty, price decimal.Decimal) {
+		fmt.Printf("🏦 Market maker quotes %s %s @ $%s\n", side, qty, price)
+		placeOrder(&domain.Order{
+			UserID:   makerID,
+			Symbol:   "BTCUSDT",
+			Side:     side,
+			Type:     domain.Limit,
+			Price:    price,
+			Quantity: qty,
+		})
+	}
+
+	// setIndex feeds the mark price service a new index price by hand
+	setIndex := func(price decimal.Decimal) {
+		m, err := markSvc.Update("BTCUSDT", price)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to update mark price: %v", err))
+		}
+		fmt.Printf("📈 Index $%s, mark $%s (basis %s)\n", m.IndexPrice, m.Price, m.Basis)
+	}
+
+	markOf := func(symbol string) decimal.Decimal {
+		mark, _ := orderSvc.MarkPrice(symbol)
+		return mark
+	}
+
+	// Fund the demo accounts
+	for _, uid := range []int64{1001, 1002, makerID} {
+		if err := accountSvc.Deposit(uid, "USDT", decimal.FromInt(100000)); err != nil {
+			panic(fmt.Sprintf("Failed to fund user %d: %v", uid, err))
+		}
+	}
+	fmt.Println("💵 Deposited 100000 USDT to each demo user")
+
 	// ===================================
 	// Scenario 1: Normal Order Flow
 	// ===================================
@@ -121,47 +243,27 @@ func main() {
 	userID := int64(1001)
 	symbol := "BTCUSDT"
 
-	// Create a buy limit order
+	setIndex(decimal.FromInt(42000))
+	quote(domain.Sell, decimal.FromInt(1), decimal.FromInt(42000))
+
+	// Create a buy limit order that crosses the maker's ask
 	buyOrder := &domain.Order{
-		ID:       idGen.Next(),
 		UserID:   userID,
 		Symbol:   symbol,
 		Side:     domain.Buy,
 		Type:     domain.Limit,
-		Price:    42000,
-		Quantity: 1.0,
+		Price:    decimal.FromInt(42000),
+		Quantity: decimal.FromInt(1),
 	}
 
-	fmt.Printf("📝 Creating BUY order: %.2f %s @ $%.2f\n",
+	fmt.Printf("📝 Creating BUY order: %s %s @ $%s\n",
 		buyOrder.Quantity, symbol, buyOrder.Price)
-
-	dispatcher.Dispatch(buyOrder.ID, func() {
-		orderSvc.CreateOrder(buyOrder)
-	})
-
-	time.Sleep(100 * time.Millisecond)
-
-	// Simulate trade execution from matching engine
-	fmt.Println("\n💱 Matching Engine: Order matched!")
-	trade1 := &domain.Trade{
-		OrderID: buyOrder.ID,
-		UserID:  userID,
-		Symbol:  symbol,
-		Qty:     1.0,
-		Price:   42000,
-		IsMaker: false,
-	}
-
-	dispatcher.Dispatch(buyOrder.ID, func() {
-		orderSvc.OnTrade(trade1)
-	})
-
-	time.Sleep(200 * time.Millisecond)
+	placeOrder(buyOrder)
 
 	// Check position
 	pos, ok := positionSvc.Get(userID, symbol)
 	if ok {
-		printPosition(pos, 42000)
+		printPosition(pos, markOf(symbol))
 	}
 
 	// ===================================
@@ -169,46 +271,27 @@ func main() {
 	// ===================================
 	printSeparator("SCENARIO 2: BUILDING POSITION WITH MULTIPLE TRADES")
 
+	setIndex(decimal.FromInt(43000))
+	quote(domain.Sell, decimal.MustParse("0.5"), decimal.FromInt(43000))
+
 	// Add more to position
 	buyOrder2 := &domain.Order{
-		ID:       idGen.Next(),
 		UserID:   userID,
 		Symbol:   symbol,
 		Side:     domain.Buy,
 		Type:     domain.Limit,
-		Price:    43000,
-		Quantity: 0.5,
+		Price:    decimal.FromInt(43000),
+		Quantity: decimal.MustParse("0.5"),
 	}
 
-	fmt.Printf("📝 Creating BUY order: %.2f %s @ $%.2f\n",
+	fmt.Printf("📝 Creating BUY order: %s %s @ $%s\n",
 		buyOrder2.Quantity, symbol, buyOrder2.Price)
-
-	dispatcher.Dispatch(buyOrder2.ID, func() {
-		orderSvc.CreateOrder(buyOrder2)
-	})
-
-	time.Sleep(100 * time.Millisecond)
-
-	fmt.Println("\n💱 Matching Engine: Order matched!")
-	trade2 := &domain.Trade{
-		OrderID: buyOrder2.ID,
-		UserID:  userID,
-		Symbol:  symbol,
-		Qty:     0.5,
-		Price:   43000,
-		IsMaker: false,
-	}
-
-	dispatcher.Dispatch(buyOrder2.ID, func() {
-		orderSvc.OnTrade(trade2)
-	})
-
-	time.Sleep(200 * time.Millisecond)
+	placeOrder(buyOrder2)
 
 	// Check updated position
 	pos, ok = positionSvc.Get(userID, symbol)
 	if ok {
-		printPosition(pos, 43000)
+		printPosition(pos, markOf(symbol))
 	}
 
 	// ===================================
@@ -216,50 +299,36 @@ func main() {
 	// ===================================
 	printSeparator("SCENARIO 3: CLOSING POSITION WITH PROFIT")
 
-	currentPrice := 44500.0
-	fmt.Printf("📊 Current Market Price: $%.2f\n", currentPrice)
+	currentPrice := decimal.FromInt(44500)
+	fmt.Printf("📊 Current Market Price: $%s\n", currentPrice)
+	setIndex(currentPrice)
 
 	if pos != nil {
-		unrealizedPnL := (currentPrice - pos.EntryPrice) * pos.Qty
-		fmt.Printf("💰 Unrealized PnL: $%.2f (%.2f%%)\n",
-			unrealizedPnL, (unrealizedPnL/pos.Margin)*100)
+		unrealizedPnL := (currentPrice - pos.EntryPrice).Mul(pos.Qty)
+		fmt.Printf("💰 Unrealized PnL: $%s (%s%%)\n",
+			unrealizedPnL, (unrealizedPnL.Div(pos.Margin) * 100).Round(2))
 	}
 
+	quote(domain.Buy, decimal.MustParse("1.5"), currentPrice)
+
 	// Close entire position
 	sellOrder := &domain.Order{
-		ID:       idGen.Next(),
 		UserID:   userID,
 		Symbol:   symbol,
 		Side:     domain.Sell,
 		Type:     domain.Limit,
-		Price:    44500,
-		Quantity: 1.5, // Close full position
+		Price:    currentPrice,
+		Quantity: decimal.MustParse("1.5"), // Close full position
 	}
 
-	fmt.Printf("\n📝 Creating SELL order to close: %.2f %s @ $%.2f\n",
+	fmt.Printf("\n📝 Creating SELL order to close: %s %s @ $%s\n",
 		sellOrder.Quantity, symbol, sellOrder.Price)
+	placeOrder(sellOrder)
 
-	dispatcher.Dispatch(sellOrder.ID, func() {
-		orderSvc.CreateOrder(sellOrder)
-	})
-
-	time.Sleep(100 * time.Millisecond)
-
-	fmt.Println("\n💱 Matching Engine: Position closed!")
-	trade3 := &domain.Trade{
-		OrderID: sellOrder.ID,
-		UserID:  userID,
-		Symbol:  symbol,
-		Qty:     1.5,
-		Price:   44500,
-		IsMaker: false,
+	if p, ok := positionSvc.Get(userID, symbol); ok {
+		fmt.Printf("✅ Position closed, realized PnL: $%s\n", p.RealizedPnL)
 	}
-
-	dispatcher.Dispatch(sellOrder.ID, func() {
-		orderSvc.OnTrade(trade3)
-	})
-
-	time.Sleep(200 * time.Millisecond)
+	printAccount(accountSvc, userID, "USDT")
 
 	// ===================================
 	// Scenario 4: Liquidation Flow
@@ -270,86 +339,74 @@ func main() {
 
 	// Open a leveraged long position
 	fmt.Printf("👤 User %d opening 10x leveraged LONG position\n", userID2)
+	if _, err := orderSvc.SetLeverage(userID2, symbol, decimal.FromInt(10)); err != nil {
+		fmt.Printf("❌ Failed to set leverage: %v\n", err)
+	}
+
+	setIndex(decimal.FromInt(40000))
+	quote(domain.Sell, decimal.FromInt(2), decimal.FromInt(40000))
 
 	leveragedBuy := &domain.Order{
-		ID:       idGen.Next(),
 		UserID:   userID2,
 		Symbol:   symbol,
 		Side:     domain.Buy,
 		Type:     domain.Limit,
-		Price:    40000,
-		Quantity: 2.0,
+		Price:    decimal.FromInt(40000),
+		Quantity: decimal.FromInt(2),
 	}
 
-	fmt.Printf("📝 Creating BUY order: %.2f %s @ $%.2f (10x leverage)\n",
+	fmt.Printf("📝 Creating BUY order: %s %s @ $%s (10x leverage)\n",
 		leveragedBuy.Quantity, symbol, leveragedBuy.Price)
-
-	dispatcher.Dispatch(leveragedBuy.ID, func() {
-		orderSvc.CreateOrder(leveragedBuy)
-	})
-
-	time.Sleep(100 * time.Millisecond)
-
-	// Execute trade
-	tradeL1 := &domain.Trade{
-		OrderID: leveragedBuy.ID,
-		UserID:  userID2,
-		Symbol:  symbol,
-		Qty:     2.0,
-		Price:   40000,
-		IsMaker: false,
-	}
-
-	dispatcher.Dispatch(leveragedBuy.ID, func() {
-		orderSvc.OnTrade(tradeL1)
-	})
-
-	time.Sleep(200 * time.Millisecond)
+	placeOrder(leveragedBuy)
 
 	pos2, ok := positionSvc.Get(userID2, symbol)
 	if ok {
-		printPosition(pos2, 40000)
-
-		// Simulate price drop
-		fmt.Println("\n⚠️  Market price dropped sharply!")
-		liquidationPrice := 38000.0
-		fmt.Printf("📉 New Market Price: $%.2f\n", liquidationPrice)
-
-		// Calculate current equity
-		notional := abs(pos2.Qty) * liquidationPrice
-		mm := notional * 0.005 // 0.5% maintenance margin
-		upnl := (liquidationPrice - pos2.EntryPrice) * pos2.Qty
-		equity := pos2.Margin + upnl
+		printPosition(pos2, markOf(symbol))
+
+		held := *pos2 // the liquidation below closes the booked position
+
+		// The market maker lowers its bid as the market falls, still above
+		// the position's bankruptcy price the liquidation order is limited to
+		quote(domain.Buy, decimal.FromInt(2), decimal.FromInt(36100))
+		liquidationPrice := decimal.FromInt(35800)
+
+		// Simulate an index drop; the mark follows it, not the last trade,
+		// and every mark update scans the symbol for positions past their
+		// liquidation price
+		fmt.Println("\n⚠️  Index price dropped sharply!")
+		setIndex(liquidationPrice)
+		mark := markOf(symbol)
+
+		// Calculate equity at the new mark
+		notional := held.Qty.Abs().Mul(mark)
+		tier := domain.RiskTier{MaintenanceMarginRate: service.MaintenanceMarginRate}
+		if inst, ok := instrumentSvc.Get(symbol); ok {
+			_, tier, _ = inst.TierFor(notional)
+		}
+		mmr := tier.MaintenanceMarginRate
+		mm := tier.MaintenanceMargin(notional)
+		upnl := (mark - held.EntryPrice).Mul(held.Qty)
+		equity := held.Margin + upnl
 
 		fmt.Printf("\n💼 Position Analysis:\n")
-		fmt.Printf("   Notional Value: $%.2f\n", notional)
-		fmt.Printf("   Maintenance Margin Required: $%.2f (0.5%%)\n", mm)
-		fmt.Printf("   Unrealized PnL: $%.2f\n", upnl)
-		fmt.Printf("   Current Equity: $%.2f\n", equity)
+		fmt.Printf("   Mark Price: $%s\n", mark)
+		fmt.Printf("   Notional Value: $%s\n", notional)
+		fmt.Printf("   Maintenance Margin Required: $%s (%s%%)\n", mm, mmr*100)
+		fmt.Printf("   Unrealized PnL: $%s\n", upnl)
+		fmt.Printf("   Current Equity: $%s\n", equity)
 		fmt.Printf("   Liquidation Threshold: Equity <= MM\n")
 
 		if equity <= mm {
-			fmt.Printf("\n🚨 LIQUIDATION TRIGGERED! (Equity $%.2f <= MM $%.2f)\n", equity, mm)
-
-			// Liquidation service will create IOC order
-			dispatcher.Dispatch(leveragedBuy.ID, func() {
-				// This will trigger liquidation check inside OnTrade
-				// We simulate a trade at liquidation price to trigger it
-				liqTrade := &domain.Trade{
-					OrderID: leveragedBuy.ID,
-					UserID:  userID2,
-					Symbol:  symbol,
-					Qty:     0.01, // Small trade to update price
-					Price:   liquidationPrice,
-					IsMaker: false,
-				}
-				orderSvc.OnTrade(liqTrade)
-			})
-
-			time.Sleep(300 * time.Millisecond)
+			fmt.Printf("\n🚨 LIQUIDATED on the mark update (Equity $%s <= MM $%s)\n", equity, mm)
+		}
+		if p, ok := positionSvc.Get(userID2, symbol); ok {
+			fmt.Printf("📊 Position after liquidation: qty=%s\n", p.Qty)
 		}
+		fmt.Printf("🛡️  Insurance fund after liquidation: %s USDT\n", insuranceSvc.Balance("USDT"))
 	}
 
+	fmt.Printf("\n💰 Fee income net of maker rebates: %s USDT\n", feeSvc.Income("USDT"))
+
 	// ===================================
 	// Scenario 5: Sharded Matching Engine
 	// ===================================
@@ -375,8 +432,8 @@ func main() {
 			Symbol:   sym,
 			Side:     domain.Buy,
 			Type:     domain.Limit,
-			Price:    1000,
-			Quantity: 0.01,
+			Price:    decimal.FromInt(1000),
+			Quantity: decimal.MustParse("0.01"),
 		}
 		// Submit to see which shard it goes to (we'll see from the internal routing)
 		_ = shardedEngine.Submit(dummyOrder)
@@ -406,7 +463,7 @@ func main() {
 				Side:     domain.Sell,
 				Type:     domain.Limit,
 				Price:    getPriceForSymbol(sym),
-				Quantity: 0.1,
+				Quantity: decimal.MustParse("0.1"),
 			}
 			shardedEngine.Submit(sellOrder)
 			totalOrders++
@@ -419,7 +476,7 @@ func main() {
 				Side:     domain.Buy,
 				Type:     domain.Limit,
 				Price:    getPriceForSymbol(sym),
-				Quantity: 0.1,
+				Quantity: decimal.MustParse("0.1"),
 			}
 			trades := shardedEngine.Submit(buyOrder)
 			totalOrders++
@@ -480,61 +537,81 @@ func printSeparator(title string) {
 	fmt.Println()
 }
 
-func printPosition(p *domain.Position, currentPrice float64) {
+func printPosition(p *domain.Position, currentPrice decimal.Decimal) {
 	direction := "LONG"
 	if p.Qty < 0 {
 		direction = "SHORT"
 	}
 
-	notional := abs(p.Qty) * p.EntryPrice
-	unrealizedPnL := (currentPrice - p.EntryPrice) * p.Qty
+	notional := p.Qty.Abs().Mul(p.EntryPrice)
+	unrealizedPnL := (currentPrice - p.EntryPrice).Mul(p.Qty)
 	equity := p.Margin + unrealizedPnL
-	roe := (unrealizedPnL / p.Margin) * 100
+	roe := (unrealizedPnL.Div(p.Margin) * 100).Round(2)
 
 	fmt.Printf("\n📊 Position Status:\n")
 	fmt.Printf("   User: %d\n", p.UserID)
 	fmt.Printf("   Symbol: %s\n", p.Symbol)
 	fmt.Printf("   Direction: %s\n", direction)
-	fmt.Printf("   Quantity: %.4f\n", abs(p.Qty))
-	fmt.Printf("   Entry Price: $%.2f\n", p.EntryPrice)
-	fmt.Printf("   Leverage: %.1fx\n", p.Leverage)
-	fmt.Printf("   Margin: $%.2f\n", p.Margin)
-	fmt.Printf("   Notional: $%.2f\n", notional)
-	fmt.Printf("   Mark Price: $%.2f\n", currentPrice)
-	fmt.Printf("   Unrealized PnL: $%.2f\n", unrealizedPnL)
-	fmt.Printf("   Equity: $%.2f\n", equity)
-	fmt.Printf("   ROE: %.2f%%\n", roe)
+	fmt.Printf("   Quantity: %s\n", p.Qty.Abs())
+	fmt.Printf("   Entry Price: $%s\n", p.EntryPrice)
+	fmt.Printf("   Leverage: %sx\n", p.Leverage)
+	fmt.Printf("   Margin: $%s\n", p.Margin)
+	fmt.Printf("   Notional: $%s\n", notional)
+	fmt.Printf("   Mark Price: $%s\n", currentPrice)
+	fmt.Printf("   Unrealized PnL: $%s\n", unrealizedPnL)
+	fmt.Printf("   Equity: $%s\n", equity)
+	fmt.Printf("   ROE: %s%%\n", roe)
+	fmt.Printf("   Realized PnL: $%s\n", p.RealizedPnL)
+	fmt.Printf("   Liquidation Price: $%s\n", p.LiquidationPrice)
+	fmt.Printf("   Bankruptcy Price: $%s\n", p.BankruptcyPrice)
 }
 
-func abs(v float64) float64 {
-	if v < 0 {
-		return -v
+func printAccount(svc *service.AccountService, userID int64, asset string) {
+	a, ok := svc.Get(userID, asset)
+	if !ok {
+		return
+	}
+
+	fmt.Printf("\n💼 Account %d (%s):\n", userID, asset)
+	fmt.Printf("   Available: $%s\n", a.Available)
+	fmt.Printf("   Frozen: $%s\n", a.Frozen)
+	fmt.Printf("   Margin: $%s\n", a.Margin)
+	fmt.Printf("   Total: $%s\n", a.Total())
+
+	fmt.Println("   Recent ledger entries:")
+	for _, e := range svc.Journal(userID, 5) {
+		fmt.Printf("     #%d %-14s %s %s  %s → %s\n", e.ID, e.Type, e.Amount, e.Asset, e.Debit, e.Credit)
 	}
-	return v
 }
 
 // getPriceForSymbol returns a realistic price for a given symbol
-func getPriceForSymbol(symbol string) float64 {
-	prices := map[string]float64{
-		"BTCUSDT":  42000,
-		"ETHUSDT":  2200,
-		"SOLUSDT":  100,
-		"XRPUSDT":  0.5,
-		"ADAUSDT":  0.4,
-		"DOGEUSDT": 0.08,
+func getPriceForSymbol(symbol string) decimal.Decimal {
+	prices := map[string]decimal.Decimal{
+		"BTCUSDT":  decimal.FromInt(42000),
+		"ETHUSDT":  decimal.FromInt(2200),
+		"SOLUSDT":  decimal.FromInt(100),
+		"XRPUSDT":  decimal.MustParse("0.5"),
+		"ADAUSDT":  decimal.MustParse("0.4"),
+		"DOGEUSDT": decimal.MustParse("0.08"),
 	}
 	if price, ok := prices[symbol]; ok {
 		return price
 	}
-func startGRPCServer(port int, orderSvc *service.OrderService, posSvc *service.PositionService) {
+	return decimal.FromInt(100)
+}
+
+func startGRPCServer(port int, orderSvc *service.OrderService, posSvc *service.PositionService,
+	instSvc *service.InstrumentService, acctSvc *service.AccountService, markSvc *service.MarkPriceService,
+	fundingSvc *service.FundingService, feeSvc *service.FeeService, insuranceSvc *service.InsuranceService) {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
-	omsServer := transport.NewServer(orderSvc, posSvc)
+	s := grpc.NewServer(grpc.UnaryInterceptor(transport.RecoverUnary))
+	omsServer := transport.NewServer(orderSvc, instSvc, posSvc, acctSvc, markSvc, fundingSvc, feeSvc)
 	omsv1.RegisterOMSServer(s, omsServer)
+	omsv1.RegisterOMSAdminServer(s, transport.NewAdminServer(instSvc, acctSvc, insuranceSvc, feeSvc))
 
 	fmt.Printf("🚀 gRPC Server listening at %v\n", lis.Addr())
 	if err := s.Serve(lis); err != nil {
@@ -0,0 +1,64 @@
+package index
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"oms-contract/pkg/decimal"
+)
+
+// ReplaySource is a local index feed that plays back a fixed series of
+// ticks, one per poll, then keeps returning the last tick. It stands in
+// for exchange feeds in tests and the demo.
+type ReplaySource struct {
+	mu    sync.Mutex
+	ticks []map[string]decimal.Decimal
+	next  int // tick served by the next poll; past the end repeats the last
+}
+
+func NewReplaySource(ticks ...map[string]decimal.Decimal) *ReplaySource {
+	return &ReplaySource{ticks: ticks}
+}
+
+// LoadReplaySource reads ticks from a JSON file holding an array of
+// symbol → price objects, e.g. [{"BTCUSDT": "42000"}, {"BTCUSDT": "42010"}]
+func LoadReplaySource(path string) (*ReplaySource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var ticks []map[string]decimal.Decimal
+	if err := json.Unmarshal(data, &ticks); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return NewReplaySource(ticks...), nil
+}
+
+// Push appends a tick to play after the ones already queued
+func (s *ReplaySource) Push(tick map[string]decimal.Decimal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ticks = append(s.ticks, tick)
+}
+
+func (s *ReplaySource) IndexPrices() (map[string]decimal.Decimal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.ticks) == 0 {
+		return nil, nil
+	}
+	tick := s.ticks[min(s.next, len(s.ticks)-1)]
+	if s.next < len(s.ticks) {
+		s.next++
+	}
+
+	prices := make(map[string]decimal.Decimal, len(tick))
+	for symbol, price := range tick {
+		prices[symbol] = price
+	}
+	return prices, nil
+}
@@ -0,0 +1,97 @@
+package matching
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"oms-contract/internal/domain"
+	"oms-contract/internal/engine"
+	"oms-contract/internal/service"
+	"oms-contract/pkg/decimal"
+)
+
+var ErrOrderNotResting = errors.New("order is not resting on the matching book")
+
+// EngineGateway connects the OMS to the in-process sharded matching engine.
+// The engine owns its own copy of every order: it decrements Quantity as the
+// order fills, while the OMS order keeps the original size and FilledQty.
+type EngineGateway struct {
+	engine *engine.ShardedMatchingEngine
+}
+
+func NewEngineGateway(e *engine.ShardedMatchingEngine) *EngineGateway {
+	return &EngineGateway{engine: e}
+}
+
+func (g *EngineGateway) SubmitOrder(o *domain.Order) ([]*domain.Trade, error) {
+	cp := *o
+	cp.Quantity = o.Quantity - o.FilledQty
+	return g.engine.Submit(&cp), nil
+}
+
+func (g *EngineGateway) SendLiquidationOrder(o *domain.LiquidationOrder) ([]*domain.Trade, error) {
+	fmt.Printf("[MATCHING] IOC liquidation order received: %+v\n", o)
+
+	return g.engine.Submit(&domain.Order{
+		ID:        o.OrderID,
+		UserID:    o.UserID,
+		Symbol:    o.Symbol,
+		Side:      o.Side,
+		Type:      o.OrderType,
+		Price:     o.Price,
+		Quantity:  o.Quantity,
+		CreatedAt: time.Now(),
+		IsSystem:  true,
+
+		PositionSide: o.PositionSide,
+		ReduceOnly:   o.ReduceOnly,
+	}), nil
+}
+
+// CancelOrder succeeds if the order is not resting: it may have been
+// submitted before a restart and never restored, so there is nothing to pull
+func (g *EngineGateway) CancelOrder(symbol string, orderID int64) error {
+	g.engine.Cancel(symbol, orderID)
+	return nil
+}
+
+func (g *EngineGateway) ReplaceOrder(symbol string, orderID int64, price, remainingQty decimal.Decimal) ([]*domain.Trade, error) {
+	trades, ok := g.engine.Replace(symbol, orderID, price, remainingQty)
+	if !ok {
+		return nil, ErrOrderNotResting
+	}
+	return trades, nil
+}
+
+// Restore rests active limit orders recovered by replay back on the book,
+// in time priority. Recovered orders never cross each other, so any trade
+// here means the recovered state is inconsistent.
+func (g *EngineGateway) Restore(orders map[int64]*domain.Order) (int, error) {
+	active := make([]*domain.Order, 0, len(orders))
+	for _, o := range orders {
+		if o.IsActive() && o.Type == domain.Limit {
+			active = append(active, o)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool {
+		if active[i].CreatedAt.Equal(active[j].CreatedAt) {
+			return active[i].ID < active[j].ID
+		}
+		return active[i].CreatedAt.Before(active[j].CreatedAt)
+	})
+
+	for _, o := range active {
+		trades, _ := g.SubmitOrder(o)
+		if len(trades) > 0 {
+			return 0, fmt.Errorf("restored order %d crossed the book", o.ID)
+		}
+	}
+	return len(active), nil
+}
+
+var (
+	_ service.MatchingGateway = (*EngineGateway)(nil)
+	_ service.MatchingGateway = (*MockMatching)(nil)
+)
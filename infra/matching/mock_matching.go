@@ -5,20 +5,25 @@ import (
 	"time"
 
 	"oms-contract/internal/domain"
-	"oms-contract/internal/service"
+	"oms-contract/pkg/decimal"
 )
 
-type MockMatching struct {
-	orderSvc *service.OrderService
+// MockMatching keeps no book: orders never trade, except liquidation
+// orders which fill immediately at a fixed demo price
+type MockMatching struct{}
+
+func NewMockMatching() *MockMatching {
+	return &MockMatching{}
 }
 
-func NewMockMatching(orderSvc *service.OrderService) *MockMatching {
-	return &MockMatching{orderSvc: orderSvc}
+func (m *MockMatching) SubmitOrder(o *domain.Order) ([]*domain.Trade, error) {
+	fmt.Printf("[MATCHING] order received: id=%d symbol=%s\n", o.ID, o.Symbol)
+	return nil, nil
 }
 
 func (m *MockMatching) SendLiquidationOrder(
 	o *domain.LiquidationOrder,
-) error {
+) ([]*domain.Trade, error) {
 
 	fmt.Printf(
 		"[MATCHING] IOC liquidation order received: %+v\n",
@@ -30,14 +35,31 @@ func (m *MockMatching) SendLiquidationOrder(
 
 	trade := &domain.Trade{
 		OrderID: o.OrderID,
+		UserID:  o.UserID,
+		Symbol:  o.Symbol,
+		Side:    o.Side,
 		Qty:     o.Quantity,
 		Price:   mockMarketPrice(o.Symbol),
+
+		PositionSide: o.PositionSide,
 	}
 
-	m.orderSvc.OnTrade(trade)
+	return []*domain.Trade{trade}, nil
+}
+
+// CancelOrder always succeeds: the mock never rests orders on a book
+func (m *MockMatching) CancelOrder(symbol string, orderID int64) error {
+	fmt.Printf("[MATCHING] cancel order received: symbol=%s id=%d\n", symbol, orderID)
 	return nil
 }
 
-func mockMarketPrice(symbol string) float64 {
-	return 38000 // demo
+// ReplaceOrder always succeeds without trades: the mock keeps no book to cross
+func (m *MockMatching) ReplaceOrder(symbol string, orderID int64, price, remainingQty decimal.Decimal) ([]*domain.Trade, error) {
+	fmt.Printf("[MATCHING] replace order received: symbol=%s id=%d price=%s qty=%s\n",
+		symbol, orderID, price, remainingQty)
+	return nil, nil
+}
+
+func mockMarketPrice(symbol string) decimal.Decimal {
+	return decimal.FromInt(38000) // demo
 }
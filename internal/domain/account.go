@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+
+	"oms-contract/pkg/decimal"
+)
+
+// Account is a user's balance of one asset, split into buckets
+type Account struct {
+	UserID    int64
+	Asset     string
+	Available decimal.Decimal // 可用
+	Frozen    decimal.Decimal // 挂单冻结
+	Margin    decimal.Decimal // 持仓保证金
+}
+
+// Total is the wallet balance across all buckets
+func (a *Account) Total() decimal.Decimal {
+	return a.Available + a.Frozen + a.Margin
+}
+
+type Bucket string
+
+const (
+	// User buckets, stored on Account
+	BucketAvailable Bucket = "AVAILABLE"
+	BucketFrozen    Bucket = "FROZEN"
+	BucketMargin    Bucket = "MARGIN"
+
+	// System accounts, the other side of every user movement
+	BucketExternal    Bucket = "EXTERNAL"     // 充提
+	BucketPnLClearing Bucket = "PNL_CLEARING" // 已实现盈亏清算
+	BucketFeeIncome   Bucket = "FEE_INCOME"
+	BucketFunding     Bucket = "FUNDING"
+	BucketInsurance   Bucket = "INSURANCE"
+)
+
+// LedgerAccount identifies one side of a journal entry.
+// System accounts have UserID 0.
+type LedgerAccount struct {
+	UserID int64
+	Bucket Bucket
+}
+
+func UserAccount(uid int64, b Bucket) LedgerAccount { return LedgerAccount{UserID: uid, Bucket: b} }
+func SystemAccount(b Bucket) LedgerAccount          { return LedgerAccount{Bucket: b} }
+
+func (a LedgerAccount) IsSystem() bool { return a.UserID == 0 }
+
+func (a LedgerAccount) String() string {
+	if a.IsSystem() {
+		return "system:" + string(a.Bucket)
+	}
+	return "user:" + strconv.FormatInt(a.UserID, 10) + ":" + string(a.Bucket)
+}
+
+type JournalType string
+
+const (
+	JournalDeposit         JournalType = "DEPOSIT"
+	JournalWithdraw        JournalType = "WITHDRAW"
+	JournalOrderFreeze     JournalType = "ORDER_FREEZE"
+	JournalOrderUnfreeze   JournalType = "ORDER_UNFREEZE"
+	JournalTradeSettle     JournalType = "TRADE_SETTLE" // 保证金转入仓位
+	JournalMarginRelease   JournalType = "MARGIN_RELEASE"
+	JournalMarginAdjust    JournalType = "MARGIN_ADJUST" // 调整杠杆后追加或释放保证金
+	JournalRealizedPnL     JournalType = "REALIZED_PNL"
+	JournalFee             JournalType = "FEE"
+	JournalFunding         JournalType = "FUNDING"
+	JournalLiquidation     JournalType = "LIQUIDATION"      // 强平成交优于破产价的剩余保证金转入保险基金
+	JournalInsuranceCover  JournalType = "INSURANCE_COVER"  // 保险基金弥补穿仓亏损
+	JournalInsuranceInject JournalType = "INSURANCE_INJECT" // 外部注资保险基金
+)
+
+// JournalEntry is one double-entry movement: Amount is debited from
+// Debit and credited to Credit, so the sum of all balances never changes.
+// User balances are liabilities of the venue: a credit increases them.
+type JournalEntry struct {
+	ID        int64
+	Type      JournalType
+	Asset     string
+	Amount    decimal.Decimal // always > 0
+	Debit     LedgerAccount
+	Credit    LedgerAccount
+	RefID     int64 // order, trade or liquidation the movement belongs to
+	CreatedAt time.Time
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+
+	"oms-contract/pkg/decimal"
+)
+
+// ADLRecord is one counterparty position closed by auto-deleveraging
+// against a bankrupt position, at the bankrupt position's bankruptcy price
+type ADLRecord struct {
+	TradeID int64
+	Symbol  string
+	Price   decimal.Decimal
+	Qty     decimal.Decimal
+
+	UserID       int64        // 被减仓的盈利方
+	PositionSide PositionSide // 被减仓的仓位方向
+	Score        decimal.Decimal
+
+	BankruptUserID int64
+	BankruptSide   PositionSide
+
+	CreatedAt time.Time
+}
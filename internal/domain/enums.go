@@ -3,6 +3,12 @@ package domain
 type Side string
 type OrderType string
 type OrderStatus string
+type PositionSide string
+type PositionMode string
+type MarginMode string
+type LiquidationState string
+type TriggerPrice string
+type StopStatus string
 
 const (
 	Buy  Side = "BUY"
@@ -11,9 +17,35 @@ const (
 	Limit      OrderType   = "LIMIT"
 	Market     OrderType   = "MARKET"
 	IOC        OrderType   = "IOC"
+	StopMarket OrderType   = "STOP_MARKET" // 条件单：触发后以市价单提交
+	StopLimit  OrderType   = "STOP_LIMIT"  // 条件单：触发后以限价单提交
 	Submitted  OrderStatus = "SUBMITTED"
 	PartFilled OrderStatus = "PART_FILLED"
 	Filled     OrderStatus = "FILLED"
 	Canceled   OrderStatus = "CANCELED"
 	Rejected   OrderStatus = "REJECTED"
+
+	PositionBoth  PositionSide = "BOTH" // 单向持仓的净仓位
+	PositionLong  PositionSide = "LONG"
+	PositionShort PositionSide = "SHORT"
+
+	OneWayMode PositionMode = "ONE_WAY"
+	HedgeMode  PositionMode = "HEDGE" // 双向持仓
+
+	IsolatedMargin MarginMode = "ISOLATED" // 逐仓：每个仓位只以自身保证金承担亏损
+	CrossMargin    MarginMode = "CROSS"    // 全仓：所有仓位共享钱包余额
+
+	PositionNormal      LiquidationState = "NORMAL"
+	PositionLiquidating LiquidationState = "LIQUIDATING" // 强平中：挂单已撤，只接受减仓单
+	PositionADL         LiquidationState = "ADL"         // 强平由自动减仓完成
+	PositionClosed      LiquidationState = "CLOSED"      // 强平由市场成交完成
+
+	TriggerLastPrice TriggerPrice = "LAST_PRICE" // 最新成交价触发
+	TriggerMarkPrice TriggerPrice = "MARK_PRICE" // 标记价格触发
+
+	StopPending   StopStatus = "PENDING"
+	StopTriggered StopStatus = "TRIGGERED" // 已触发，订单待提交
+	StopActivated StopStatus = "ACTIVATED" // 已触发并提交订单
+	StopRejected  StopStatus = "REJECTED"  // 已触发，但订单被拒
+	StopCanceled  StopStatus = "CANCELED"
 )
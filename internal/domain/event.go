@@ -1,5 +1,7 @@
 package domain
 
+import "oms-contract/pkg/decimal"
+
 type OrderEvent struct {
 	Type  string // NEW_ORDER
 	Order *LiquidationOrder
@@ -9,6 +11,6 @@ type TradeEvent struct {
 	OrderID int64
 	UserID  int64
 	Symbol  string
-	Qty     float64
-	Price   float64
+	Qty     decimal.Decimal
+	Price   decimal.Decimal
 }
@@ -0,0 +1,11 @@
+package domain
+
+import "oms-contract/pkg/decimal"
+
+// FeeTier is one VIP level of the fee schedule. A user's level is the
+// highest one whose MinVolume its 30-day traded notional reaches.
+type FeeTier struct {
+	MinVolume    decimal.Decimal // 30 日成交额门槛
+	MakerFeeRate decimal.Decimal // 可为负，即 maker 返佣
+	TakerFeeRate decimal.Decimal
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	"oms-contract/pkg/decimal"
+)
+
+// FundingRate is one funding settlement of a symbol. A positive rate has
+// longs pay shorts, a negative one shorts pay longs.
+type FundingRate struct {
+	ID           int64 // 结算批次，资金费流水以此关联
+	Symbol       string
+	Rate         decimal.Decimal
+	PremiumIndex decimal.Decimal // 本周期 (标记价 - 指数价) / 指数价 的均值
+	MarkPrice    decimal.Decimal // 计算资金费的标记价格
+	FundingTime  time.Time
+}
+
+// FundingPayment is what one position received (positive) or paid
+// (negative) at a funding settlement
+type FundingPayment struct {
+	UserID       int64
+	Symbol       string
+	PositionSide PositionSide
+	Qty          decimal.Decimal // 结算时的仓位数量，空仓为负
+	Amount       decimal.Decimal
+}
@@ -0,0 +1,92 @@
+package domain
+
+import "oms-contract/pkg/decimal"
+
+type InstrumentStatus string
+
+const (
+	InstrumentTrading  InstrumentStatus = "TRADING"
+	InstrumentHalted   InstrumentStatus = "HALTED"   // 暂停交易，可撤单
+	InstrumentDelisted InstrumentStatus = "DELISTED" // 已下架
+)
+
+// Instrument is the contract specification of a tradable symbol
+type Instrument struct {
+	Symbol      string
+	BaseAsset   string
+	SettleAsset string // 保证金与盈亏结算资产
+
+	TickSize    decimal.Decimal // 价格最小变动单位
+	LotSize     decimal.Decimal // 数量最小变动单位
+	MinQty      decimal.Decimal
+	MaxQty      decimal.Decimal
+	MinNotional decimal.Decimal // 限价单最小名义价值
+	MaxPrice    decimal.Decimal // 限价单最高价格，0 为不限
+
+	// 价格与数量的小数位数，报单按此解析、回报按此输出；
+	// 0 取 TickSize / LotSize 的小数位数
+	PriceDecimals int32
+	QtyDecimals   int32
+
+	MaxLeverage           decimal.Decimal
+	MaintenanceMarginRate decimal.Decimal
+	LiquidationStep       decimal.Decimal // 单笔强平单最大数量，0 为一次全部平仓
+	FundingRateCap        decimal.Decimal // 单期资金费率绝对值上限，0 取默认值
+
+	// 基础手续费率，即 VIP 0 费率；VIP 等级取与其中较低者，maker 可为负（返佣）；
+	// 两者均为 0 时视为未设置，按 VIP 费率收取
+	MakerFeeRate decimal.Decimal
+	TakerFeeRate decimal.Decimal
+
+	// 风险限额档位，按名义价值上限升序；为空时整个合约为一档，
+	// 取 MaintenanceMarginRate 与 MaxLeverage 且不限名义价值
+	RiskTiers []RiskTier
+
+	Status InstrumentStatus
+}
+
+// RiskTier is one notional bracket of an instrument's risk limits. A
+// position's maintenance margin and the leverage it may use follow the
+// bracket its notional falls in.
+type RiskTier struct {
+	MaxNotional           decimal.Decimal // 档位名义价值上限，0 为不限
+	MaintenanceMarginRate decimal.Decimal
+	MaintenanceAmount     decimal.Decimal // 速算扣除数，使维持保证金在档位之间连续
+	MaxLeverage           decimal.Decimal
+}
+
+// MaintenanceMargin is the maintenance margin of a position of notional in this tier
+func (t RiskTier) MaintenanceMargin(notional decimal.Decimal) decimal.Decimal {
+	return notional.Mul(t.MaintenanceMarginRate) - t.MaintenanceAmount
+}
+
+// PriceScale is the number of decimal places of the instrument's prices
+func (i *Instrument) PriceScale() int32 {
+	return max(i.PriceDecimals, i.TickSize.Places())
+}
+
+// QtyScale is the number of decimal places of the instrument's quantities
+func (i *Instrument) QtyScale() int32 {
+	return max(i.QtyDecimals, i.LotSize.Places())
+}
+
+// Tiers returns the instrument's risk tiers, a single unbounded one when
+// none are configured
+func (i *Instrument) Tiers() []RiskTier {
+	if len(i.RiskTiers) > 0 {
+		return i.RiskTiers
+	}
+	return []RiskTier{{MaintenanceMarginRate: i.MaintenanceMarginRate, MaxLeverage: i.MaxLeverage}}
+}
+
+// TierFor returns the 1-based number and spec of the tier a notional
+// falls in, false when it exceeds the last tier
+func (i *Instrument) TierFor(notional decimal.Decimal) (int, RiskTier, bool) {
+	tiers := i.Tiers()
+	for n, t := range tiers {
+		if t.MaxNotional.IsZero() || notional <= t.MaxNotional {
+			return n + 1, t, true
+		}
+	}
+	return len(tiers), tiers[len(tiers)-1], false
+}
@@ -1,12 +1,17 @@
 package domain
 
+import "oms-contract/pkg/decimal"
+
 type LiquidationOrder struct {
-	OrderID     int64
-	UserID      int64
-	Symbol      string
-	Side        Side
-	Quantity    float64
-	OrderType   OrderType // 永远是 MARKET
-	TimeInForce string    // IOC
-	Reason      string    // LIQUIDATION
+	OrderID      int64
+	UserID       int64
+	Symbol       string
+	Side         Side
+	PositionSide PositionSide // 被强平的仓位方向
+	Quantity     decimal.Decimal
+	Price        decimal.Decimal // 破产价，成交不得劣于此价
+	OrderType    OrderType       // 永远是 IOC
+	TimeInForce  string          // IOC
+	Reason       string          // LIQUIDATION
+	ReduceOnly   bool            // 永远为 true：只平仓，不反向开仓
 }
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+
+	"oms-contract/pkg/decimal"
+)
+
+// MarkPrice is the fair price positions of a symbol are valued at.
+// It follows the index and carries a smoothed basis so a single
+// off-market trade cannot move it.
+type MarkPrice struct {
+	Symbol     string
+	IndexPrice decimal.Decimal // 现货指数价格
+	Basis      decimal.Decimal // 平滑后的基差
+	Price      decimal.Decimal // 标记价格 = 指数 + 基差
+	UpdatedAt  time.Time
+}
+
+// Premium is (mark - index) / index, the premium funding pays for; zero
+// without an index price
+func (m *MarkPrice) Premium() decimal.Decimal {
+	if m.IndexPrice <= 0 {
+		return decimal.Zero
+	}
+	return (m.Price - m.IndexPrice).Div(m.IndexPrice)
+}
@@ -1,17 +1,67 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"oms-contract/pkg/decimal"
+)
 
 type Order struct {
-	ID        int64
-	UserID    int64
-	Symbol    string
-	Side      Side
-	Type      OrderType
-	Price     float64
-	Quantity  float64
-	FilledQty float64
-	Status    OrderStatus
-	CreatedAt time.Time
-	IsSystem  bool
+	ID     int64
+	UserID int64
+	Symbol string
+	Side   Side
+	Type   OrderType
+	// PositionSide is BOTH in one-way mode; in hedge mode LONG or SHORT
+	// selects which position the order opens or closes
+	PositionSide PositionSide
+	Price        decimal.Decimal
+	Quantity     decimal.Decimal
+	FilledQty    decimal.Decimal
+	Status       OrderStatus
+	CreatedAt    time.Time
+	IsSystem     bool
+
+	// ReduceOnly orders may only reduce the position they trade; resting
+	// ones are cut down or canceled as the position shrinks
+	ReduceOnly bool
+	// ClosePosition is a reduce-only order for all of the position the
+	// user's other reduce-only orders leave; its quantity is set on entry
+	ClosePosition bool
+	// StopID is the stop order that submitted this order when triggered
+	StopID int64
+
+	FrozenMargin decimal.Decimal // 未成交部分仍冻结的保证金
+}
+
+// IsActive reports whether the order may still rest on the book
+func (o *Order) IsActive() bool {
+	return o.Status == Submitted || o.Status == PartFilled
+}
+
+// FrozenFor returns the frozen margin a fill of qty releases:
+// pro rata to the unfilled quantity, and all of it on the final fill
+func (o *Order) FrozenFor(qty decimal.Decimal) decimal.Decimal {
+	remaining := o.Quantity - o.FilledQty
+	if qty >= remaining {
+		return o.FrozenMargin
+	}
+	return o.FrozenMargin.Mul(qty).Div(remaining)
+}
+
+// Fill records an execution of qty against the order
+func (o *Order) Fill(qty decimal.Decimal) {
+	o.FrozenMargin -= o.FrozenFor(qty)
+	o.FilledQty += qty
+	if o.FilledQty >= o.Quantity {
+		o.Status = Filled
+	} else {
+		o.Status = PartFilled
+	}
+}
+
+// Cancel ends the order; the caller releases any frozen margin
+func (o *Order) Cancel() {
+	o.Status = Canceled
+	o.FrozenMargin = decimal.Zero
 }
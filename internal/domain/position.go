@@ -1,10 +1,83 @@
 package domain
 
+import "oms-contract/pkg/decimal"
+
 type Position struct {
-	UserID     int64
-	Symbol     string
-	Qty        float64 // >0 多仓 <0 空仓
-	EntryPrice float64
-	Leverage   float64
-	Margin     float64 // 当前保证金
+	UserID        int64
+	Symbol        string
+	Side          PositionSide    // BOTH 单向持仓，LONG/SHORT 双向持仓
+	Qty           decimal.Decimal // >0 多仓 <0 空仓
+	EntryPrice    decimal.Decimal
+	Leverage      decimal.Decimal
+	Margin        decimal.Decimal // 当前保证金
+	UnrealizedPnL decimal.Decimal
+	RealizedPnL   decimal.Decimal // 累计已实现盈亏
+
+	// 逐仓的强平价与破产价随仓位变化更新；全仓的取决于账户权益与标记价格，
+	// 查询时计算，这里为 0
+	LiquidationPrice decimal.Decimal
+	BankruptcyPrice  decimal.Decimal
+
+	State LiquidationState // 空值视同 NORMAL
+}
+
+// Liquidating reports whether the position is frozen by a liquidation in progress
+func (p *Position) Liquidating() bool {
+	return p.State == PositionLiquidating
+}
+
+// Reduces reports whether a signed fill of qty only reduces the position
+func (p *Position) Reduces(qty decimal.Decimal) bool {
+	return !p.Qty.IsZero() && qty.Sign() == -p.Qty.Sign() && qty.Abs() <= p.Qty.Abs()
+}
+
+// LiquidationPriceAt is the mark price at which margin plus the position's
+// unrealized PnL falls to its maintenance margin at rate mmr. It is zero
+// for a flat position and for a long the margin covers entirely.
+func (p *Position) LiquidationPriceAt(margin, mmr decimal.Decimal) decimal.Decimal {
+	qty := p.Qty.Abs()
+	switch {
+	case p.Qty.IsZero():
+		return decimal.Zero
+	case p.Qty > 0:
+		// margin + (P - Entry) × Qty = P × Qty × mmr
+		price := (qty.Mul(p.EntryPrice) - margin).Div(qty.Mul(decimal.One - mmr))
+		return decimal.Max(price, decimal.Zero)
+	default:
+		// margin - (P - Entry) × |Qty| = P × |Qty| × mmr
+		return (qty.Mul(p.EntryPrice) + margin).Div(qty.Mul(decimal.One + mmr))
+	}
+}
+
+// BankruptcyPriceAt is the mark price at which margin plus the position's
+// unrealized PnL is zero: closing there returns exactly nothing
+func (p *Position) BankruptcyPriceAt(margin decimal.Decimal) decimal.Decimal {
+	qty := p.Qty.Abs()
+	switch {
+	case p.Qty.IsZero():
+		return decimal.Zero
+	case p.Qty > 0:
+		return decimal.Max(p.EntryPrice-margin.Div(qty), decimal.Zero)
+	default:
+		return p.EntryPrice + margin.Div(qty)
+	}
+}
+
+// OpeningSide is the order side that opens or adds to a hedge-mode position side
+func (s PositionSide) OpeningSide() Side {
+	if s == PositionShort {
+		return Sell
+	}
+	return Buy
+}
+
+// Opposite returns the other side of a hedge-mode position
+func (s PositionSide) Opposite() PositionSide {
+	switch s {
+	case PositionLong:
+		return PositionShort
+	case PositionShort:
+		return PositionLong
+	}
+	return s
 }
@@ -0,0 +1,33 @@
+package domain
+
+import "oms-contract/pkg/decimal"
+
+// RejectCode says which pre-trade check turned an order away
+type RejectCode string
+
+const (
+	RejectNone             RejectCode = ""
+	RejectInstrument       RejectCode = "INSTRUMENT"         // 合约规则：状态、价格步长、数量步长、最小名义价值
+	RejectPositionSide     RejectCode = "POSITION_SIDE"      // 持仓方向与持仓模式不符
+	RejectReduceOnly       RejectCode = "REDUCE_ONLY"        // 只能减仓：双向持仓的平仓单、强平中的仓位
+	RejectMaxOrderQty      RejectCode = "MAX_ORDER_QTY"      // 单笔数量上限
+	RejectMaxOrderNotional RejectCode = "MAX_ORDER_NOTIONAL" // 单笔名义价值上限
+	RejectPriceBand        RejectCode = "PRICE_BAND"         // 限价偏离标记价格过远（防乌龙指）
+	RejectMaxOpenOrders    RejectCode = "MAX_OPEN_ORDERS"    // 单合约挂单数上限
+	RejectRiskLimit        RejectCode = "RISK_LIMIT"         // 风险限额档位
+	RejectMargin           RejectCode = "MARGIN"             // 无法冻结保证金：余额不足或市价单无参考价
+	RejectStopPrice        RejectCode = "STOP_PRICE"         // 条件单触发价无效或会立即触发
+	RejectOther            RejectCode = "OTHER"
+)
+
+// RiskRules are the pre-trade limits of one symbol and user group. Empty
+// Symbol or Group match every symbol or user; a zero limit is not enforced.
+type RiskRules struct {
+	Symbol string `json:",omitempty"`
+	Group  string `json:",omitempty"`
+
+	MaxOrderQty      decimal.Decimal
+	MaxOrderNotional decimal.Decimal
+	MaxOpenOrders    int             // 每个用户每个合约
+	PriceBand        decimal.Decimal // 限价单 |Price - Mark| / Mark 上限
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"time"
+
+	"oms-contract/pkg/decimal"
+)
+
+// StopOrder is a conditional order. It rests outside the matching engine
+// until its trigger price reaches StopPrice, rising to it when TriggerAbove
+// and falling to it otherwise, and is then submitted as a market order
+// (STOP_MARKET) or a limit order at Price (STOP_LIMIT).
+type StopOrder struct {
+	ID           int64
+	UserID       int64
+	Symbol       string
+	Side         Side
+	Type         OrderType // STOP_MARKET 或 STOP_LIMIT
+	PositionSide PositionSide
+	StopPrice    decimal.Decimal
+	TriggerBy    TriggerPrice
+	TriggerAbove bool            // 下单时按 StopPrice 相对触发价的位置确定
+	Price        decimal.Decimal // STOP_LIMIT 触发后的限价
+	Quantity     decimal.Decimal
+
+	ReduceOnly    bool
+	ClosePosition bool // 触发时按仓位确定数量
+
+	Status       StopStatus
+	CreatedAt    time.Time
+	TriggeredAt  time.Time
+	TriggerPrice decimal.Decimal // 触发时的价格
+	OrderID      int64           // 触发后提交的订单
+	RejectReason string
+}
+
+// IsPending reports whether the stop order still waits for its trigger
+func (s *StopOrder) IsPending() bool {
+	return s.Status == StopPending
+}
+
+// Reached reports whether a trigger price reaches the stop price
+func (s *StopOrder) Reached(price decimal.Decimal) bool {
+	if s.TriggerAbove {
+		return price >= s.StopPrice
+	}
+	return price <= s.StopPrice
+}
+
+// Order is the order the stop order submits once triggered
+func (s *StopOrder) Order() *Order {
+	o := &Order{
+		UserID:        s.UserID,
+		Symbol:        s.Symbol,
+		Side:          s.Side,
+		Type:          Market,
+		PositionSide:  s.PositionSide,
+		Quantity:      s.Quantity,
+		ReduceOnly:    s.ReduceOnly,
+		ClosePosition: s.ClosePosition,
+		StopID:        s.ID,
+	}
+	if s.Type == StopLimit {
+		o.Type, o.Price = Limit, s.Price
+	}
+	return o
+}
@@ -1,12 +1,25 @@
 package domain
 
+import (
+	"time"
+
+	"oms-contract/pkg/decimal"
+)
+
 type Trade struct {
 	TradeID int64
 	OrderID int64
-	Qty     float64
-	Price   float64
+	Qty     decimal.Decimal
+	Price   decimal.Decimal
 	UserID  int64
 	Symbol  string
 	Side    Side
 	IsMaker bool
+
+	PositionSide PositionSide
+
+	Fee       decimal.Decimal // 手续费，负数为 maker 返佣
+	FeeAsset  string
+	Forced    bool // 强平或自动减仓成交，不计入 VIP 交易量
+	CreatedAt time.Time
 }
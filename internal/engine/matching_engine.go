@@ -1,10 +1,11 @@
 package engine
 
 import (
-	"container/heap"
 	"oms-contract/internal/domain"
+	"oms-contract/pkg/decimal"
 	"oms-contract/pkg/idgen"
 	"sync"
+	"time"
 )
 
 // MatchingEngine implements a price-time priority order matching engine
@@ -38,142 +39,181 @@ func (m *MatchingEngine) SubmitOrder(order *domain.Order) []*domain.Trade {
 	return book.Match(order)
 }
 
+// CancelOrder removes a resting order from the book of the given symbol.
+// It returns the removed order, or false if the order is not resting.
+func (m *MatchingEngine) CancelOrder(symbol string, orderID int64) (*domain.Order, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	book, ok := m.books[symbol]
+	if !ok {
+		return nil, false
+	}
+	return book.Cancel(orderID)
+}
+
+// ReplaceOrder amends price and remaining quantity of a resting order.
+// Trades are returned if the new price crosses the book.
+func (m *MatchingEngine) ReplaceOrder(symbol string, orderID int64, price, qty decimal.Decimal) ([]*domain.Trade, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	book, ok := m.books[symbol]
+	if !ok {
+		return nil, false
+	}
+	return book.Replace(orderID, price, qty)
+}
+
+// Depth returns up to n aggregated price levels per side for the symbol
+func (m *MatchingEngine) Depth(symbol string, n int) (bids, asks []DepthLevel) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	book, ok := m.books[symbol]
+	if !ok {
+		return nil, nil
+	}
+	return book.Depth(n)
+}
+
 // ================= OrderBook =================
 
 type OrderBook struct {
 	symbol string
-	bids   *PriceHeap
-	asks   *PriceHeap
+	bids   *BookSide
+	asks   *BookSide
 }
 
 func NewOrderBook(symbol string) *OrderBook {
 	return &OrderBook{
 		symbol: symbol,
-		bids:   NewPriceHeap(domain.Buy),
-		asks:   NewPriceHeap(domain.Sell),
+		bids:   NewBookSide(domain.Buy),
+		asks:   NewBookSide(domain.Sell),
 	}
 }
 
 func (ob *OrderBook) Match(order *domain.Order) []*domain.Trade {
 	trades := make([]*domain.Trade, 0)
 
-	var bookSide *PriceHeap
+	var bookSide *BookSide
 	if order.Side == domain.Buy {
 		bookSide = ob.asks
 	} else {
 		bookSide = ob.bids
 	}
 
-	for order.Quantity > 0 && bookSide.Len() > 0 {
-		best := heap.Pop(bookSide).(*domain.Order)
-
-		// price check
-		if order.Side == domain.Buy && order.Price < best.Price {
-			heap.Push(bookSide, best)
-			break
-		}
-		if order.Side == domain.Sell && order.Price > best.Price {
-			heap.Push(bookSide, best)
+	for order.Quantity > 0 {
+		level := bookSide.Best()
+		if level == nil {
 			break
 		}
 
-		qty := min(order.Quantity, best.Quantity)
-
-		// taker trade
-		takerTrade := &domain.Trade{
-			TradeID: genTradeID(),
-			OrderID: order.ID,
-			UserID:  order.UserID,
-			Symbol:  order.Symbol,
-			Side:    order.Side,
-			Price:   best.Price,
-			Qty:     qty,
-			IsMaker: false,
-		}
-
-		// maker trade
-		makerTrade := &domain.Trade{
-			TradeID: genTradeID(),
-			OrderID: best.ID,
-			UserID:  best.UserID,
-			Symbol:  best.Symbol,
-			Side:    best.Side,
-			Price:   best.Price,
-			Qty:     qty,
-			IsMaker: true,
+		// price check (market orders take any price)
+		if order.Type != domain.Market {
+			if order.Side == domain.Buy && order.Price < level.Price {
+				break
+			}
+			if order.Side == domain.Sell && order.Price > level.Price {
+				break
+			}
 		}
 
-		trades = append(trades, takerTrade, makerTrade)
-
-		order.Quantity -= qty
-		best.Quantity -= qty
-
-		if best.Quantity > 0 {
-			heap.Push(bookSide, best)
+		for order.Quantity > 0 && level.Len() > 0 {
+			best := level.Front()
+			qty := decimal.Min(order.Quantity, best.Quantity)
+
+			// taker trade
+			takerTrade := &domain.Trade{
+				TradeID: genTradeID(),
+				OrderID: order.ID,
+				UserID:  order.UserID,
+				Symbol:  order.Symbol,
+				Side:    order.Side,
+				Price:   best.Price,
+				Qty:     qty,
+				IsMaker: false,
+
+				PositionSide: order.PositionSide,
+			}
+
+			// maker trade
+			makerTrade := &domain.Trade{
+				TradeID: genTradeID(),
+				OrderID: best.ID,
+				UserID:  best.UserID,
+				Symbol:  best.Symbol,
+				Side:    best.Side,
+				Price:   best.Price,
+				Qty:     qty,
+				IsMaker: true,
+
+				PositionSide: best.PositionSide,
+			}
+
+			trades = append(trades, takerTrade, makerTrade)
+
+			order.Quantity -= qty
+			if qty == best.Quantity {
+				bookSide.Remove(best.ID)
+			} else {
+				bookSide.Reduce(best.ID, best.Quantity-qty)
+			}
 		}
 	}
 
-	// Non-IOC orders can rest on book
-	if order.Quantity > 0 && order.Type != domain.IOC {
+	// Only limit orders can rest on book; IOC and market remainders expire
+	if order.Quantity > 0 && order.Type != domain.IOC && order.Type != domain.Market {
 		if order.Side == domain.Buy {
-			heap.Push(ob.bids, order)
+			ob.bids.Add(order)
 		} else {
-			heap.Push(ob.asks, order)
+			ob.asks.Add(order)
 		}
 	}
 
 	return trades
 }
 
-// ================= PriceHeap =================
-
-type PriceHeap struct {
-	side   domain.Side
-	orders []*domain.Order
-}
-
-func NewPriceHeap(side domain.Side) *PriceHeap {
-	h := &PriceHeap{side: side}
-	heap.Init(h)
-	return h
+// Cancel removes a resting order from either side of the book
+func (ob *OrderBook) Cancel(orderID int64) (*domain.Order, bool) {
+	if o, ok := ob.bids.Remove(orderID); ok {
+		return o, true
+	}
+	return ob.asks.Remove(orderID)
 }
 
-func (h PriceHeap) Len() int { return len(h.orders) }
-
-func (h PriceHeap) Less(i, j int) bool {
-	if h.orders[i].Price == h.orders[j].Price {
-		return h.orders[i].CreatedAt.Before(h.orders[j].CreatedAt)
-	}
-	if h.side == domain.Buy {
-		return h.orders[i].Price > h.orders[j].Price
+// Replace amends a resting order (cancel-replace).
+// A quantity reduction at the same price keeps time priority; a price change
+// or quantity increase re-queues the order with a new CreatedAt and re-matches it.
+func (ob *OrderBook) Replace(orderID int64, price, qty decimal.Decimal) ([]*domain.Trade, bool) {
+	side := ob.bids
+	o, ok := side.Get(orderID)
+	if !ok {
+		side = ob.asks
+		if o, ok = side.Get(orderID); !ok {
+			return nil, false
+		}
 	}
-	return h.orders[i].Price < h.orders[j].Price
-}
 
-func (h PriceHeap) Swap(i, j int) {
-	h.orders[i], h.orders[j] = h.orders[j], h.orders[i]
-}
+	if price == o.Price && qty <= o.Quantity {
+		side.Reduce(orderID, qty)
+		return nil, true
+	}
 
-func (h *PriceHeap) Push(x any) {
-	h.orders = append(h.orders, x.(*domain.Order))
+	side.Remove(orderID)
+	o.Price = price
+	o.Quantity = qty
+	o.CreatedAt = time.Now()
+	return ob.Match(o), true
 }
 
-func (h *PriceHeap) Pop() any {
-	n := len(h.orders)
-	item := h.orders[n-1]
-	h.orders = h.orders[:n-1]
-	return item
+// Depth returns up to n aggregated price levels per side, best first
+func (ob *OrderBook) Depth(n int) (bids, asks []DepthLevel) {
+	return ob.bids.Depth(n), ob.asks.Depth(n)
 }
 
 // ================= helpers =================
 
-func min(a, b float64) float64 {
-	if a < b {
-		return a
-	}
-	return b
-}
-
 func buyID(a, b *domain.Order) int64 {
 	if a.Side == domain.Buy {
 		return a.ID
@@ -188,8 +228,9 @@ func sellID(a, b *domain.Order) int64 {
 	return b.ID
 }
 
+// tradeIDGen is shared so that taker and maker trades never reuse an ID
+var tradeIDGen = idgen.NewTradeIDGen(1)
+
 func genTradeID() int64 {
-	tradeIDGen := idgen.NewTradeIDGen(1)
-	tradeID := tradeIDGen.Next()
-	return tradeID
+	return tradeIDGen.Next()
 }
@@ -4,6 +4,7 @@ import (
 	"hash/fnv"
 
 	"oms-contract/internal/domain"
+	"oms-contract/pkg/decimal"
 )
 
 // =============================
@@ -30,6 +31,24 @@ func (e *ShardedMatchingEngine) Submit(order *domain.Order) []*domain.Trade {
 	return shard.submit(order)
 }
 
+// Cancel removes a resting order from the shard owning the symbol
+func (e *ShardedMatchingEngine) Cancel(symbol string, orderID int64) (*domain.Order, bool) {
+	shard := e.pickShard(symbol)
+	return shard.cancel(symbol, orderID)
+}
+
+// Replace amends a resting order on the shard owning the symbol
+func (e *ShardedMatchingEngine) Replace(symbol string, orderID int64, price, qty decimal.Decimal) ([]*domain.Trade, bool) {
+	shard := e.pickShard(symbol)
+	return shard.replace(symbol, orderID, price, qty)
+}
+
+// Depth returns up to n aggregated price levels per side for the symbol
+func (e *ShardedMatchingEngine) Depth(symbol string, n int) (bids, asks []DepthLevel) {
+	shard := e.pickShard(symbol)
+	return shard.depth(symbol, n)
+}
+
 func (e *ShardedMatchingEngine) pickShard(symbol string) *engineShard {
 	h := fnv.New32a()
 	_, _ = h.Write([]byte(symbol))
@@ -42,10 +61,13 @@ func (e *ShardedMatchingEngine) pickShard(symbol string) *engineShard {
 // =============================
 
 type engineShard struct {
-	id     int
-	inCh   chan *submitReq
-	books  map[string]*OrderBook
-	closed chan struct{}
+	id        int
+	inCh      chan *submitReq
+	cancelCh  chan *cancelReq
+	replaceCh chan *replaceReq
+	depthCh   chan *depthReq
+	books     map[string]*OrderBook
+	closed    chan struct{}
 }
 
 type submitReq struct {
@@ -53,12 +75,40 @@ type submitReq struct {
 	resp  chan []*domain.Trade
 }
 
+type cancelReq struct {
+	symbol  string
+	orderID int64
+	resp    chan *domain.Order // nil if the order was not resting
+}
+
+type replaceReq struct {
+	symbol  string
+	orderID int64
+	price   decimal.Decimal
+	qty     decimal.Decimal
+	resp    chan replaceResp
+}
+
+type replaceResp struct {
+	trades []*domain.Trade
+	ok     bool
+}
+
+type depthReq struct {
+	symbol string
+	n      int
+	resp   chan [2][]DepthLevel // bids, asks
+}
+
 func newEngineShard(id int) *engineShard {
 	s := &engineShard{
-		id:     id,
-		inCh:   make(chan *submitReq, 1024),
-		books:  make(map[string]*OrderBook),
-		closed: make(chan struct{}),
+		id:        id,
+		inCh:      make(chan *submitReq, 1024),
+		cancelCh:  make(chan *cancelReq, 1024),
+		replaceCh: make(chan *replaceReq, 1024),
+		depthCh:   make(chan *depthReq, 1024),
+		books:     make(map[string]*OrderBook),
+		closed:    make(chan struct{}),
 	}
 
 	go s.loop()
@@ -72,6 +122,17 @@ func (s *engineShard) loop() {
 			book := s.getBook(req.order.Symbol)
 			trades := book.Match(req.order)
 			req.resp <- trades
+		case req := <-s.cancelCh:
+			book := s.getBook(req.symbol)
+			o, _ := book.Cancel(req.orderID)
+			req.resp <- o
+		case req := <-s.replaceCh:
+			book := s.getBook(req.symbol)
+			trades, ok := book.Replace(req.orderID, req.price, req.qty)
+			req.resp <- replaceResp{trades: trades, ok: ok}
+		case req := <-s.depthCh:
+			bids, asks := s.getBook(req.symbol).Depth(req.n)
+			req.resp <- [2][]DepthLevel{bids, asks}
 		case <-s.closed:
 			return
 		}
@@ -84,6 +145,27 @@ func (s *engineShard) submit(order *domain.Order) []*domain.Trade {
 	return <-resp
 }
 
+func (s *engineShard) cancel(symbol string, orderID int64) (*domain.Order, bool) {
+	resp := make(chan *domain.Order, 1)
+	s.cancelCh <- &cancelReq{symbol: symbol, orderID: orderID, resp: resp}
+	o := <-resp
+	return o, o != nil
+}
+
+func (s *engineShard) replace(symbol string, orderID int64, price, qty decimal.Decimal) ([]*domain.Trade, bool) {
+	resp := make(chan replaceResp, 1)
+	s.replaceCh <- &replaceReq{symbol: symbol, orderID: orderID, price: price, qty: qty, resp: resp}
+	r := <-resp
+	return r.trades, r.ok
+}
+
+func (s *engineShard) depth(symbol string, n int) (bids, asks []DepthLevel) {
+	resp := make(chan [2][]DepthLevel, 1)
+	s.depthCh <- &depthReq{symbol: symbol, n: n, resp: resp}
+	d := <-resp
+	return d[0], d[1]
+}
+
 func (s *engineShard) getBook(symbol string) *OrderBook {
 	book, ok := s.books[symbol]
 	if !ok {
@@ -109,4 +191,6 @@ func (e *ShardedMatchingEngine) Close() {
 
 var _ interface {
 	Submit(*domain.Order) []*domain.Trade
+	Cancel(string, int64) (*domain.Order, bool)
+	Replace(string, int64, decimal.Decimal, decimal.Decimal) ([]*domain.Trade, bool)
 } = (*ShardedMatchingEngine)(nil)
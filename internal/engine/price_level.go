@@ -0,0 +1,238 @@
+package engine
+
+import (
+	"container/list"
+	"math/rand"
+
+	"oms-contract/internal/domain"
+	"oms-contract/pkg/decimal"
+)
+
+// ================= PriceLevel =================
+
+// PriceLevel is a FIFO queue of resting orders sharing one price.
+// Queue position is time priority: the front order trades first.
+type PriceLevel struct {
+	Price    decimal.Decimal
+	Quantity decimal.Decimal // total resting quantity at this price
+	orders   *list.List
+}
+
+func newPriceLevel(price decimal.Decimal) *PriceLevel {
+	return &PriceLevel{Price: price, orders: list.New()}
+}
+
+// Len returns the number of orders queued at this level
+func (l *PriceLevel) Len() int { return l.orders.Len() }
+
+// Front returns the order with the highest time priority
+func (l *PriceLevel) Front() *domain.Order {
+	e := l.orders.Front()
+	if e == nil {
+		return nil
+	}
+	return e.Value.(*domain.Order)
+}
+
+// DepthLevel is an aggregated view of one price level
+type DepthLevel struct {
+	Price    decimal.Decimal
+	Quantity decimal.Decimal
+	Orders   int
+}
+
+// ================= BookSide =================
+
+// orderRef locates a resting order for O(1) removal
+type orderRef struct {
+	level *PriceLevel
+	elem  *list.Element
+}
+
+// BookSide holds one side of an order book as sorted price levels.
+// Bids are sorted high to low, asks low to high.
+type BookSide struct {
+	side   domain.Side
+	levels *skipList
+	index  map[int64]*orderRef // orderID -> queue position
+}
+
+func NewBookSide(side domain.Side) *BookSide {
+	better := func(a, b decimal.Decimal) bool { return a < b }
+	if side == domain.Buy {
+		better = func(a, b decimal.Decimal) bool { return a > b }
+	}
+	return &BookSide{
+		side:   side,
+		levels: newSkipList(better),
+		index:  make(map[int64]*orderRef),
+	}
+}
+
+// Len returns the number of resting orders
+func (s *BookSide) Len() int { return len(s.index) }
+
+// Best returns the best price level, or nil if the side is empty
+func (s *BookSide) Best() *PriceLevel {
+	return s.levels.first()
+}
+
+// Add appends an order to the back of its price level
+func (s *BookSide) Add(o *domain.Order) {
+	level := s.levels.get(o.Price)
+	if level == nil {
+		level = newPriceLevel(o.Price)
+		s.levels.insert(level)
+	}
+	elem := level.orders.PushBack(o)
+	level.Quantity += o.Quantity
+	s.index[o.ID] = &orderRef{level: level, elem: elem}
+}
+
+// Get returns a resting order by ID without removing it
+func (s *BookSide) Get(orderID int64) (*domain.Order, bool) {
+	ref, ok := s.index[orderID]
+	if !ok {
+		return nil, false
+	}
+	return ref.elem.Value.(*domain.Order), true
+}
+
+// Remove takes an arbitrary order out of the book by ID
+func (s *BookSide) Remove(orderID int64) (*domain.Order, bool) {
+	ref, ok := s.index[orderID]
+	if !ok {
+		return nil, false
+	}
+	o := ref.level.orders.Remove(ref.elem).(*domain.Order)
+	ref.level.Quantity -= o.Quantity
+	delete(s.index, orderID)
+
+	if ref.level.Len() == 0 {
+		s.levels.remove(ref.level.Price)
+	}
+	return o, true
+}
+
+// Reduce lowers the resting quantity of an order in place, keeping its priority
+func (s *BookSide) Reduce(orderID int64, qty decimal.Decimal) bool {
+	ref, ok := s.index[orderID]
+	if !ok {
+		return false
+	}
+	o := ref.elem.Value.(*domain.Order)
+	ref.level.Quantity -= o.Quantity - qty
+	o.Quantity = qty
+	return true
+}
+
+// Depth returns up to n aggregated levels from the best price outwards
+func (s *BookSide) Depth(n int) []DepthLevel {
+	depth := make([]DepthLevel, 0, n)
+	for node := s.levels.head.next[0]; node != nil && len(depth) < n; node = node.next[0] {
+		depth = append(depth, DepthLevel{
+			Price:    node.level.Price,
+			Quantity: node.level.Quantity,
+			Orders:   node.level.Len(),
+		})
+	}
+	return depth
+}
+
+// ================= skipList =================
+
+const skipListMaxLevel = 24
+
+type skipNode struct {
+	level *PriceLevel
+	next  []*skipNode
+}
+
+// skipList keeps price levels sorted by the side's notion of "better".
+// The random source is seeded so that node heights, and hence memory
+// layout, are reproducible across replays.
+type skipList struct {
+	head   *skipNode
+	height int
+	better func(a, b decimal.Decimal) bool
+	rnd    *rand.Rand
+}
+
+func newSkipList(better func(a, b decimal.Decimal) bool) *skipList {
+	return &skipList{
+		head:   &skipNode{next: make([]*skipNode, skipListMaxLevel)},
+		height: 1,
+		better: better,
+		rnd:    rand.New(rand.NewSource(1)),
+	}
+}
+
+func (l *skipList) randomHeight() int {
+	h := 1
+	for h < skipListMaxLevel && l.rnd.Intn(4) == 0 {
+		h++
+	}
+	return h
+}
+
+func (l *skipList) first() *PriceLevel {
+	if n := l.head.next[0]; n != nil {
+		return n.level
+	}
+	return nil
+}
+
+// seek fills update with the last node before price on every lane
+func (l *skipList) seek(price decimal.Decimal, update []*skipNode) *skipNode {
+	x := l.head
+	for i := l.height - 1; i >= 0; i-- {
+		for x.next[i] != nil && l.better(x.next[i].level.Price, price) {
+			x = x.next[i]
+		}
+		if update != nil {
+			update[i] = x
+		}
+	}
+	return x.next[0]
+}
+
+func (l *skipList) get(price decimal.Decimal) *PriceLevel {
+	if n := l.seek(price, nil); n != nil && n.level.Price == price {
+		return n.level
+	}
+	return nil
+}
+
+func (l *skipList) insert(level *PriceLevel) {
+	var update [skipListMaxLevel]*skipNode
+	l.seek(level.Price, update[:])
+
+	h := l.randomHeight()
+	if h > l.height {
+		for i := l.height; i < h; i++ {
+			update[i] = l.head
+		}
+		l.height = h
+	}
+
+	node := &skipNode{level: level, next: make([]*skipNode, h)}
+	for i := 0; i < h; i++ {
+		node.next[i] = update[i].next[i]
+		update[i].next[i] = node
+	}
+}
+
+func (l *skipList) remove(price decimal.Decimal) {
+	var update [skipListMaxLevel]*skipNode
+	node := l.seek(price, update[:])
+	if node == nil || node.level.Price != price {
+		return
+	}
+
+	for i := 0; i < len(node.next); i++ {
+		update[i].next[i] = node.next[i]
+	}
+	for l.height > 1 && l.head.next[l.height-1] == nil {
+		l.height--
+	}
+}
@@ -3,6 +3,7 @@ package engine_test
 import (
 	"oms-contract/internal/domain"
 	"oms-contract/internal/engine"
+	"oms-contract/pkg/decimal"
 	"testing"
 	"time"
 )
@@ -15,8 +16,8 @@ func TestMatchingEngine_BasicMatch(t *testing.T) {
 		UserID:    100,
 		Symbol:    "BTCUSDT",
 		Side:      domain.Sell,
-		Price:     30000,
-		Quantity:  1,
+		Price:     decimal.FromInt(30000),
+		Quantity:  decimal.FromInt(1),
 		CreatedAt: time.Now(),
 	}
 
@@ -25,8 +26,8 @@ func TestMatchingEngine_BasicMatch(t *testing.T) {
 		UserID:    200,
 		Symbol:    "BTCUSDT",
 		Side:      domain.Buy,
-		Price:     31000,
-		Quantity:  1,
+		Price:     decimal.FromInt(31000),
+		Quantity:  decimal.FromInt(1),
 		CreatedAt: time.Now(),
 	}
 
@@ -43,7 +44,7 @@ func TestMatchingEngine_BasicMatch(t *testing.T) {
 		t.Fatalf("expected 2 trades (maker+taker), got %d", len(trades))
 	}
 
-	if trades[0].Price != 30000 {
-		t.Fatalf("unexpected trade price: %f", trades[0].Price)
+	if trades[0].Price != decimal.FromInt(30000) {
+		t.Fatalf("unexpected trade price: %s", trades[0].Price)
 	}
 }
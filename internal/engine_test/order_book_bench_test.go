@@ -0,0 +1,230 @@
+package engine_test
+
+import (
+	"container/heap"
+	"testing"
+	"time"
+
+	"oms-contract/internal/domain"
+	"oms-contract/internal/engine"
+	"oms-contract/pkg/decimal"
+
+	"github.com/stretchr/testify/require"
+)
+
+// ================= legacy heap book (baseline) =================
+
+// heapBook is the previous single-heap order book, kept here only as a
+// benchmark baseline for the price-level book.
+type heapBook struct {
+	bids *priceHeap
+	asks *priceHeap
+}
+
+func newHeapBook() *heapBook {
+	return &heapBook{bids: newPriceHeap(domain.Buy), asks: newPriceHeap(domain.Sell)}
+}
+
+func (ob *heapBook) Match(order *domain.Order) int {
+	trades := 0
+	bookSide := ob.bids
+	if order.Side == domain.Buy {
+		bookSide = ob.asks
+	}
+
+	for order.Quantity > 0 && bookSide.Len() > 0 {
+		best := heap.Pop(bookSide).(*domain.Order)
+		if (order.Side == domain.Buy && order.Price < best.Price) ||
+			(order.Side == domain.Sell && order.Price > best.Price) {
+			heap.Push(bookSide, best)
+			break
+		}
+
+		qty := decimal.Min(order.Quantity, best.Quantity)
+		trades += 2
+		order.Quantity -= qty
+		best.Quantity -= qty
+		if best.Quantity > 0 {
+			heap.Push(bookSide, best)
+		}
+	}
+
+	if order.Quantity > 0 && order.Type != domain.IOC {
+		if order.Side == domain.Buy {
+			heap.Push(ob.bids, order)
+		} else {
+			heap.Push(ob.asks, order)
+		}
+	}
+	return trades
+}
+
+func (ob *heapBook) Cancel(orderID int64) bool {
+	for _, h := range []*priceHeap{ob.bids, ob.asks} {
+		if i, ok := h.index[orderID]; ok {
+			heap.Remove(h, i)
+			return true
+		}
+	}
+	return false
+}
+
+type priceHeap struct {
+	side   domain.Side
+	orders []*domain.Order
+	index  map[int64]int
+}
+
+func newPriceHeap(side domain.Side) *priceHeap {
+	return &priceHeap{side: side, index: make(map[int64]int)}
+}
+
+func (h priceHeap) Len() int { return len(h.orders) }
+
+func (h priceHeap) Less(i, j int) bool {
+	if h.orders[i].Price == h.orders[j].Price {
+		return h.orders[i].CreatedAt.Before(h.orders[j].CreatedAt)
+	}
+	if h.side == domain.Buy {
+		return h.orders[i].Price > h.orders[j].Price
+	}
+	return h.orders[i].Price < h.orders[j].Price
+}
+
+func (h priceHeap) Swap(i, j int) {
+	h.orders[i], h.orders[j] = h.orders[j], h.orders[i]
+	h.index[h.orders[i].ID] = i
+	h.index[h.orders[j].ID] = j
+}
+
+func (h *priceHeap) Push(x any) {
+	o := x.(*domain.Order)
+	h.index[o.ID] = len(h.orders)
+	h.orders = append(h.orders, o)
+}
+
+func (h *priceHeap) Pop() any {
+	n := len(h.orders)
+	item := h.orders[n-1]
+	h.orders = h.orders[:n-1]
+	delete(h.index, item.ID)
+	return item
+}
+
+// ================= benchmarks =================
+
+type benchBook interface {
+	place(o *domain.Order)
+	cancel(orderID int64)
+}
+
+type levelBookAdapter struct{ *engine.OrderBook }
+
+func (b levelBookAdapter) place(o *domain.Order) { b.Match(o) }
+func (b levelBookAdapter) cancel(id int64)       { b.Cancel(id) }
+
+type heapBookAdapter struct{ *heapBook }
+
+func (b heapBookAdapter) place(o *domain.Order) { b.Match(o) }
+func (b heapBookAdapter) cancel(id int64)       { b.Cancel(id) }
+
+var benchBooks = []struct {
+	name string
+	new  func() benchBook
+}{
+	{"PriceLevels", func() benchBook { return levelBookAdapter{engine.NewOrderBook("BTCUSDT")} }},
+	{"Heap", func() benchBook { return heapBookAdapter{newHeapBook()} }},
+}
+
+// benchOrders builds n unit-size orders spread over 100 price levels
+func benchOrders(n int, firstID int64, side domain.Side, base int64) []*domain.Order {
+	now := time.Now()
+	orders := make([]*domain.Order, n)
+	for i := range orders {
+		orders[i] = &domain.Order{
+			ID:        firstID + int64(i),
+			UserID:    100,
+			Symbol:    "BTCUSDT",
+			Side:      side,
+			Type:      domain.Limit,
+			Price:     decimal.FromInt(base + int64(i%100)),
+			Quantity:  decimal.One,
+			CreatedAt: now.Add(time.Duration(i)),
+		}
+	}
+	return orders
+}
+
+func BenchmarkOrderBook_Insert(b *testing.B) {
+	for _, bb := range benchBooks {
+		b.Run(bb.name, func(b *testing.B) {
+			orders := benchOrders(b.N, 1, domain.Sell, 30000)
+			book := bb.new()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				book.place(orders[i])
+			}
+		})
+	}
+}
+
+func BenchmarkOrderBook_Match(b *testing.B) {
+	for _, bb := range benchBooks {
+		b.Run(bb.name, func(b *testing.B) {
+			makers := benchOrders(b.N, 1, domain.Sell, 30000)
+			takers := benchOrders(b.N, int64(b.N)+1, domain.Buy, 40000)
+			book := bb.new()
+			for _, o := range makers {
+				book.place(o)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				book.place(takers[i])
+			}
+		})
+	}
+}
+
+func BenchmarkOrderBook_Cancel(b *testing.B) {
+	for _, bb := range benchBooks {
+		b.Run(bb.name, func(b *testing.B) {
+			orders := benchOrders(b.N, 1, domain.Sell, 30000)
+			book := bb.new()
+			for _, o := range orders {
+				book.place(o)
+			}
+			b.ResetTimer()
+			// cancel in scattered order rather than queue order
+			for i := 0; i < b.N; i++ {
+				book.cancel(orders[(i*7919)%b.N].ID)
+			}
+		})
+	}
+}
+
+func TestOrderBook_Depth(t *testing.T) {
+	book := engine.NewOrderBook("BTCUSDT")
+	for _, o := range benchOrders(300, 1, domain.Sell, 30000) {
+		book.Match(o)
+	}
+	book.Match(newLimitOrder("BTCUSDT", domain.Buy, 29000, 2))
+
+	bids, asks := book.Depth(5)
+	require.Len(t, bids, 1)
+	require.Equal(t, engine.DepthLevel{Price: decimal.FromInt(29000), Quantity: decimal.FromInt(2), Orders: 1}, bids[0])
+
+	require.Len(t, asks, 5)
+	for i, lvl := range asks {
+		require.Equal(t, decimal.FromInt(30000+int64(i)), lvl.Price)
+		require.Equal(t, decimal.FromInt(3), lvl.Quantity)
+		require.Equal(t, 3, lvl.Orders)
+	}
+
+	// Cancelling every order at the best level removes the level
+	for id := int64(1); id <= 300; id += 100 {
+		_, ok := book.Cancel(id)
+		require.True(t, ok)
+	}
+	_, asks = book.Depth(1)
+	require.Equal(t, decimal.FromInt(30001), asks[0].Price)
+}
@@ -7,6 +7,7 @@ import (
 
 	"oms-contract/internal/domain"
 	"oms-contract/internal/engine"
+	"oms-contract/pkg/decimal"
 	"oms-contract/pkg/idgen"
 
 	"github.com/stretchr/testify/require"
@@ -22,8 +23,8 @@ func newLimitOrder(symbol string, side domain.Side, price, qty float64) *domain.
 		Symbol:    symbol,
 		Side:      side,
 		Type:      domain.Limit,
-		Price:     price,
-		Quantity:  qty,
+		Price:     decimal.FromFloat(price),
+		Quantity:  decimal.FromFloat(qty),
 		Status:    domain.Submitted,
 		CreatedAt: time.Now(),
 	}
@@ -44,8 +45,8 @@ func Test_ShardedMatchingEngine_BasicMatch(t *testing.T) {
 	// Should have 2 trades (one for maker, one for taker)
 	require.Len(t, trades2, 2)
 	require.Equal(t, "BTCUSDT", trades2[0].Symbol)
-	require.Equal(t, 30000.0, trades2[0].Price)
-	require.Equal(t, 1.0, trades2[0].Qty)
+	require.Equal(t, decimal.FromInt(30000), trades2[0].Price)
+	require.Equal(t, decimal.FromInt(1), trades2[0].Qty)
 }
 
 func Test_ShardedMatchingEngine_PriceTimePriority(t *testing.T) {
@@ -62,8 +63,8 @@ func Test_ShardedMatchingEngine_PriceTimePriority(t *testing.T) {
 	require.Len(t, trades, 4)
 
 	// Verify quantities
-	require.Equal(t, 5.0, trades[0].Qty)
-	require.Equal(t, 5.0, trades[2].Qty)
+	require.Equal(t, decimal.FromInt(5), trades[0].Qty)
+	require.Equal(t, decimal.FromInt(5), trades[2].Qty)
 }
 
 func Test_ShardedMatchingEngine_ConcurrentSymbols(t *testing.T) {
@@ -164,6 +165,69 @@ func Test_ShardedMatchingEngine_PartialFill(t *testing.T) {
 	trades := e.Submit(newLimitOrder("BTCUSDT", domain.Buy, 1000, 5))
 
 	require.Len(t, trades, 2)
-	require.Equal(t, 5.0, trades[0].Qty)
-	require.Equal(t, 5.0, trades[1].Qty)
+	require.Equal(t, decimal.FromInt(5), trades[0].Qty)
+	require.Equal(t, decimal.FromInt(5), trades[1].Qty)
+}
+
+func Test_ShardedMatchingEngine_Cancel(t *testing.T) {
+	e := engine.NewShardedMatchingEngine(4)
+
+	maker := newLimitOrder("BTCUSDT", domain.Sell, 1000, 1)
+	e.Submit(maker)
+
+	canceled, ok := e.Cancel("BTCUSDT", maker.ID)
+	require.True(t, ok)
+	require.Equal(t, maker.ID, canceled.ID)
+
+	// Canceled order no longer provides liquidity
+	trades := e.Submit(newLimitOrder("BTCUSDT", domain.Buy, 1000, 1))
+	require.Len(t, trades, 0)
+
+	// Second cancel is a no-op
+	_, ok = e.Cancel("BTCUSDT", maker.ID)
+	require.False(t, ok)
+}
+
+func Test_ShardedMatchingEngine_ReplacePriority(t *testing.T) {
+	e := engine.NewShardedMatchingEngine(2)
+
+	first := newLimitOrder("ETHUSDT", domain.Sell, 2000, 5)
+	e.Submit(first)
+	time.Sleep(time.Millisecond)
+	second := newLimitOrder("ETHUSDT", domain.Sell, 2000, 5)
+	e.Submit(second)
+
+	// Reducing quantity at the same price keeps the front of the queue
+	trades, ok := e.Replace("ETHUSDT", first.ID, decimal.FromInt(2000), decimal.FromInt(3))
+	require.True(t, ok)
+	require.Len(t, trades, 0)
+
+	trades = e.Submit(newLimitOrder("ETHUSDT", domain.Buy, 2000, 1))
+	require.Len(t, trades, 2)
+	require.Equal(t, first.ID, trades[1].OrderID)
+
+	// Increasing quantity sends the order to the back of the queue
+	_, ok = e.Replace("ETHUSDT", first.ID, decimal.FromInt(2000), decimal.FromInt(4))
+	require.True(t, ok)
+
+	trades = e.Submit(newLimitOrder("ETHUSDT", domain.Buy, 2000, 1))
+	require.Len(t, trades, 2)
+	require.Equal(t, second.ID, trades[1].OrderID)
+}
+
+func Test_ShardedMatchingEngine_ReplaceCrossesBook(t *testing.T) {
+	e := engine.NewShardedMatchingEngine(2)
+
+	e.Submit(newLimitOrder("BTCUSDT", domain.Sell, 1010, 1))
+	bid := newLimitOrder("BTCUSDT", domain.Buy, 1000, 1)
+	e.Submit(bid)
+
+	// Repricing the bid through the ask trades immediately
+	trades, ok := e.Replace("BTCUSDT", bid.ID, decimal.FromInt(1010), decimal.FromInt(1))
+	require.True(t, ok)
+	require.Len(t, trades, 2)
+	require.Equal(t, decimal.FromInt(1010), trades[0].Price)
+
+	_, ok = e.Replace("BTCUSDT", bid.ID, decimal.FromInt(1000), decimal.FromInt(1))
+	require.False(t, ok)
 }
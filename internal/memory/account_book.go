@@ -0,0 +1,246 @@
+package memory
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	"oms-contract/internal/domain"
+	"oms-contract/pkg/decimal"
+)
+
+// JournalRetention is how many recent journal entries are kept in memory
+// per user for queries; the full journal lives in the event log
+const JournalRetention = 200
+
+type AccountBook struct {
+	mu            sync.RWMutex
+	accounts      map[string]*domain.Account
+	system        map[string]decimal.Decimal // bucket:asset -> balance
+	journal       map[int64][]*domain.JournalEntry
+	systemJournal map[string][]*domain.JournalEntry // bucket:asset -> entries
+	lastEntryID   int64
+}
+
+// AccountBookState is the serializable form of an AccountBook
+type AccountBookState struct {
+	Accounts       map[string]*domain.Account `json:"accounts"`
+	SystemBalances map[string]decimal.Decimal `json:"system_balances"`
+	Journal        []*domain.JournalEntry     `json:"journal"`
+	LastEntryID    int64                      `json:"last_entry_id"`
+}
+
+func NewAccountBook() *AccountBook {
+	return &AccountBook{
+		accounts: make(map[string]*domain.Account),
+		system:   make(map[string]decimal.Decimal),
+		journal:  make(map[int64][]*domain.JournalEntry),
+
+		systemJournal: make(map[string][]*domain.JournalEntry),
+	}
+}
+
+func accountKey(uid int64, asset string) string {
+	return asset + ":" + strconv.FormatInt(uid, 10)
+}
+
+func systemKey(b domain.Bucket, asset string) string {
+	return string(b) + ":" + asset
+}
+
+func (b *AccountBook) Get(uid int64, asset string) (*domain.Account, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	a, ok := b.accounts[accountKey(uid, asset)]
+	return a, ok
+}
+
+// GetByUser returns all of a user's accounts sorted by asset
+func (b *AccountBook) GetByUser(uid int64) []*domain.Account {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var list []*domain.Account
+	for _, a := range b.accounts {
+		if a.UserID == uid {
+			list = append(list, a)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Asset < list[j].Asset })
+	return list
+}
+
+// Balance returns the balance of any ledger account
+func (b *AccountBook) Balance(la domain.LedgerAccount, asset string) decimal.Decimal {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.balance(la, asset)
+}
+
+func (b *AccountBook) balance(la domain.LedgerAccount, asset string) decimal.Decimal {
+	if la.IsSystem() {
+		return b.system[systemKey(la.Bucket, asset)]
+	}
+	a, ok := b.accounts[accountKey(la.UserID, asset)]
+	if !ok {
+		return decimal.Zero
+	}
+	return *bucketOf(a, la.Bucket)
+}
+
+// Post applies both legs of a journal entry and assigns its ID
+func (b *AccountBook) Post(e *domain.JournalEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastEntryID++
+	e.ID = b.lastEntryID
+
+	b.adjust(e.Debit, e.Asset, -e.Amount)
+	b.adjust(e.Credit, e.Asset, e.Amount)
+
+	b.record(e)
+}
+
+// record keeps the entry in the journal of each user and each system
+// account it touches
+func (b *AccountBook) record(e *domain.JournalEntry) {
+	users := []int64{e.Debit.UserID}
+	if e.Credit.UserID != e.Debit.UserID {
+		users = append(users, e.Credit.UserID)
+	}
+
+	for _, uid := range users {
+		if uid == 0 {
+			continue
+		}
+		b.journal[uid] = retain(append(b.journal[uid], e))
+	}
+
+	for _, la := range []domain.LedgerAccount{e.Debit, e.Credit} {
+		if la.IsSystem() {
+			k := systemKey(la.Bucket, e.Asset)
+			b.systemJournal[k] = retain(append(b.systemJournal[k], e))
+		}
+	}
+}
+
+func retain(entries []*domain.JournalEntry) []*domain.JournalEntry {
+	if len(entries) > JournalRetention {
+		entries = entries[len(entries)-JournalRetention:]
+	}
+	return entries
+}
+
+func (b *AccountBook) adjust(la domain.LedgerAccount, asset string, delta decimal.Decimal) {
+	if la.IsSystem() {
+		b.system[systemKey(la.Bucket, asset)] += delta
+		return
+	}
+
+	k := accountKey(la.UserID, asset)
+	a, ok := b.accounts[k]
+	if !ok {
+		a = &domain.Account{UserID: la.UserID, Asset: asset}
+		b.accounts[k] = a
+	}
+	*bucketOf(a, la.Bucket) += delta
+}
+
+func bucketOf(a *domain.Account, bucket domain.Bucket) *decimal.Decimal {
+	switch bucket {
+	case domain.BucketFrozen:
+		return &a.Frozen
+	case domain.BucketMargin:
+		return &a.Margin
+	}
+	return &a.Available
+}
+
+// Journal returns up to limit of the user's most recent entries, newest first
+func (b *AccountBook) Journal(uid int64, limit int) []*domain.JournalEntry {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return newestFirst(b.journal[uid], limit)
+}
+
+func newestFirst(entries []*domain.JournalEntry, limit int) []*domain.JournalEntry {
+	if limit <= 0 || limit > len(entries) {
+		limit = len(entries)
+	}
+	out := make([]*domain.JournalEntry, 0, limit)
+	for i := len(entries) - 1; i >= len(entries)-limit; i-- {
+		out = append(out, entries[i])
+	}
+	return out
+}
+
+// SystemJournal returns up to limit of the most recent entries of a system
+// account in one asset, newest first
+func (b *AccountBook) SystemJournal(bucket domain.Bucket, asset string, limit int) []*domain.JournalEntry {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return newestFirst(b.systemJournal[systemKey(bucket, asset)], limit)
+}
+
+// State returns a deep copy of the book for snapshots
+func (b *AccountBook) State() *AccountBookState {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	s := &AccountBookState{
+		Accounts:       make(map[string]*domain.Account, len(b.accounts)),
+		SystemBalances: make(map[string]decimal.Decimal, len(b.system)),
+		LastEntryID:    b.lastEntryID,
+	}
+	for k, a := range b.accounts {
+		cp := *a
+		s.Accounts[k] = &cp
+	}
+	for k, v := range b.system {
+		s.SystemBalances[k] = v
+	}
+
+	seen := make(map[int64]bool)
+	collect := func(entries []*domain.JournalEntry) {
+		for _, e := range entries {
+			if !seen[e.ID] {
+				seen[e.ID] = true
+				cp := *e
+				s.Journal = append(s.Journal, &cp)
+			}
+		}
+	}
+	for _, entries := range b.journal {
+		collect(entries)
+	}
+	for _, entries := range b.systemJournal {
+		collect(entries)
+	}
+	sort.Slice(s.Journal, func(i, j int) bool { return s.Journal[i].ID < s.Journal[j].ID })
+	return s
+}
+
+// Restore replaces the book contents with a snapshot state
+func (b *AccountBook) Restore(s *AccountBookState) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.accounts = make(map[string]*domain.Account, len(s.Accounts))
+	for k, a := range s.Accounts {
+		cp := *a
+		b.accounts[k] = &cp
+	}
+	b.system = make(map[string]decimal.Decimal, len(s.SystemBalances))
+	for k, v := range s.SystemBalances {
+		b.system[k] = v
+	}
+	b.journal = make(map[int64][]*domain.JournalEntry)
+	b.systemJournal = make(map[string][]*domain.JournalEntry)
+	for _, e := range s.Journal {
+		cp := *e
+		b.record(&cp)
+	}
+	b.lastEntryID = s.LastEntryID
+}
@@ -0,0 +1,140 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"oms-contract/internal/domain"
+	"oms-contract/pkg/decimal"
+)
+
+// PremiumSampleInterval is the clock the funding premium is sampled on:
+// each tick takes the premium of the mark price in force at that moment,
+// so the premium index weighs marks by how long they held
+const PremiumSampleInterval = time.Minute
+
+// PremiumSamples accumulates a symbol's premium over a funding interval
+type PremiumSamples struct {
+	Sum     decimal.Decimal `json:"sum"`
+	N       int64           `json:"n"`
+	Premium decimal.Decimal `json:"premium"` // in force since At
+	At      time.Time       `json:"at"`
+}
+
+// until takes the ticks in (p.At, at] at the premium in force
+func (p PremiumSamples) until(at time.Time) PremiumSamples {
+	if p.At.IsZero() || !at.After(p.At) {
+		return p
+	}
+	n := int64(at.Truncate(PremiumSampleInterval).Sub(p.At.Truncate(PremiumSampleInterval)) / PremiumSampleInterval)
+	p.Sum += p.Premium.Mul(decimal.FromInt(n))
+	p.N += n
+	p.At = at
+	return p
+}
+
+// FundingBook keeps the last funding settlement of each symbol and the
+// premium sampled since
+type FundingBook struct {
+	mu       sync.RWMutex
+	rates    map[string]*domain.FundingRate
+	premiums map[string]PremiumSamples
+}
+
+func NewFundingBook() *FundingBook {
+	return &FundingBook{
+		rates:    make(map[string]*domain.FundingRate),
+		premiums: make(map[string]PremiumSamples),
+	}
+}
+
+func (b *FundingBook) Get(symbol string) (*domain.FundingRate, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	r, ok := b.rates[symbol]
+	return r, ok
+}
+
+func (b *FundingBook) Save(r *domain.FundingRate) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rates[r.Symbol] = r
+}
+
+// Settle saves a settlement and starts the symbol's next interval at its
+// funding time, at the premium in force
+func (b *FundingBook) Settle(r *domain.FundingRate) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rates[r.Symbol] = r
+	if p, ok := b.premiums[r.Symbol]; ok {
+		b.premiums[r.Symbol] = PremiumSamples{Premium: p.Premium, At: r.FundingTime}
+	}
+}
+
+// Sample records the premium of a mark price updated at at: the ticks
+// since the last update are taken at the previous premium
+func (b *FundingBook) Sample(symbol string, premium decimal.Decimal, at time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	p := b.premiums[symbol].until(at)
+	if p.At.IsZero() {
+		p.At = at
+	}
+	p.Premium = premium
+	b.premiums[symbol] = p
+}
+
+// PremiumIndex is the average premium of the ticks up to at in the current
+// interval, the premium in force when no tick passed yet. ok is false for a
+// symbol never sampled.
+func (b *FundingBook) PremiumIndex(symbol string, at time.Time) (premium decimal.Decimal, ok bool) {
+	b.mu.RLock()
+	p, ok := b.premiums[symbol]
+	b.mu.RUnlock()
+	if !ok {
+		return decimal.Zero, false
+	}
+
+	p = p.until(at)
+	if p.N > 0 {
+		return p.Sum.Div(decimal.FromInt(p.N)), true
+	}
+	return p.Premium, true
+}
+
+// GetAll returns a copy of the last funding rate map
+func (b *FundingBook) GetAll() map[string]*domain.FundingRate {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	copy := make(map[string]*domain.FundingRate, len(b.rates))
+	for k, v := range b.rates {
+		copy[k] = v
+	}
+	return copy
+}
+
+// Premiums returns a copy of the premium sampled per symbol for snapshots
+func (b *FundingBook) Premiums() map[string]PremiumSamples {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	copy := make(map[string]PremiumSamples, len(b.premiums))
+	for k, v := range b.premiums {
+		copy[k] = v
+	}
+	return copy
+}
+
+// RestorePremiums replaces the premium samples with a snapshot's
+func (b *FundingBook) RestorePremiums(premiums map[string]PremiumSamples) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.premiums = make(map[string]PremiumSamples, len(premiums))
+	for k, v := range premiums {
+		b.premiums[k] = v
+	}
+}
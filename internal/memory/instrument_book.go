@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"sync"
+
+	"oms-contract/internal/domain"
+)
+
+type InstrumentBook struct {
+	mu          sync.RWMutex
+	instruments map[string]*domain.Instrument
+}
+
+func NewInstrumentBook() *InstrumentBook {
+	return &InstrumentBook{instruments: make(map[string]*domain.Instrument)}
+}
+
+func (b *InstrumentBook) Get(symbol string) (*domain.Instrument, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	i, ok := b.instruments[symbol]
+	return i, ok
+}
+
+func (b *InstrumentBook) Save(i *domain.Instrument) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.instruments[i.Symbol] = i
+}
+
+// GetAll returns a copy of the current instrument map
+func (b *InstrumentBook) GetAll() map[string]*domain.Instrument {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	copy := make(map[string]*domain.Instrument, len(b.instruments))
+	for k, v := range b.instruments {
+		copy[k] = v
+	}
+	return copy
+}
@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"sync"
+
+	"oms-contract/internal/domain"
+)
+
+type MarkPriceBook struct {
+	mu     sync.RWMutex
+	prices map[string]*domain.MarkPrice
+}
+
+func NewMarkPriceBook() *MarkPriceBook {
+	return &MarkPriceBook{prices: make(map[string]*domain.MarkPrice)}
+}
+
+func (b *MarkPriceBook) Get(symbol string) (*domain.MarkPrice, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	m, ok := b.prices[symbol]
+	return m, ok
+}
+
+func (b *MarkPriceBook) Save(m *domain.MarkPrice) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.prices[m.Symbol] = m
+}
+
+// GetAll returns a copy of the current mark price map
+func (b *MarkPriceBook) GetAll() map[string]*domain.MarkPrice {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	copy := make(map[string]*domain.MarkPrice, len(b.prices))
+	for k, v := range b.prices {
+		copy[k] = v
+	}
+	return copy
+}
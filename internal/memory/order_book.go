@@ -41,3 +41,17 @@ func (b *OrderBook) GetAll() map[int64]*domain.Order {
 	}
 	return copy
 }
+
+// GetActiveByUser returns the user's orders that can still trade
+func (b *OrderBook) GetActiveByUser(uid int64) []*domain.Order {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var list []*domain.Order
+	for _, o := range b.orders {
+		if o.UserID == uid && o.IsActive() {
+			list = append(list, o)
+		}
+	}
+	return list
+}
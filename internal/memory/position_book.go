@@ -2,6 +2,8 @@ package memory
 
 import (
 	"oms-contract/internal/domain"
+	"oms-contract/pkg/decimal"
+	"sort"
 	"strconv"
 	"sync"
 )
@@ -9,30 +11,172 @@ import (
 type PositionBook struct {
 	mu        sync.RWMutex
 	positions map[string]*domain.Position
+	symbols   map[string]*symbolIndex       // 按合约索引的非空仓位
+	modes     map[int64]domain.PositionMode // 仅记录切换过模式的用户，缺省为单向持仓
+	margins   map[int64]domain.MarginMode   // 仅记录全仓用户，缺省为逐仓
+	limits    map[int64]map[string]int      // 用户按合约选择的风险限额档位，缺省不限
+}
+
+// symbolIndex holds the open positions of one symbol, with those that have
+// a liquidation price kept in the order a moving mark reaches them
+type symbolIndex struct {
+	open   map[string]*domain.Position
+	liq    map[string]decimal.Decimal // 已入强平索引的仓位及其强平价
+	longs  liqIndex                   // 强平价从高到低
+	shorts liqIndex                   // 强平价从低到高
+}
+
+type liqEntry struct {
+	key   string
+	price decimal.Decimal
+}
+
+type liqIndex struct {
+	desc    bool
+	entries []liqEntry
 }
 
 func NewPositionBook() *PositionBook {
 	return &PositionBook{
 		positions: make(map[string]*domain.Position),
+		symbols:   make(map[string]*symbolIndex),
+		modes:     make(map[int64]domain.PositionMode),
+		margins:   make(map[int64]domain.MarginMode),
+		limits:    make(map[int64]map[string]int),
 	}
 }
 
-func key(uid int64, symbol string) string {
-	return symbol + ":" + strconv.FormatInt(uid, 10)
-
+// key keeps one-way positions at symbol:uid and adds the side for hedge positions
+func key(uid int64, symbol string, side domain.PositionSide) string {
+	k := symbol + ":" + strconv.FormatInt(uid, 10)
+	if side == domain.PositionLong || side == domain.PositionShort {
+		k += ":" + string(side)
+	}
+	return k
 }
 
+// Get returns the user's one-way (net) position in a symbol
 func (b *PositionBook) Get(uid int64, symbol string) (*domain.Position, bool) {
+	return b.GetSide(uid, symbol, domain.PositionBoth)
+}
+
+// GetSide returns the user's position on one side of a symbol
+func (b *PositionBook) GetSide(uid int64, symbol string, side domain.PositionSide) (*domain.Position, bool) {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
-	p, ok := b.positions[key(uid, symbol)]
+	p, ok := b.positions[key(uid, symbol, side)]
 	return p, ok
 }
 
 func (b *PositionBook) Save(p *domain.Position) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
-	b.positions[key(p.UserID, p.Symbol)] = p
+
+	k := key(p.UserID, p.Symbol, p.Side)
+	idx, ok := b.symbols[p.Symbol]
+	if !ok {
+		idx = &symbolIndex{
+			open:  make(map[string]*domain.Position),
+			liq:   make(map[string]decimal.Decimal),
+			longs: liqIndex{desc: true},
+		}
+		b.symbols[p.Symbol] = idx
+	}
+
+	// The saved position may be the indexed one mutated in place, so its
+	// old entry is found by the price stored at indexing time
+	if price, ok := idx.liq[k]; ok {
+		idx.longs.remove(liqEntry{key: k, price: price})
+		idx.shorts.remove(liqEntry{key: k, price: price})
+		delete(idx.liq, k)
+	}
+	delete(idx.open, k)
+
+	b.positions[k] = p
+	if p.Qty.IsZero() {
+		return
+	}
+	idx.open[k] = p
+	if p.LiquidationPrice > 0 {
+		idx.liq[k] = p.LiquidationPrice
+		if p.Qty > 0 {
+			idx.longs.insert(liqEntry{key: k, price: p.LiquidationPrice})
+		} else {
+			idx.shorts.insert(liqEntry{key: k, price: p.LiquidationPrice})
+		}
+	}
+}
+
+// SetState moves a position to a liquidation state
+func (b *PositionBook) SetState(uid int64, symbol string, side domain.PositionSide, state domain.LiquidationState) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if p, ok := b.positions[key(uid, symbol, side)]; ok {
+		p.State = state
+	}
+}
+
+// AllBySymbol returns the open positions in a symbol, ordered by key
+func (b *PositionBook) AllBySymbol(symbol string) []*domain.Position {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	idx, ok := b.symbols[symbol]
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(idx.open))
+	for k := range idx.open {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	list := make([]*domain.Position, 0, len(keys))
+	for _, k := range keys {
+		list = append(list, idx.open[k])
+	}
+	return list
+}
+
+// AtRisk returns the open positions in a symbol whose liquidation price the
+// mark has reached: longs at or above it, shorts at or below it. Only the
+// positions past the mark are visited.
+func (b *PositionBook) AtRisk(symbol string, mark decimal.Decimal) []*domain.Position {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	idx, ok := b.symbols[symbol]
+	if !ok {
+		return nil
+	}
+	var list []*domain.Position
+	for _, e := range idx.longs.entries {
+		if e.price < mark {
+			break
+		}
+		list = append(list, idx.open[e.key])
+	}
+	for _, e := range idx.shorts.entries {
+		if e.price > mark {
+			break
+		}
+		list = append(list, idx.open[e.key])
+	}
+	return list
+}
+
+// GetByUser returns all of a user's positions, open or flat
+func (b *PositionBook) GetByUser(uid int64) []*domain.Position {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var list []*domain.Position
+	for _, p := range b.positions {
+		if p.UserID == uid {
+			list = append(list, p)
+		}
+	}
+	return list
 }
 
 // GetAll returns a copy of the current position map
@@ -46,3 +190,131 @@ func (b *PositionBook) GetAll() map[string]*domain.Position {
 	}
 	return copy
 }
+
+// Mode returns the user's position mode, one-way unless switched
+func (b *PositionBook) Mode(uid int64) domain.PositionMode {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if m, ok := b.modes[uid]; ok {
+		return m
+	}
+	return domain.OneWayMode
+}
+
+func (b *PositionBook) SetMode(uid int64, mode domain.PositionMode) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if mode == domain.OneWayMode {
+		delete(b.modes, uid)
+		return
+	}
+	b.modes[uid] = mode
+}
+
+// Modes returns a copy of every non-default position mode
+func (b *PositionBook) Modes() map[int64]domain.PositionMode {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	copy := make(map[int64]domain.PositionMode, len(b.modes))
+	for k, v := range b.modes {
+		copy[k] = v
+	}
+	return copy
+}
+
+// MarginMode returns the user's margin mode, isolated unless switched
+func (b *PositionBook) MarginMode(uid int64) domain.MarginMode {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if m, ok := b.margins[uid]; ok {
+		return m
+	}
+	return domain.IsolatedMargin
+}
+
+func (b *PositionBook) SetMarginMode(uid int64, mode domain.MarginMode) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if mode == domain.IsolatedMargin {
+		delete(b.margins, uid)
+		return
+	}
+	b.margins[uid] = mode
+}
+
+// MarginModes returns a copy of every non-default margin mode
+func (b *PositionBook) MarginModes() map[int64]domain.MarginMode {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	copy := make(map[int64]domain.MarginMode, len(b.margins))
+	for k, v := range b.margins {
+		copy[k] = v
+	}
+	return copy
+}
+
+// RiskLimit returns the risk tier a user's positions in a symbol are
+// capped at, 0 when uncapped
+func (b *PositionBook) RiskLimit(uid int64, symbol string) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.limits[uid][symbol]
+}
+
+func (b *PositionBook) SetRiskLimit(uid int64, symbol string, tier int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if tier == 0 {
+		delete(b.limits[uid], symbol)
+		if len(b.limits[uid]) == 0 {
+			delete(b.limits, uid)
+		}
+		return
+	}
+	if b.limits[uid] == nil {
+		b.limits[uid] = make(map[string]int)
+	}
+	b.limits[uid][symbol] = tier
+}
+
+// RiskLimits returns a copy of every selected risk limit
+func (b *PositionBook) RiskLimits() map[int64]map[string]int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	copy := make(map[int64]map[string]int, len(b.limits))
+	for uid, limits := range b.limits {
+		copy[uid] = make(map[string]int, len(limits))
+		for symbol, tier := range limits {
+			copy[uid][symbol] = tier
+		}
+	}
+	return copy
+}
+
+// before orders entries by price, then key so equal prices sort stably
+func (x *liqIndex) before(a, b liqEntry) bool {
+	if a.price != b.price {
+		return (a.price > b.price) == x.desc
+	}
+	return a.key < b.key
+}
+
+func (x *liqIndex) search(e liqEntry) int {
+	return sort.Search(len(x.entries), func(i int) bool { return !x.before(x.entries[i], e) })
+}
+
+func (x *liqIndex) insert(e liqEntry) {
+	i := x.search(e)
+	x.entries = append(x.entries, liqEntry{})
+	copy(x.entries[i+1:], x.entries[i:])
+	x.entries[i] = e
+}
+
+func (x *liqIndex) remove(e liqEntry) {
+	if i := x.search(e); i < len(x.entries) && x.entries[i] == e {
+		x.entries = append(x.entries[:i], x.entries[i+1:]...)
+	}
+}
@@ -0,0 +1,205 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"oms-contract/internal/domain"
+	"oms-contract/pkg/decimal"
+)
+
+// StopBook holds stop orders. Pending ones are also queued per symbol and
+// trigger price, sorted by stop price in the order a moving price reaches
+// them.
+type StopBook struct {
+	mu      sync.RWMutex
+	orders  map[int64]*domain.StopOrder
+	pending map[stopKey]*stopQueue
+}
+
+type stopKey struct {
+	symbol string
+	by     domain.TriggerPrice
+}
+
+type stopQueue struct {
+	above []*domain.StopOrder // 价格上涨触发，StopPrice 升序
+	below []*domain.StopOrder // 价格下跌触发，StopPrice 降序
+}
+
+func NewStopBook() *StopBook {
+	return &StopBook{
+		orders:  make(map[int64]*domain.StopOrder),
+		pending: make(map[stopKey]*stopQueue),
+	}
+}
+
+func (b *StopBook) Add(o *domain.StopOrder) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.orders[o.ID] = o
+	if o.IsPending() {
+		b.enqueue(o)
+	}
+}
+
+func (b *StopBook) Get(id int64) (*domain.StopOrder, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	o, ok := b.orders[id]
+	return o, ok
+}
+
+// GetAll returns a copy of the stop order map
+func (b *StopBook) GetAll() map[int64]*domain.StopOrder {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	copy := make(map[int64]*domain.StopOrder, len(b.orders))
+	for k, v := range b.orders {
+		copy[k] = v
+	}
+	return copy
+}
+
+// PendingByUser returns the user's stop orders still waiting for their
+// trigger, optionally filtered by symbol, oldest first
+func (b *StopBook) PendingByUser(uid int64, symbol string) []*domain.StopOrder {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var list []*domain.StopOrder
+	for _, o := range b.orders {
+		if o.UserID == uid && o.IsPending() && (symbol == "" || o.Symbol == symbol) {
+			list = append(list, o)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// Next returns the first pending stop order of a symbol that a trigger
+// price moving between low and high has reached, with the price that
+// reached it: the nearest stop above before the nearest one below
+func (b *StopBook) Next(symbol string, by domain.TriggerPrice, low, high decimal.Decimal) (*domain.StopOrder, decimal.Decimal, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	q, ok := b.pending[stopKey{symbol, by}]
+	if !ok {
+		return nil, decimal.Zero, false
+	}
+	if len(q.above) > 0 && q.above[0].Reached(high) {
+		return q.above[0], high, true
+	}
+	if len(q.below) > 0 && q.below[0].Reached(low) {
+		return q.below[0], low, true
+	}
+	return nil, decimal.Zero, false
+}
+
+// Trigger marks a pending stop order triggered at price and takes it off
+// its queue
+func (b *StopBook) Trigger(id int64, price decimal.Decimal, at time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	o, ok := b.orders[id]
+	if !ok || !o.IsPending() {
+		return
+	}
+	b.dequeue(o)
+	o.Status = domain.StopTriggered
+	o.TriggerPrice = price
+	o.TriggeredAt = at
+}
+
+// Activate records the order a triggered stop order submitted
+func (b *StopBook) Activate(id, orderID int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	o, ok := b.orders[id]
+	if !ok || o.Status != domain.StopTriggered {
+		return
+	}
+	o.OrderID = orderID
+	o.Status = domain.StopActivated
+}
+
+// Reject records why the order a triggered stop order submitted was
+// rejected
+func (b *StopBook) Reject(id int64, reason string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	o, ok := b.orders[id]
+	if !ok || o.Status != domain.StopTriggered {
+		return
+	}
+	o.RejectReason = reason
+	o.Status = domain.StopRejected
+}
+
+// Cancel ends a pending stop order
+func (b *StopBook) Cancel(id int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	o, ok := b.orders[id]
+	if !ok || !o.IsPending() {
+		return
+	}
+	b.dequeue(o)
+	o.Status = domain.StopCanceled
+}
+
+func (b *StopBook) enqueue(o *domain.StopOrder) {
+	key := stopKey{o.Symbol, o.TriggerBy}
+	q, ok := b.pending[key]
+	if !ok {
+		q = &stopQueue{}
+		b.pending[key] = q
+	}
+
+	if o.TriggerAbove {
+		n := sort.Search(len(q.above), func(i int) bool {
+			return q.above[i].StopPrice > o.StopPrice ||
+				(q.above[i].StopPrice == o.StopPrice && q.above[i].ID > o.ID)
+		})
+		q.above = append(q.above, nil)
+		copy(q.above[n+1:], q.above[n:])
+		q.above[n] = o
+	} else {
+		n := sort.Search(len(q.below), func(i int) bool {
+			return q.below[i].StopPrice < o.StopPrice ||
+				(q.below[i].StopPrice == o.StopPrice && q.below[i].ID > o.ID)
+		})
+		q.below = append(q.below, nil)
+		copy(q.below[n+1:], q.below[n:])
+		q.below[n] = o
+	}
+}
+
+func (b *StopBook) dequeue(o *domain.StopOrder) {
+	key := stopKey{o.Symbol, o.TriggerBy}
+	q, ok := b.pending[key]
+	if !ok {
+		return
+	}
+	remove := func(list []*domain.StopOrder) []*domain.StopOrder {
+		for i, other := range list {
+			if other.ID == o.ID {
+				return append(list[:i], list[i+1:]...)
+			}
+		}
+		return list
+	}
+	q.above = remove(q.above)
+	q.below = remove(q.below)
+	if len(q.above) == 0 && len(q.below) == 0 {
+		delete(b.pending, key)
+	}
+}
@@ -0,0 +1,175 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"oms-contract/internal/domain"
+	"oms-contract/pkg/decimal"
+)
+
+// TradeRetention is how many recent trades are kept in memory per user
+// for history queries; the full trade log lives in the event log
+const TradeRetention = 200
+
+// VolumeWindowDays is how many UTC days of traded notional are kept per
+// user for fee tiers
+const VolumeWindowDays = 30
+
+type TradeBook struct {
+	mu     sync.RWMutex
+	trades map[int64][]*domain.Trade
+	volume map[int64]map[int64]decimal.Decimal // uid -> UTC day -> notional
+	last   map[string]decimal.Decimal          // symbol -> last trade price
+}
+
+// TradeBookState is the serializable form of a TradeBook
+type TradeBookState struct {
+	Trades map[int64][]*domain.Trade           `json:"trades"`
+	Volume map[int64]map[int64]decimal.Decimal `json:"volume"`
+	Last   map[string]decimal.Decimal          `json:"last,omitempty"`
+}
+
+func NewTradeBook() *TradeBook {
+	return &TradeBook{
+		trades: make(map[int64][]*domain.Trade),
+		volume: make(map[int64]map[int64]decimal.Decimal),
+		last:   make(map[string]decimal.Decimal),
+	}
+}
+
+func day(t time.Time) int64 {
+	return t.Unix() / 86400
+}
+
+// Add records a user's fill in its history and daily volume, dropping
+// days that left the volume window, and the symbol's last price. Forced
+// fills stay out of the volume.
+func (b *TradeBook) Add(t *domain.Trade) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.last[t.Symbol] = t.Price
+
+	trades := append(b.trades[t.UserID], t)
+	if len(trades) > TradeRetention {
+		trades = trades[len(trades)-TradeRetention:]
+	}
+	b.trades[t.UserID] = trades
+	if t.Forced {
+		return
+	}
+
+	days := b.volume[t.UserID]
+	if days == nil {
+		days = make(map[int64]decimal.Decimal)
+		b.volume[t.UserID] = days
+	}
+	today := day(t.CreatedAt)
+	days[today] += t.Price.Mul(t.Qty)
+	for d := range days {
+		if d <= today-VolumeWindowDays {
+			delete(days, d)
+		}
+	}
+}
+
+// ByUser returns a user's most recent trades, newest first, optionally
+// filtered by symbol. limit <= 0 returns all retained trades.
+func (b *TradeBook) ByUser(uid int64, symbol string, limit int) []*domain.Trade {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	trades := b.trades[uid]
+	var list []*domain.Trade
+	for i := len(trades) - 1; i >= 0; i-- {
+		if symbol != "" && trades[i].Symbol != symbol {
+			continue
+		}
+		list = append(list, trades[i])
+		if limit > 0 && len(list) == limit {
+			break
+		}
+	}
+	return list
+}
+
+// LastPrices returns the last trade price of every symbol that traded
+func (b *TradeBook) LastPrices() map[string]decimal.Decimal {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	prices := make(map[string]decimal.Decimal, len(b.last))
+	for symbol, price := range b.last {
+		prices[symbol] = price
+	}
+	return prices
+}
+
+// Volume is a user's traded notional over the VolumeWindowDays UTC days
+// ending with the day of now
+func (b *TradeBook) Volume(uid int64, now time.Time) decimal.Decimal {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	today := day(now)
+	var volume decimal.Decimal
+	for d, v := range b.volume[uid] {
+		if d > today-VolumeWindowDays && d <= today {
+			volume += v
+		}
+	}
+	return volume
+}
+
+// State returns a deep copy of the book for snapshots
+func (b *TradeBook) State() *TradeBookState {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	state := &TradeBookState{
+		Trades: make(map[int64][]*domain.Trade, len(b.trades)),
+		Volume: make(map[int64]map[int64]decimal.Decimal, len(b.volume)),
+		Last:   make(map[string]decimal.Decimal, len(b.last)),
+	}
+	for uid, trades := range b.trades {
+		list := make([]*domain.Trade, len(trades))
+		for i, t := range trades {
+			tc := *t
+			list[i] = &tc
+		}
+		state.Trades[uid] = list
+	}
+	for uid, days := range b.volume {
+		state.Volume[uid] = make(map[int64]decimal.Decimal, len(days))
+		for d, v := range days {
+			state.Volume[uid][d] = v
+		}
+	}
+	for symbol, price := range b.last {
+		state.Last[symbol] = price
+	}
+	return state
+}
+
+// Restore replaces the book's contents with a snapshot state
+func (b *TradeBook) Restore(state *TradeBookState) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trades = make(map[int64][]*domain.Trade, len(state.Trades))
+	b.volume = make(map[int64]map[int64]decimal.Decimal, len(state.Volume))
+	b.last = make(map[string]decimal.Decimal, len(state.Last))
+	for uid, trades := range state.Trades {
+		b.trades[uid] = append([]*domain.Trade(nil), trades...)
+	}
+	for uid, days := range state.Volume {
+		b.volume[uid] = make(map[int64]decimal.Decimal, len(days))
+		for d, v := range days {
+			b.volume[uid][d] = v
+		}
+	}
+	for symbol, price := range state.Last {
+		b.last[symbol] = price
+	}
+}
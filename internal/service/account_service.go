@@ -0,0 +1,228 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"oms-contract/internal/domain"
+	"oms-contract/internal/memory"
+	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
+)
+
+// DefaultSettleAsset is used for symbols without an instrument spec
+const DefaultSettleAsset = "USDT"
+
+var (
+	ErrInvalidAmount       = errors.New("amount must be positive")
+	ErrInsufficientBalance = errors.New("insufficient available balance")
+)
+
+// AccountService keeps user balances in a double-entry ledger.
+// Every movement is a pair of postings published as one LEDGER_POSTED event.
+type AccountService struct {
+	mu       sync.Mutex // makes balance checks and the postings they guard atomic
+	book     *memory.AccountBook
+	eventBus *snapshot.EventBus
+}
+
+func NewAccountService(book *memory.AccountBook, eb *snapshot.EventBus) *AccountService {
+	return &AccountService{
+		book:     book,
+		eventBus: eb,
+	}
+}
+
+func (s *AccountService) Get(userID int64, asset string) (*domain.Account, bool) {
+	return s.book.Get(userID, asset)
+}
+
+// List returns all of a user's accounts
+func (s *AccountService) List(userID int64) []*domain.Account {
+	return s.book.GetByUser(userID)
+}
+
+// Journal returns the user's most recent ledger entries, newest first
+func (s *AccountService) Journal(userID int64, limit int) []*domain.JournalEntry {
+	return s.book.Journal(userID, limit)
+}
+
+// SystemBalance returns the balance of a system ledger account
+func (s *AccountService) SystemBalance(bucket domain.Bucket, asset string) decimal.Decimal {
+	return s.book.Balance(domain.SystemAccount(bucket), asset)
+}
+
+func (s *AccountService) Deposit(userID int64, asset string, amount decimal.Decimal) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+	return s.post(entry(domain.JournalDeposit, asset, amount,
+		domain.SystemAccount(domain.BucketExternal), domain.UserAccount(userID, domain.BucketAvailable), 0))
+}
+
+func (s *AccountService) Withdraw(userID int64, asset string, amount decimal.Decimal) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkAvailable(userID, asset, amount); err != nil {
+		return err
+	}
+	return s.post(entry(domain.JournalWithdraw, asset, amount,
+		domain.UserAccount(userID, domain.BucketAvailable), domain.SystemAccount(domain.BucketExternal), 0))
+}
+
+// Freeze moves available balance into the frozen bucket for an order
+func (s *AccountService) Freeze(userID int64, asset string, amount decimal.Decimal, orderID int64) error {
+	if amount <= 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkAvailable(userID, asset, amount); err != nil {
+		return err
+	}
+	return s.post(entry(domain.JournalOrderFreeze, asset, amount,
+		domain.UserAccount(userID, domain.BucketAvailable), domain.UserAccount(userID, domain.BucketFrozen), orderID))
+}
+
+// Unfreeze returns frozen balance of an order to available
+func (s *AccountService) Unfreeze(userID int64, asset string, amount decimal.Decimal, orderID int64) error {
+	if amount <= 0 {
+		return nil
+	}
+	return s.post(entry(domain.JournalOrderUnfreeze, asset, amount,
+		domain.UserAccount(userID, domain.BucketFrozen), domain.UserAccount(userID, domain.BucketAvailable), orderID))
+}
+
+// AdjustMargin moves balance between available and position margin:
+// a positive amount tops margin up and must be available, a negative
+// amount releases it
+func (s *AccountService) AdjustMargin(userID int64, asset string, amount decimal.Decimal) error {
+	available := domain.UserAccount(userID, domain.BucketAvailable)
+	margin := domain.UserAccount(userID, domain.BucketMargin)
+
+	if amount < 0 {
+		return s.post(entry(domain.JournalMarginAdjust, asset, -amount, margin, available, 0))
+	}
+	if amount == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkAvailable(userID, asset, amount); err != nil {
+		return err
+	}
+	return s.post(entry(domain.JournalMarginAdjust, asset, amount, available, margin, 0))
+}
+
+// SettleTrade books one fill: the order's frozen margin released by the
+// fill moves into position margin as far as the position needs it, the rest
+// returns to available, and margin released or PnL realized by the position
+// is credited or debited to available, as is the fee: charged into fee
+// income, or paid out of it when negative.
+func (s *AccountService) SettleTrade(userID int64, asset string, tradeID int64, unfrozen decimal.Decimal, c PositionChange, fee decimal.Decimal) error {
+	available := domain.UserAccount(userID, domain.BucketAvailable)
+	frozen := domain.UserAccount(userID, domain.BucketFrozen)
+	margin := domain.UserAccount(userID, domain.BucketMargin)
+	clearing := domain.SystemAccount(domain.BucketPnLClearing)
+	income := domain.SystemAccount(domain.BucketFeeIncome)
+
+	fromFrozen := decimal.Min(unfrozen, c.MarginLocked)
+
+	var entries []*domain.JournalEntry
+	if fromFrozen > 0 {
+		entries = append(entries, entry(domain.JournalTradeSettle, asset, fromFrozen, frozen, margin, tradeID))
+	}
+	if rest := unfrozen - fromFrozen; rest > 0 {
+		entries = append(entries, entry(domain.JournalOrderUnfreeze, asset, rest, frozen, available, tradeID))
+	}
+	if c.MarginReleased > 0 {
+		entries = append(entries, entry(domain.JournalMarginRelease, asset, c.MarginReleased, margin, available, tradeID))
+	}
+	switch {
+	case c.RealizedPnL > 0:
+		entries = append(entries, entry(domain.JournalRealizedPnL, asset, c.RealizedPnL, clearing, available, tradeID))
+	case c.RealizedPnL < 0:
+		entries = append(entries, entry(domain.JournalRealizedPnL, asset, -c.RealizedPnL, available, clearing, tradeID))
+	}
+	if rest := c.MarginLocked - fromFrozen; rest > 0 {
+		entries = append(entries, entry(domain.JournalTradeSettle, asset, rest, available, margin, tradeID))
+	}
+	switch {
+	case fee > 0:
+		entries = append(entries, entry(domain.JournalFee, asset, fee, available, income, tradeID))
+	case fee < 0:
+		entries = append(entries, entry(domain.JournalFee, asset, -fee, income, available, tradeID))
+	}
+
+	return s.post(entries...)
+}
+
+// SettleFunding books a funding payment against the funding clearing
+// account: a positive amount is received into bucket, a negative one paid
+// out of it. Isolated positions settle in their margin, cross ones in the
+// wallet.
+func (s *AccountService) SettleFunding(userID int64, asset string, amount decimal.Decimal, bucket domain.Bucket, ref int64) error {
+	user := domain.UserAccount(userID, bucket)
+	funding := domain.SystemAccount(domain.BucketFunding)
+
+	switch {
+	case amount > 0:
+		return s.post(entry(domain.JournalFunding, asset, amount, funding, user, ref))
+	case amount < 0:
+		return s.post(entry(domain.JournalFunding, asset, -amount, user, funding, ref))
+	}
+	return nil
+}
+
+func (s *AccountService) checkAvailable(userID int64, asset string, amount decimal.Decimal) error {
+	if avail := s.book.Balance(domain.UserAccount(userID, domain.BucketAvailable), asset); avail < amount {
+		return fmt.Errorf("%w: available=%s required=%s", ErrInsufficientBalance, avail, amount)
+	}
+	return nil
+}
+
+func (s *AccountService) post(entries ...*domain.JournalEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	event := snapshot.NewEvent(
+		0,
+		snapshot.EventLedgerPosted,
+		snapshot.LedgerPostedData{Entries: entries},
+	)
+
+	if s.eventBus != nil {
+		if err := s.eventBus.Publish(event); err != nil {
+			return fmt.Errorf("publish ledger posted event: %w", err)
+		}
+	} else {
+		for _, e := range entries {
+			s.book.Post(e)
+		}
+	}
+	return nil
+}
+
+func entry(t domain.JournalType, asset string, amount decimal.Decimal, debit, credit domain.LedgerAccount, ref int64) *domain.JournalEntry {
+	return &domain.JournalEntry{
+		Type:      t,
+		Asset:     asset,
+		Amount:    amount,
+		Debit:     debit,
+		Credit:    credit,
+		RefID:     ref,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"oms-contract/internal/domain"
+	"oms-contract/internal/engine"
+	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
+
+	"github.com/stretchr/testify/require"
+)
+
+// ledgerSum adds every user and system balance of an asset; double entry keeps it at zero
+func ledgerSum(state *snapshot.SystemState, asset string) decimal.Decimal {
+	s := state.AccountBook.State()
+	var sum decimal.Decimal
+	for _, a := range s.Accounts {
+		if a.Asset == asset {
+			sum += a.Total()
+		}
+	}
+	for k, v := range s.SystemBalances {
+		if strings.HasSuffix(k, ":"+asset) {
+			sum += v
+		}
+	}
+	return sum
+}
+
+func TestAccountService_DepositWithdraw(t *testing.T) {
+	svc, state, _ := newTestOrderService(t)
+	accounts := svc.accounts
+
+	require.ErrorIs(t, accounts.Deposit(100, "USDT", decimal.Zero), ErrInvalidAmount)
+	require.NoError(t, accounts.Deposit(100, "USDT", decimal.FromInt(1000)))
+	require.ErrorIs(t, accounts.Withdraw(100, "USDT", decimal.FromInt(1001)), ErrInsufficientBalance)
+	require.NoError(t, accounts.Withdraw(100, "USDT", decimal.FromInt(400)))
+
+	a, ok := accounts.Get(100, "USDT")
+	require.True(t, ok)
+	require.Equal(t, decimal.FromInt(600), a.Available)
+	require.Equal(t, decimal.FromInt(-600), accounts.SystemBalance(domain.BucketExternal, "USDT"))
+	require.True(t, ledgerSum(state, "USDT").IsZero())
+
+	journal := accounts.Journal(100, 10)
+	require.Len(t, journal, 2)
+	require.Equal(t, domain.JournalWithdraw, journal[0].Type)
+	require.Equal(t, domain.JournalDeposit, journal[1].Type)
+}
+
+func TestAccountService_TradeSettlement(t *testing.T) {
+	svc, state, store := newTestOrderService(t)
+	svc.matching = &engineGateway{engine: engine.NewMatchingEngine()}
+
+	for _, uid := range []int64{100, 200} {
+		require.NoError(t, svc.accounts.Deposit(uid, "USDT", decimal.FromInt(10000)))
+	}
+
+	place := func(user int64, side domain.Side, price int64) {
+		_, err := svc.CreateOrder(&domain.Order{UserID: user, Symbol: "BTCUSDT", Side: side,
+			Type: domain.Limit, Price: decimal.FromInt(price), Quantity: decimal.FromInt(1)})
+		require.NoError(t, err)
+	}
+
+	// 100 goes long 1 @ 30000 against 200, then closes @ 31000
+	place(200, domain.Sell, 30000)
+	place(100, domain.Buy, 30000)
+
+	a, _ := svc.accounts.Get(100, "USDT")
+	require.Equal(t, decimal.FromInt(3000), a.Margin)
+	require.Equal(t, decimal.FromInt(7000), a.Available)
+
+	place(200, domain.Buy, 31000)
+	place(100, domain.Sell, 31000)
+
+	a, _ = svc.accounts.Get(100, "USDT")
+	require.True(t, a.Margin.IsZero())
+	require.Equal(t, decimal.FromInt(11000), a.Available)
+
+	b, _ := svc.accounts.Get(200, "USDT")
+	require.Equal(t, decimal.FromInt(9000), b.Available)
+	require.True(t, ledgerSum(state, "USDT").IsZero())
+
+	// Balances and journal survive replay
+	snapMgr, err := snapshot.NewSnapshotManager(t.TempDir(), 1)
+	require.NoError(t, err)
+	replayed, err := snapshot.NewReplayEngine(store, snapMgr).Replay()
+	require.NoError(t, err)
+	require.Equal(t, state.AccountBook.State(), replayed.AccountBook.State())
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"oms-contract/internal/domain"
+	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
+	"oms-contract/pkg/idgen"
+)
+
+// ADLQuantiles is the number of buckets the ADL indicator ranks positions into
+const ADLQuantiles = 5
+
+// ADLService auto-deleverages: what a liquidation could not close in the
+// market, once the insurance fund is exhausted, is closed against the
+// opposite side's profitable positions at the bankruptcy price. They are
+// taken in order of PnL% × leverage, the most profitable and most levered
+// first.
+type ADLService struct {
+	positions *PositionService
+	eventBus  *snapshot.EventBus
+	idGen     *idgen.Generator
+
+	mu        sync.Mutex
+	listeners []func(*domain.ADLRecord)
+}
+
+func NewADLService(positions *PositionService, eb *snapshot.EventBus, idGen *idgen.Generator) *ADLService {
+	return &ADLService{
+		positions: positions,
+		eventBus:  eb,
+		idGen:     idGen,
+	}
+}
+
+// Subscribe registers fn to run for every position ADL closes. It runs
+// with the order flow locked and must not call back into OrderService.
+func (s *ADLService) Subscribe(fn func(*domain.ADLRecord)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, fn)
+}
+
+// Score ranks a position for ADL: its PnL as a fraction of its margin
+// times its leverage. Only profitable positions score above zero.
+func (s *ADLService) Score(p *domain.Position, mark decimal.Decimal) decimal.Decimal {
+	if p.Qty.IsZero() || p.Margin <= 0 {
+		return decimal.Zero
+	}
+	upnl := (mark - p.EntryPrice).Mul(p.Qty)
+	if upnl <= 0 {
+		return decimal.Zero
+	}
+	return upnl.Div(p.Margin).Mul(p.Leverage)
+}
+
+// Queue returns the profitable positions of a symbol held in direction
+// (+1 long, -1 short), highest score first, ties broken by user and side
+func (s *ADLService) Queue(symbol string, direction int, mark decimal.Decimal) []*domain.Position {
+	score := make(map[*domain.Position]decimal.Decimal)
+	var queue []*domain.Position
+	for _, p := range s.positions.AllBySymbol(symbol) {
+		if p.Qty.Sign() != direction {
+			continue
+		}
+		if sc := s.Score(p, mark); sc > 0 {
+			score[p] = sc
+			queue = append(queue, p)
+		}
+	}
+
+	sort.SliceStable(queue, func(i, j int) bool {
+		a, b := queue[i], queue[j]
+		if score[a] != score[b] {
+			return score[a] > score[b]
+		}
+		if a.UserID != b.UserID {
+			return a.UserID < b.UserID
+		}
+		return a.Side < b.Side
+	})
+	return queue
+}
+
+// Quantile is a position's ADL indicator: ADLQuantiles for the top of its
+// side's queue down to 1 for the bottom, 0 when it is not in the queue
+func (s *ADLService) Quantile(p *domain.Position, mark decimal.Decimal) int {
+	queue := s.Queue(p.Symbol, p.Qty.Sign(), mark)
+	for i, q := range queue {
+		if q.UserID == p.UserID && q.Side == p.Side {
+			return ADLQuantiles - i*ADLQuantiles/len(queue)
+		}
+	}
+	return 0
+}
+
+// Deleverage closes qty of the bankrupt position p at its bankruptcy
+// price against the queue on the other side, handing each pair of trades
+// to apply, and returns the quantity no counterparty was left to take
+func (s *ADLService) Deleverage(
+	p *domain.Position,
+	qty decimal.Decimal,
+	bankruptcy decimal.Decimal,
+	mark decimal.Decimal,
+	apply func(*domain.Trade),
+) decimal.Decimal {
+
+	bankrupt := *p // apply moves the booked position
+	remaining := qty
+	closeSide, counterSide := domain.Sell, domain.Buy
+	if bankrupt.Qty < 0 {
+		closeSide, counterSide = domain.Buy, domain.Sell
+	}
+
+	for _, c := range s.Queue(bankrupt.Symbol, -bankrupt.Qty.Sign(), mark) {
+		if remaining.IsZero() {
+			break
+		}
+		if c.UserID == bankrupt.UserID {
+			continue
+		}
+		counter := *c
+		fill := decimal.Min(remaining, counter.Qty.Abs())
+		tradeID := s.idGen.Next()
+
+		apply(&domain.Trade{TradeID: tradeID, UserID: bankrupt.UserID, Symbol: bankrupt.Symbol,
+			Side: closeSide, Qty: fill, Price: bankruptcy, PositionSide: bankrupt.Side})
+		apply(&domain.Trade{TradeID: tradeID, UserID: counter.UserID, Symbol: counter.Symbol,
+			Side: counterSide, Qty: fill, Price: bankruptcy, PositionSide: counter.Side, IsMaker: true})
+		remaining -= fill
+
+		s.record(&domain.ADLRecord{
+			TradeID:        tradeID,
+			Symbol:         bankrupt.Symbol,
+			Price:          bankruptcy,
+			Qty:            fill,
+			UserID:         counter.UserID,
+			PositionSide:   counter.Side,
+			Score:          s.Score(&counter, mark),
+			BankruptUserID: bankrupt.UserID,
+			BankruptSide:   bankrupt.Side,
+			CreatedAt:      time.Now(),
+		})
+	}
+	return remaining
+}
+
+func (s *ADLService) record(r *domain.ADLRecord) {
+	fmt.Printf("[OMS] ADL: user=%d %s %s reduced %s @ %s against bankrupt user=%d\n",
+		r.UserID, r.Symbol, r.PositionSide, r.Qty, r.Price, r.BankruptUserID)
+
+	event := snapshot.NewEvent(
+		0,
+		snapshot.EventADLExecuted,
+		snapshot.ADLExecutedData{ADL: r},
+	)
+	if s.eventBus != nil {
+		if err := s.eventBus.Publish(event); err != nil {
+			fmt.Printf("[OMS] failed to publish ADL executed event: %v\n", err)
+		}
+	}
+
+	s.mu.Lock()
+	listeners := s.listeners
+	s.mu.Unlock()
+	for _, fn := range listeners {
+		fn(r)
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"oms-contract/internal/domain"
+	"oms-contract/internal/engine"
+	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestADLService_Deleverage(t *testing.T) {
+	svc, state, store := newTestOrderService(t)
+	svc.matching = &engineGateway{engine: engine.NewMatchingEngine()}
+	svc.liquidator.matching = svc.matching
+	fund(t, svc, 100000, 100, 200, 300, 500)
+
+	var records []*domain.ADLRecord
+	svc.SubscribeADL(func(r *domain.ADLRecord) { records = append(records, r) })
+
+	place := func(user int64, side domain.Side, qty string) {
+		_, err := svc.CreateOrder(&domain.Order{UserID: user, Symbol: "BTCUSDT", Side: side, Type: domain.Limit,
+			Price: decimal.FromInt(30000), Quantity: decimal.MustParse(qty)})
+		require.NoError(t, err)
+	}
+	qty := func(user int64) decimal.Decimal {
+		p, _ := state.PositionBook.Get(user, "BTCUSDT")
+		return p.Qty
+	}
+	for user, leverage := range map[int64]int64{300: 5, 500: 1} {
+		_, err := svc.SetLeverage(user, "BTCUSDT", decimal.FromInt(leverage))
+		require.NoError(t, err)
+	}
+	_, err := svc.marks.Update("BTCUSDT", decimal.FromInt(30000))
+	require.NoError(t, err)
+
+	// 100 goes long 1 @ 30000 at 10x against two shorts: 200 with 0.6 at
+	// 10x and 300 with 0.8 at 5x
+	place(200, domain.Sell, "0.6")
+	place(300, domain.Sell, "0.8")
+	place(100, domain.Buy, "1")
+	place(500, domain.Buy, "0.4")
+
+	// At the same PnL% the more levered short is deleveraged first
+	mark := decimal.FromInt(27000)
+	queue := svc.adl.Queue("BTCUSDT", -1, mark)
+	require.Len(t, queue, 2)
+	require.Equal(t, int64(200), queue[0].UserID)
+	require.Equal(t, int64(300), queue[1].UserID)
+	require.Equal(t, 5, svc.adl.Quantile(queue[0], mark))
+	require.Equal(t, 3, svc.adl.Quantile(queue[1], mark))
+	p, _ := state.PositionBook.Get(500, "BTCUSDT")
+	require.Equal(t, 0, svc.adl.Quantile(p, mark))
+
+	// The book has no bids and the insurance fund is empty: the mark
+	// update liquidates 100 by closing it against the shorts at 27000
+	_, err = svc.marks.Update("BTCUSDT", decimal.FromInt(26800))
+	require.NoError(t, err)
+
+	require.True(t, qty(100).IsZero())
+	p, _ = state.PositionBook.Get(100, "BTCUSDT")
+	require.Equal(t, domain.PositionADL, p.State)
+	require.True(t, qty(200).IsZero())
+	require.Equal(t, decimal.MustParse("-0.4"), qty(300))
+	require.Len(t, records, 2)
+	require.Equal(t, int64(200), records[0].UserID)
+	require.Equal(t, decimal.MustParse("0.6"), records[0].Qty)
+	require.Equal(t, int64(300), records[1].UserID)
+	require.Equal(t, decimal.MustParse("0.4"), records[1].Qty)
+	require.Equal(t, decimal.FromInt(27000), records[1].Price)
+
+	// The bankrupt user loses its margin, the shorts realize their PnL at
+	// the bankruptcy price
+	total := func(user int64) decimal.Decimal {
+		a, _ := svc.accounts.Get(user, "USDT")
+		return a.Total()
+	}
+	require.Equal(t, decimal.FromInt(97000), total(100))
+	require.Equal(t, decimal.FromInt(101800), total(200))
+	require.Equal(t, decimal.FromInt(101200), total(300))
+
+	p, _ = state.PositionBook.Get(300, "BTCUSDT")
+	require.Equal(t, 5, svc.ADLQuantile(p))
+
+	snapMgr, err := snapshot.NewSnapshotManager(t.TempDir(), 1)
+	require.NoError(t, err)
+	replayed, err := snapshot.NewReplayEngine(store, snapMgr).Replay()
+	require.NoError(t, err)
+	require.Equal(t, state.AccountBook.State(), replayed.AccountBook.State())
+	// Deleveraged on either side, no one trades volume for it
+	for user, volume := range map[int64]int64{100: 30000, 200: 18000, 300: 24000} {
+		require.Equal(t, decimal.FromInt(volume), replayed.TradeBook.Volume(user, time.Now()), "user %d", user)
+	}
+	replayedPos, _ := replayed.PositionBook.Get(300, "BTCUSDT")
+	require.Equal(t, decimal.MustParse("-0.4"), replayedPos.Qty)
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"oms-contract/internal/domain"
+	"oms-contract/internal/memory"
+	"oms-contract/pkg/decimal"
+)
+
+var ErrInvalidFeeTiers = errors.New("invalid fee tiers")
+
+// DefaultFeeTiers is the VIP schedule until one is loaded
+var DefaultFeeTiers = []domain.FeeTier{
+	{MinVolume: decimal.Zero, MakerFeeRate: decimal.New(2, -4), TakerFeeRate: decimal.New(5, -4)},
+	{MinVolume: decimal.FromInt(15000000), MakerFeeRate: decimal.New(16, -5), TakerFeeRate: decimal.New(4, -4)},
+	{MinVolume: decimal.FromInt(50000000), MakerFeeRate: decimal.New(14, -5), TakerFeeRate: decimal.New(35, -5)},
+	{MinVolume: decimal.FromInt(100000000), MakerFeeRate: decimal.New(12, -5), TakerFeeRate: decimal.New(32, -5)},
+	{MinVolume: decimal.FromInt(600000000), MakerFeeRate: decimal.New(1, -4), TakerFeeRate: decimal.New(3, -4)},
+	{MinVolume: decimal.FromInt(1000000000), MakerFeeRate: decimal.Zero, TakerFeeRate: decimal.New(25, -5)},
+	{MinVolume: decimal.FromInt(5000000000), MakerFeeRate: decimal.New(-5, -5), TakerFeeRate: decimal.New(2, -4)},
+}
+
+// FeeService prices trading fees. A user's VIP level follows its traded
+// notional over the last 30 UTC days; each rate is the lower of the VIP
+// level's and the instrument's own, so an instrument can be discounted
+// below the schedule but never charged above it; one with neither rate set
+// follows the schedule. A negative maker rate is a rebate paid out of fee
+// income.
+type FeeService struct {
+	trades      *memory.TradeBook
+	instruments *InstrumentService
+	accounts    *AccountService
+
+	mu    sync.RWMutex
+	tiers []domain.FeeTier
+}
+
+func NewFeeService(trades *memory.TradeBook, instruments *InstrumentService, accounts *AccountService) *FeeService {
+	return &FeeService{
+		trades:      trades,
+		instruments: instruments,
+		accounts:    accounts,
+		tiers:       DefaultFeeTiers,
+	}
+}
+
+// Tiers returns the VIP schedule, level 0 first
+func (s *FeeService) Tiers() []domain.FeeTier {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tiers
+}
+
+// SetTiers replaces the VIP schedule. Thresholds must start at zero and
+// rise, and no maker rebate may exceed its level's taker fee.
+func (s *FeeService) SetTiers(tiers []domain.FeeTier) error {
+	for n, t := range tiers {
+		switch {
+		case n == 0 && !t.MinVolume.IsZero():
+			return fmt.Errorf("%w: level 0 must start at zero volume", ErrInvalidFeeTiers)
+		case n > 0 && t.MinVolume <= tiers[n-1].MinVolume:
+			return fmt.Errorf("%w: level %d volume must rise", ErrInvalidFeeTiers, n)
+		case t.TakerFeeRate < 0 || t.TakerFeeRate >= decimal.One || t.MakerFeeRate >= decimal.One ||
+			t.MakerFeeRate+t.TakerFeeRate < 0:
+			return fmt.Errorf("%w: level %d requires 0 <= taker fee < 1 and -taker fee <= maker fee < 1", ErrInvalidFeeTiers, n)
+		}
+	}
+	if len(tiers) == 0 {
+		return fmt.Errorf("%w: empty schedule", ErrInvalidFeeTiers)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tiers = append([]domain.FeeTier(nil), tiers...)
+	return nil
+}
+
+// LoadFile replaces the VIP schedule with a JSON array config file
+func (s *FeeService) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var tiers []domain.FeeTier
+	if err := json.Unmarshal(data, &tiers); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	return s.SetTiers(tiers)
+}
+
+// Volume is a user's traded notional over the 30 UTC days ending with now
+func (s *FeeService) Volume(userID int64, now time.Time) decimal.Decimal {
+	return s.trades.Volume(userID, now)
+}
+
+// Level returns a user's VIP level and its rates at time now
+func (s *FeeService) Level(userID int64, now time.Time) (int, domain.FeeTier) {
+	volume := s.Volume(userID, now)
+	tiers := s.Tiers()
+
+	level := 0
+	for n, t := range tiers {
+		if volume >= t.MinVolume {
+			level = n
+		}
+	}
+	return level, tiers[level]
+}
+
+// Rates returns the maker and taker rates a user pays on a symbol now
+func (s *FeeService) Rates(userID int64, symbol string, now time.Time) (maker, taker decimal.Decimal) {
+	_, tier := s.Level(userID, now)
+	maker, taker = tier.MakerFeeRate, tier.TakerFeeRate
+	if s.instruments != nil {
+		// An instrument without rates of its own sets no cap
+		if inst, ok := s.instruments.Get(symbol); ok && (inst.MakerFeeRate != 0 || inst.TakerFeeRate != 0) {
+			maker = decimal.Min(maker, inst.MakerFeeRate)
+			taker = decimal.Min(taker, inst.TakerFeeRate)
+		}
+	}
+	return maker, taker
+}
+
+// Fee is what a fill costs its user: notional × the maker or taker rate,
+// negative for a maker rebate
+func (s *FeeService) Fee(t *domain.Trade) decimal.Decimal {
+	maker, taker := s.Rates(t.UserID, t.Symbol, t.CreatedAt)
+	rate := taker
+	if t.IsMaker {
+		rate = maker
+	}
+	return t.Price.Mul(t.Qty).Mul(rate)
+}
+
+// Trades returns a user's most recent trades with their fees, newest first
+func (s *FeeService) Trades(userID int64, symbol string, limit int) []*domain.Trade {
+	return s.trades.ByUser(userID, symbol, limit)
+}
+
+// Income is the fee revenue of a settlement asset net of maker rebates
+func (s *FeeService) Income(asset string) decimal.Decimal {
+	if s.accounts == nil {
+		return decimal.Zero
+	}
+	return s.accounts.SystemBalance(domain.BucketFeeIncome, asset)
+}
+
+// IncomeHistory returns the most recent fee income movements of an asset, newest first
+func (s *FeeService) IncomeHistory(asset string, limit int) []*domain.JournalEntry {
+	if s.accounts == nil {
+		return nil
+	}
+	return s.accounts.book.SystemJournal(domain.BucketFeeIncome, asset, limit)
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"oms-contract/internal/domain"
+	"oms-contract/internal/engine"
+	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeService_MakerTaker(t *testing.T) {
+	svc, state, store := newTestOrderService(t)
+	svc.matching = &engineGateway{engine: engine.NewMatchingEngine()}
+	fund(t, svc, 100000, 100, 200)
+
+	inst := testInstrument("BTCUSDT")
+	inst.MakerFeeRate, inst.TakerFeeRate = decimal.MustParse("-0.001"), decimal.MustParse("0.0005")
+	require.ErrorIs(t, svc.instruments.Upsert(inst), ErrInvalidInstrument) // rebate above the taker fee
+	inst.MakerFeeRate = decimal.MustParse("0.0002")
+	require.NoError(t, svc.instruments.Upsert(inst))
+
+	require.ErrorIs(t, svc.fees.SetTiers([]domain.FeeTier{
+		{MakerFeeRate: decimal.MustParse("-0.001"), TakerFeeRate: decimal.MustParse("0.0005")},
+	}), ErrInvalidFeeTiers)
+	require.NoError(t, svc.fees.SetTiers([]domain.FeeTier{
+		{MakerFeeRate: decimal.MustParse("0.0002"), TakerFeeRate: decimal.MustParse("0.0005")},
+		{MinVolume: decimal.FromInt(50000), MakerFeeRate: decimal.MustParse("-0.0001"), TakerFeeRate: decimal.MustParse("0.0003")},
+	}))
+
+	// 200 rests the sell, 100 takes it
+	trade := func(qty string) {
+		for _, o := range []*domain.Order{
+			{UserID: 200, Side: domain.Sell},
+			{UserID: 100, Side: domain.Buy},
+		} {
+			o.Symbol, o.Type, o.Price, o.Quantity = "BTCUSDT", domain.Limit, decimal.FromInt(30000), decimal.MustParse(qty)
+			_, err := svc.CreateOrder(o)
+			require.NoError(t, err)
+		}
+	}
+	trade("1")
+	trade("1")
+
+	// 60k of volume lifts both to VIP 1: the maker earns a rebate
+	now := time.Now()
+	level, _ := svc.fees.Level(200, now)
+	require.Equal(t, 1, level)
+	require.Equal(t, decimal.FromInt(60000), svc.fees.Volume(200, now))
+	trade("0.5")
+
+	trades := svc.fees.Trades(200, "BTCUSDT", 10)
+	require.Len(t, trades, 3)
+	require.Equal(t, decimal.MustParse("-1.5"), trades[0].Fee)
+	require.Equal(t, "USDT", trades[0].FeeAsset)
+	require.Equal(t, decimal.FromInt(6), trades[1].Fee)
+	require.Equal(t, decimal.MustParse("4.5"), svc.fees.Trades(100, "", 1)[0].Fee)
+
+	// Taker 15 + 15 + 4.5, maker 6 + 6 - 1.5
+	require.Equal(t, decimal.FromInt(45), svc.fees.Income("USDT"))
+	history := svc.fees.IncomeHistory("USDT", 10)
+	require.Len(t, history, 6)
+	require.Equal(t, domain.JournalFee, history[0].Type)
+	require.True(t, ledgerSum(state, "USDT").IsZero())
+
+	snapMgr, err := snapshot.NewSnapshotManager(t.TempDir(), 1)
+	require.NoError(t, err)
+	replayed, err := snapshot.NewReplayEngine(store, snapMgr).Replay()
+	require.NoError(t, err)
+	require.Equal(t, state.AccountBook.State(), replayed.AccountBook.State())
+	require.Equal(t, decimal.FromInt(75000), replayed.TradeBook.Volume(200, now))
+	require.Equal(t, decimal.MustParse("-1.5"), replayed.TradeBook.ByUser(200, "", 1)[0].Fee)
+}
+
+func TestFeeService_InstrumentWithoutRates(t *testing.T) {
+	svc, _, _ := newTestOrderService(t)
+	require.NoError(t, svc.fees.SetTiers(DefaultFeeTiers))
+
+	// testInstrument sets no fee rates: the VIP schedule applies as is
+	maker, taker := svc.fees.Rates(100, "BTCUSDT", time.Now())
+	require.Equal(t, decimal.MustParse("0.0002"), maker)
+	require.Equal(t, decimal.MustParse("0.0005"), taker)
+	fee := svc.fees.Fee(&domain.Trade{UserID: 100, Symbol: "BTCUSDT", Price: decimal.FromInt(30000),
+		Qty: decimal.One, CreatedAt: time.Now()})
+	require.Equal(t, decimal.FromInt(15), fee)
+
+	// A zero maker rate next to a taker rate is a real override
+	inst := testInstrument("BTCUSDT")
+	inst.TakerFeeRate = decimal.MustParse("0.0004")
+	require.NoError(t, svc.instruments.Upsert(inst))
+	maker, taker = svc.fees.Rates(100, "BTCUSDT", time.Now())
+	require.True(t, maker.IsZero())
+	require.Equal(t, decimal.MustParse("0.0004"), taker)
+}
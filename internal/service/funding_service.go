@@ -0,0 +1,220 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"oms-contract/internal/domain"
+	"oms-contract/internal/memory"
+	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
+	"oms-contract/pkg/idgen"
+)
+
+var (
+	// FundingInterval is the time between funding settlements, aligned to 00:00 UTC
+	FundingInterval = 8 * time.Hour
+	// FundingInterestRate is the interest component of every funding interval
+	FundingInterestRate = decimal.New(1, -4) // 0.01%
+	// FundingClamp bounds how far the interest component moves the rate off the premium index
+	FundingClamp = decimal.New(5, -4) // 0.05%
+	// DefaultFundingRateCap caps the rate of instruments without their own cap
+	DefaultFundingRateCap = decimal.New(75, -4) // 0.75%
+)
+
+// FundingService ties perpetuals to the index. The premium
+// (mark - index) / index is sampled on a fixed clock of
+// memory.PremiumSampleInterval, each tick taking the mark in force; at each
+// funding time the average sample becomes the premium index, and
+//
+//	rate = premium + clamp(interest - premium, ±FundingClamp)
+//
+// capped at the instrument's funding rate cap. Positions then pay
+// |qty| × mark × rate through the ledger's funding account, longs to shorts
+// when the rate is positive. Samples are kept in the funding book with the
+// settled rates, so replaying the mark price events rebuilds them.
+type FundingService struct {
+	book        *memory.FundingBook
+	instruments *InstrumentService
+	positions   *PositionService
+	accounts    *AccountService
+	marks       *MarkPriceService
+	eventBus    *snapshot.EventBus
+	idGen       *idgen.Generator
+}
+
+func NewFundingService(
+	book *memory.FundingBook,
+	instruments *InstrumentService,
+	positions *PositionService,
+	accounts *AccountService,
+	marks *MarkPriceService,
+	eb *snapshot.EventBus,
+	idGen *idgen.Generator,
+) *FundingService {
+	s := &FundingService{
+		book:        book,
+		instruments: instruments,
+		positions:   positions,
+		accounts:    accounts,
+		marks:       marks,
+		eventBus:    eb,
+		idGen:       idGen,
+	}
+	// With an event bus the mark price events sample the premium
+	if marks != nil && eb == nil {
+		marks.Subscribe(s.sample)
+	}
+	return s
+}
+
+func (s *FundingService) sample(m *domain.MarkPrice) {
+	if m.IndexPrice > 0 {
+		s.book.Sample(m.Symbol, m.Premium(), m.UpdatedAt)
+	}
+}
+
+// PremiumIndex is the average premium sampled from the last settlement up
+// to at, the current mark's premium when nothing was sampled yet
+func (s *FundingService) PremiumIndex(symbol string, at time.Time) decimal.Decimal {
+	if premium, ok := s.book.PremiumIndex(symbol, at); ok {
+		return premium
+	}
+	if s.marks != nil {
+		if m, ok := s.marks.Get(symbol); ok {
+			return m.Premium()
+		}
+	}
+	return decimal.Zero
+}
+
+// Predicted is the rate the symbol would settle at if funding were at at
+func (s *FundingService) Predicted(symbol string, at time.Time) decimal.Decimal {
+	return s.rate(symbol, s.PremiumIndex(symbol, at))
+}
+
+// Last returns the symbol's most recent settlement
+func (s *FundingService) Last(symbol string) (*domain.FundingRate, bool) {
+	return s.book.Get(symbol)
+}
+
+// NextFundingTime is the first funding time after t
+func (s *FundingService) NextFundingTime(t time.Time) time.Time {
+	return t.UTC().Truncate(FundingInterval).Add(FundingInterval)
+}
+
+func (s *FundingService) rate(symbol string, premium decimal.Decimal) decimal.Decimal {
+	limit := DefaultFundingRateCap
+	if s.instruments != nil {
+		if inst, ok := s.instruments.Get(symbol); ok && inst.FundingRateCap > 0 {
+			limit = inst.FundingRateCap
+		}
+	}
+	interest := decimal.Max(-FundingClamp, decimal.Min(FundingClamp, FundingInterestRate-premium))
+	return decimal.Max(-limit, decimal.Min(limit, premium+interest))
+}
+
+// Settle closes the funding interval of a symbol at funding time at: it
+// fixes the rate, publishes it with every position's payment and books the
+// payments. An isolated position pays out of its margin, never more than
+// it holds; the funding account absorbs the rest. Payments are returned so
+// the caller can re-check the payers for liquidation. Settle must run with
+// the order flow locked.
+func (s *FundingService) Settle(symbol string, at time.Time) (*domain.FundingRate, []*domain.FundingPayment, error) {
+	if s.marks == nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrNoIndexPrice, symbol)
+	}
+	mark, ok := s.marks.Price(symbol)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrNoIndexPrice, symbol)
+	}
+
+	premium := s.PremiumIndex(symbol, at)
+
+	rate := &domain.FundingRate{
+		ID:           s.idGen.Next(),
+		Symbol:       symbol,
+		Rate:         s.rate(symbol, premium),
+		PremiumIndex: premium,
+		MarkPrice:    mark,
+		FundingTime:  at,
+	}
+
+	positions := s.positions.AllBySymbol(symbol)
+	sort.Slice(positions, func(i, j int) bool {
+		a, b := positions[i], positions[j]
+		if a.UserID != b.UserID {
+			return a.UserID < b.UserID
+		}
+		return a.Side < b.Side
+	})
+
+	var payments []*domain.FundingPayment
+	for _, p := range positions {
+		if p.Qty.IsZero() {
+			continue
+		}
+		amount := -p.Qty.Mul(mark).Mul(rate.Rate)
+		if s.positions.MarginMode(p.UserID) != domain.CrossMargin {
+			amount = decimal.Max(amount, -p.Margin)
+		}
+		if amount.IsZero() {
+			continue
+		}
+		payments = append(payments, &domain.FundingPayment{
+			UserID:       p.UserID,
+			Symbol:       symbol,
+			PositionSide: p.Side,
+			Qty:          p.Qty,
+			Amount:       amount,
+		})
+	}
+
+	event := snapshot.NewEvent(
+		0,
+		snapshot.EventFundingSettled,
+		snapshot.FundingSettledData{Rate: rate, Payments: payments},
+	)
+	if s.eventBus != nil {
+		if err := s.eventBus.Publish(event); err != nil {
+			return nil, nil, fmt.Errorf("publish funding settled event: %w", err)
+		}
+	} else {
+		s.book.Settle(rate)
+	}
+
+	asset := DefaultSettleAsset
+	if s.instruments != nil {
+		if inst, ok := s.instruments.Get(symbol); ok {
+			asset = inst.SettleAsset
+		}
+	}
+	for _, pay := range payments {
+		if err := s.pay(pay, asset, rate.ID); err != nil {
+			return rate, payments, err
+		}
+	}
+
+	fmt.Printf("[OMS] funding settled: %s rate=%s premium=%s mark=%s positions=%d\n",
+		symbol, rate.Rate, premium, mark, len(payments))
+	return rate, payments, nil
+}
+
+// pay books one payment: to the position margin and the margin bucket for
+// an isolated position, to the wallet for a cross one
+func (s *FundingService) pay(pay *domain.FundingPayment, asset string, ref int64) error {
+	bucket := domain.BucketAvailable
+	if s.positions.MarginMode(pay.UserID) != domain.CrossMargin {
+		p, ok := s.positions.GetSide(pay.UserID, pay.Symbol, pay.PositionSide)
+		if !ok {
+			return nil
+		}
+		s.positions.AddMargin(p, pay.Amount, "FUNDING")
+		bucket = domain.BucketMargin
+	}
+	if s.accounts == nil {
+		return nil
+	}
+	return s.accounts.SettleFunding(pay.UserID, asset, pay.Amount, bucket, ref)
+}
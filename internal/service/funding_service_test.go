@@ -0,0 +1,101 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"oms-contract/internal/domain"
+	"oms-contract/internal/engine"
+	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
+
+	"github.com/stretchr/testify/require"
+)
+
+// markAt publishes a BTCUSDT mark over an index of 30000 as if updated at
+// at, which drives the funding sample clock
+func markAt(t *testing.T, svc *OrderService, price int64, at time.Time) {
+	t.Helper()
+	m := &domain.MarkPrice{Symbol: "BTCUSDT", IndexPrice: decimal.FromInt(30000),
+		Price: decimal.FromInt(price), UpdatedAt: at}
+	require.NoError(t, svc.eventBus.Publish(snapshot.NewEvent(0, snapshot.EventMarkPriceUpdated,
+		snapshot.MarkPriceUpdatedData{MarkPrice: m})))
+}
+
+func TestFundingService_Settle(t *testing.T) {
+	svc, state, store := newTestOrderService(t)
+	svc.matching = &engineGateway{engine: engine.NewMatchingEngine()}
+	fund(t, svc, 100000, 100, 200, 300)
+	require.NoError(t, svc.SetMarginMode(300, domain.CrossMargin))
+	at := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	start := at.Add(-FundingInterval)
+	markAt(t, svc, 30000, start)
+
+	// 100 goes long 1 @ 30000 at 10x against isolated 200 and cross 300
+	for _, o := range []*domain.Order{
+		{UserID: 200, Side: domain.Sell, Quantity: decimal.MustParse("0.5")},
+		{UserID: 300, Side: domain.Sell, Quantity: decimal.MustParse("0.5")},
+		{UserID: 100, Side: domain.Buy, Quantity: decimal.FromInt(1)},
+	} {
+		o.Symbol, o.Type, o.Price = "BTCUSDT", domain.Limit, decimal.FromInt(30000)
+		_, err := svc.CreateOrder(o)
+		require.NoError(t, err)
+	}
+
+	// Premiums 0 for 2h, 0.2% for 4h and 0 for 2h weigh in at 0.1%, not at
+	// the 0.067% of the three updates; the interest component is clamped
+	// to -0.05%
+	markAt(t, svc, 30060, start.Add(2*time.Hour))
+	markAt(t, svc, 30000, start.Add(6*time.Hour))
+	require.Equal(t, decimal.MustParse("0.001"), svc.funding.PremiumIndex("BTCUSDT", at))
+	require.Equal(t, decimal.MustParse("0.0005"), svc.funding.Predicted("BTCUSDT", at))
+
+	require.Equal(t, at, svc.funding.NextFundingTime(at.Add(-90*time.Minute)))
+	svc.SettleFunding(at)
+
+	last, ok := svc.funding.Last("BTCUSDT")
+	require.True(t, ok)
+	require.Equal(t, decimal.MustParse("0.0005"), last.Rate)
+	require.Equal(t, at, last.FundingTime)
+
+	// The long pays 30000 × 0.05% = 15: out of its isolated margin, into
+	// 200's isolated margin and 300's wallet
+	margin := func(user int64) decimal.Decimal {
+		p, _ := state.PositionBook.Get(user, "BTCUSDT")
+		return p.Margin
+	}
+	total := func(user int64) decimal.Decimal {
+		a, _ := svc.accounts.Get(user, "USDT")
+		return a.Total()
+	}
+	require.Equal(t, decimal.FromInt(2985), margin(100))
+	require.Equal(t, decimal.MustParse("1507.5"), margin(200))
+	a, _ := svc.accounts.Get(100, "USDT")
+	require.Equal(t, decimal.FromInt(2985), a.Margin)
+	require.Equal(t, decimal.FromInt(99985), total(100))
+	require.Equal(t, decimal.MustParse("100007.5"), total(200))
+	require.Equal(t, decimal.MustParse("100007.5"), total(300))
+	require.True(t, svc.accounts.SystemBalance(domain.BucketFunding, "USDT").IsZero())
+
+	// A new interval starts from the current mark; extreme premiums are capped
+	require.Equal(t, FundingInterestRate, svc.funding.Predicted("BTCUSDT", at))
+	markAt(t, svc, 30600, at.Add(time.Minute))
+	later := at.Add(time.Hour)
+	require.Equal(t, DefaultFundingRateCap, svc.funding.Predicted("BTCUSDT", later))
+
+	snapMgr, err := snapshot.NewSnapshotManager(t.TempDir(), 1)
+	require.NoError(t, err)
+	replayed, err := snapshot.NewReplayEngine(store, snapMgr).Replay()
+	require.NoError(t, err)
+	require.Equal(t, state.AccountBook.State(), replayed.AccountBook.State())
+	replayedRate, ok := replayed.FundingBook.Get("BTCUSDT")
+	require.True(t, ok)
+	require.Equal(t, last.Rate, replayedRate.Rate)
+	// So do the samples of the open interval
+	live, _ := state.FundingBook.PremiumIndex("BTCUSDT", later)
+	premium, ok := replayed.FundingBook.PremiumIndex("BTCUSDT", later)
+	require.True(t, ok)
+	require.Equal(t, live, premium)
+	replayedPos, _ := replayed.PositionBook.Get(100, "BTCUSDT")
+	require.Equal(t, decimal.FromInt(2985), replayedPos.Margin)
+}
@@ -0,0 +1,11 @@
+package service
+
+import "oms-contract/pkg/decimal"
+
+// IndexSource is a feed of index prices, usually the spot price of each
+// symbol's underlying on one external venue. MarkPriceService polls every
+// source and takes the median, so one bad feed cannot move the index.
+type IndexSource interface {
+	// IndexPrices returns the latest price of every symbol the source covers
+	IndexPrices() (map[string]decimal.Decimal, error)
+}
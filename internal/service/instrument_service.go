@@ -0,0 +1,268 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+
+	"oms-contract/internal/domain"
+	"oms-contract/internal/memory"
+	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
+)
+
+var (
+	ErrUnknownSymbol     = errors.New("unknown symbol")
+	ErrSymbolNotTrading  = errors.New("symbol is not trading")
+	ErrInvalidTickSize   = errors.New("price is not a multiple of tick size")
+	ErrInvalidLotSize    = errors.New("quantity is not a multiple of lot size")
+	ErrQuantityTooSmall  = errors.New("quantity below minimum")
+	ErrQuantityTooLarge  = errors.New("quantity above maximum")
+	ErrNotionalTooSmall  = errors.New("notional below minimum")
+	ErrPriceTooHigh      = errors.New("price above maximum")
+	ErrNotionalTooLarge  = errors.New("notional out of range")
+	ErrInvalidInstrument = errors.New("invalid instrument spec")
+)
+
+// InstrumentService is the registry of tradable contracts
+type InstrumentService struct {
+	book     *memory.InstrumentBook
+	eventBus *snapshot.EventBus
+}
+
+func NewInstrumentService(book *memory.InstrumentBook, eb *snapshot.EventBus) *InstrumentService {
+	return &InstrumentService{
+		book:     book,
+		eventBus: eb,
+	}
+}
+
+func (s *InstrumentService) Get(symbol string) (*domain.Instrument, bool) {
+	return s.book.Get(symbol)
+}
+
+// List returns all instruments, including halted and delisted ones
+func (s *InstrumentService) List() []*domain.Instrument {
+	all := s.book.GetAll()
+	list := make([]*domain.Instrument, 0, len(all))
+	for _, i := range all {
+		list = append(list, i)
+	}
+	return list
+}
+
+// Upsert validates and stores an instrument spec.
+// Re-submitting an identical spec is a no-op, so loading the same
+// config file on every start does not grow the event log.
+func (s *InstrumentService) Upsert(inst *domain.Instrument) error {
+	if err := validateInstrument(inst); err != nil {
+		return err
+	}
+	if cur, ok := s.book.Get(inst.Symbol); ok && reflect.DeepEqual(cur, inst) {
+		return nil
+	}
+
+	event := snapshot.NewEvent(
+		0,
+		snapshot.EventInstrumentUpdated,
+		snapshot.InstrumentUpdatedData{Instrument: inst},
+	)
+
+	if s.eventBus != nil {
+		if err := s.eventBus.Publish(event); err != nil {
+			return fmt.Errorf("publish instrument updated event: %w", err)
+		}
+	} else {
+		s.book.Save(inst)
+	}
+
+	fmt.Printf("[OMS] instrument updated: %s status=%s\n", inst.Symbol, inst.Status)
+	return nil
+}
+
+// SetStatus switches trading status without touching the rest of the spec
+func (s *InstrumentService) SetStatus(symbol string, status domain.InstrumentStatus) error {
+	cur, ok := s.book.Get(symbol)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownSymbol, symbol)
+	}
+
+	updated := *cur
+	updated.Status = status
+	return s.Upsert(&updated)
+}
+
+// LoadFile upserts every instrument in a JSON array config file
+func (s *InstrumentService) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var instruments []*domain.Instrument
+	if err := json.Unmarshal(data, &instruments); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	for _, inst := range instruments {
+		if err := s.Upsert(inst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateOrder checks an order against its instrument spec.
+// Market orders carry no price, so tick size and min notional are skipped.
+func (s *InstrumentService) ValidateOrder(o *domain.Order) error {
+	inst, err := s.tradable(o.Symbol)
+	if err != nil {
+		return err
+	}
+
+	if !o.Quantity.IsMultipleOf(inst.LotSize) {
+		return fmt.Errorf("%w: qty=%s lot=%s", ErrInvalidLotSize, o.Quantity, inst.LotSize)
+	}
+	if o.Quantity < inst.MinQty {
+		return fmt.Errorf("%w: qty=%s min=%s", ErrQuantityTooSmall, o.Quantity, inst.MinQty)
+	}
+	if o.Quantity > inst.MaxQty {
+		return fmt.Errorf("%w: qty=%s max=%s", ErrQuantityTooLarge, o.Quantity, inst.MaxQty)
+	}
+
+	if o.Type == domain.Market {
+		return nil
+	}
+
+	if err := validatePrice(inst, o.Price); err != nil {
+		return err
+	}
+	// Client prices are unbounded without a MaxPrice; nothing downstream
+	// may multiply one that overflows here
+	notional, err := o.Price.MulChecked(o.Quantity)
+	if err != nil {
+		return fmt.Errorf("%w: price=%s qty=%s", ErrNotionalTooLarge, o.Price, o.Quantity)
+	}
+	if notional < inst.MinNotional {
+		return fmt.Errorf("%w: notional=%s min=%s", ErrNotionalTooSmall, notional, inst.MinNotional)
+	}
+	return nil
+}
+
+// ValidatePrice is ValidateOrder for an order whose quantity is not known
+// yet: the symbol must be trading and a limit price fit the instrument
+func (s *InstrumentService) ValidatePrice(o *domain.Order) error {
+	inst, err := s.tradable(o.Symbol)
+	if err != nil || o.Type == domain.Market {
+		return err
+	}
+	return validatePrice(inst, o.Price)
+}
+
+func (s *InstrumentService) tradable(symbol string) (*domain.Instrument, error) {
+	inst, ok := s.book.Get(symbol)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSymbol, symbol)
+	}
+	if inst.Status != domain.InstrumentTrading {
+		return nil, fmt.Errorf("%w: %s is %s", ErrSymbolNotTrading, symbol, inst.Status)
+	}
+	return inst, nil
+}
+
+func validatePrice(inst *domain.Instrument, price decimal.Decimal) error {
+	if price <= 0 || !price.IsMultipleOf(inst.TickSize) {
+		return fmt.Errorf("%w: price=%s tick=%s", ErrInvalidTickSize, price, inst.TickSize)
+	}
+	if inst.MaxPrice > 0 && price > inst.MaxPrice {
+		return fmt.Errorf("%w: price=%s max=%s", ErrPriceTooHigh, price, inst.MaxPrice)
+	}
+	return nil
+}
+
+func validateInstrument(i *domain.Instrument) error {
+	switch {
+	case i.Symbol == "":
+		return fmt.Errorf("%w: empty symbol", ErrInvalidInstrument)
+	case i.TickSize <= 0 || i.LotSize <= 0:
+		return fmt.Errorf("%w: %s tick and lot size must be positive", ErrInvalidInstrument, i.Symbol)
+	case i.MinQty <= 0 || i.MaxQty < i.MinQty:
+		return fmt.Errorf("%w: %s requires 0 < min_qty <= max_qty", ErrInvalidInstrument, i.Symbol)
+	case i.MinNotional < 0:
+		return fmt.Errorf("%w: %s min notional is negative", ErrInvalidInstrument, i.Symbol)
+	case i.MaxPrice < 0:
+		return fmt.Errorf("%w: %s max price is negative", ErrInvalidInstrument, i.Symbol)
+	// A scale must hold its step; zero takes the step's own
+	case i.PriceDecimals < 0 || i.PriceDecimals > decimal.Precision ||
+		(i.PriceDecimals > 0 && i.PriceDecimals < i.TickSize.Places()):
+		return fmt.Errorf("%w: %s requires tick size places <= price decimals <= %d", ErrInvalidInstrument, i.Symbol, decimal.Precision)
+	case i.QtyDecimals < 0 || i.QtyDecimals > decimal.Precision ||
+		(i.QtyDecimals > 0 && i.QtyDecimals < i.LotSize.Places()):
+		return fmt.Errorf("%w: %s requires lot size places <= qty decimals <= %d", ErrInvalidInstrument, i.Symbol, decimal.Precision)
+	case i.MaxLeverage < decimal.One:
+		return fmt.Errorf("%w: %s max leverage must be at least 1", ErrInvalidInstrument, i.Symbol)
+	// MMR must stay below the initial margin rate at max leverage, or a
+	// position opened at max leverage would be liquidatable immediately.
+	case i.MaintenanceMarginRate <= 0 || i.MaintenanceMarginRate.Mul(i.MaxLeverage) >= decimal.One:
+		return fmt.Errorf("%w: %s requires 0 < mmr < 1/max_leverage", ErrInvalidInstrument, i.Symbol)
+	case i.LiquidationStep < 0 || !i.LiquidationStep.IsMultipleOf(i.LotSize):
+		return fmt.Errorf("%w: %s liquidation step must be a non-negative multiple of lot size", ErrInvalidInstrument, i.Symbol)
+	case i.FundingRateCap < 0 || i.FundingRateCap >= decimal.One:
+		return fmt.Errorf("%w: %s requires 0 <= funding rate cap < 1", ErrInvalidInstrument, i.Symbol)
+	// A maker rebate may not exceed the taker fee it is paid from
+	case i.TakerFeeRate < 0 || i.TakerFeeRate >= decimal.One || i.MakerFeeRate >= decimal.One ||
+		i.MakerFeeRate+i.TakerFeeRate < 0:
+		return fmt.Errorf("%w: %s requires 0 <= taker fee < 1 and -taker fee <= maker fee < 1", ErrInvalidInstrument, i.Symbol)
+	}
+
+	// A capped price must bound every order's notional
+	if i.MaxPrice > 0 {
+		if _, err := i.MaxPrice.MulChecked(i.MaxQty); err != nil {
+			return fmt.Errorf("%w: %s max price × max qty is out of range", ErrInvalidInstrument, i.Symbol)
+		}
+	}
+	if err := validateRiskTiers(i); err != nil {
+		return err
+	}
+
+	switch i.Status {
+	case domain.InstrumentTrading, domain.InstrumentHalted, domain.InstrumentDelisted:
+	default:
+		return fmt.Errorf("%w: %s unknown status %q", ErrInvalidInstrument, i.Symbol, i.Status)
+	}
+	return nil
+}
+
+// validateRiskTiers checks that tiers widen with rising maintenance margin
+// rates and falling leverage, and that each maintenance amount keeps the
+// maintenance margin continuous across a bracket boundary. The instrument's
+// own rate and max leverage describe the first tier.
+func validateRiskTiers(i *domain.Instrument) error {
+	if len(i.RiskTiers) == 0 {
+		return nil
+	}
+	first := i.RiskTiers[0]
+	if first.MaintenanceMarginRate != i.MaintenanceMarginRate || first.MaxLeverage != i.MaxLeverage {
+		return fmt.Errorf("%w: %s first risk tier must match mmr and max leverage", ErrInvalidInstrument, i.Symbol)
+	}
+
+	var prev domain.RiskTier
+	for n, t := range i.RiskTiers {
+		switch {
+		case t.MaxNotional <= prev.MaxNotional:
+			return fmt.Errorf("%w: %s risk tier %d notional must rise", ErrInvalidInstrument, i.Symbol, n+1)
+		case t.MaxLeverage < decimal.One || (n > 0 && t.MaxLeverage > prev.MaxLeverage):
+			return fmt.Errorf("%w: %s risk tier %d leverage must be at least 1 and not rise", ErrInvalidInstrument, i.Symbol, n+1)
+		case t.MaintenanceMarginRate <= 0 || t.MaintenanceMarginRate < prev.MaintenanceMarginRate ||
+			t.MaintenanceMarginRate.Mul(t.MaxLeverage) >= decimal.One:
+			return fmt.Errorf("%w: %s risk tier %d requires rising 0 < mmr < 1/max_leverage", ErrInvalidInstrument, i.Symbol, n+1)
+		case t.MaintenanceAmount != prev.MaintenanceAmount+prev.MaxNotional.Mul(t.MaintenanceMarginRate-prev.MaintenanceMarginRate):
+			return fmt.Errorf("%w: %s risk tier %d maintenance amount must be %s", ErrInvalidInstrument, i.Symbol, n+1,
+				prev.MaintenanceAmount+prev.MaxNotional.Mul(t.MaintenanceMarginRate-prev.MaintenanceMarginRate))
+		}
+		prev = t
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"testing"
+
+	"oms-contract/internal/domain"
+	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testInstrument(symbol string) *domain.Instrument {
+	return &domain.Instrument{
+		Symbol:                symbol,
+		BaseAsset:             "BTC",
+		SettleAsset:           "USDT",
+		TickSize:              decimal.MustParse("0.1"),
+		LotSize:               decimal.MustParse("0.001"),
+		MinQty:                decimal.MustParse("0.001"),
+		MaxQty:                decimal.FromInt(100),
+		MinNotional:           decimal.FromInt(5),
+		MaxLeverage:           decimal.FromInt(100),
+		MaintenanceMarginRate: decimal.MustParse("0.004"),
+		Status:                domain.InstrumentTrading,
+	}
+}
+
+func TestInstrumentService_ValidateOrder(t *testing.T) {
+	svc, state, _ := newTestOrderService(t)
+
+	order := func(symbol, price, qty string) *domain.Order {
+		o := &domain.Order{UserID: 100, Symbol: symbol, Side: domain.Buy, Type: domain.Limit,
+			Quantity: decimal.MustParse(qty)}
+		if price == "" {
+			o.Type = domain.Market
+		} else {
+			o.Price = decimal.MustParse(price)
+		}
+		return o
+	}
+
+	cases := []struct {
+		name string
+		o    *domain.Order
+		err  error
+	}{
+		{"unknown symbol", order("FOOUSDT", "1", "1"), ErrUnknownSymbol},
+		{"off tick", order("BTCUSDT", "30000.05", "1"), ErrInvalidTickSize},
+		{"off lot", order("BTCUSDT", "30000", "0.0015"), ErrInvalidLotSize},
+		{"below min qty", order("BTCUSDT", "30000", "0"), ErrQuantityTooSmall},
+		{"above max qty", order("BTCUSDT", "30000", "101"), ErrQuantityTooLarge},
+		{"below min notional", order("BTCUSDT", "1000", "0.001"), ErrNotionalTooSmall},
+		{"notional overflows", order("BTCUSDT", "90000000000", "100"), ErrNotionalTooLarge},
+		{"market skips notional", order("BTCUSDT", "", "0.001"), nil},
+		{"valid limit", order("BTCUSDT", "30000.1", "0.5"), nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := svc.instruments.ValidateOrder(tc.o)
+			if tc.err == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+
+	// CreateOrder rejects an invalid order before it is recorded
+	o := order("BTCUSDT", "30000.05", "1")
+	id, err := svc.CreateOrder(o)
+	require.ErrorIs(t, err, ErrInvalidTickSize)
+	require.Zero(t, id)
+	require.Equal(t, domain.Rejected, o.Status)
+
+	// A price whose notional cannot be represented is rejected, not a panic
+	_, err = svc.CreateOrder(order("BTCUSDT", "90000000000", "100"))
+	require.ErrorIs(t, err, ErrNotionalTooLarge)
+	require.Equal(t, domain.RejectInstrument, RejectCodeOf(err))
+
+	// A max price caps limit orders, and must keep max qty notional in range
+	inst := testInstrument("BTCUSDT")
+	inst.MaxPrice = decimal.FromInt(90000000000)
+	require.ErrorIs(t, svc.instruments.Upsert(inst), ErrInvalidInstrument)
+	inst.MaxPrice = decimal.FromInt(1000000)
+	require.NoError(t, svc.instruments.Upsert(inst))
+	_, err = svc.CreateOrder(order("BTCUSDT", "1000000.1", "1"))
+	require.ErrorIs(t, err, ErrPriceTooHigh)
+
+	// Halting the symbol blocks new orders until it is resumed
+	require.NoError(t, svc.instruments.SetStatus("BTCUSDT", domain.InstrumentHalted))
+	_, err = svc.CreateOrder(order("BTCUSDT", "30000", "1"))
+	require.ErrorIs(t, err, ErrSymbolNotTrading)
+
+	inst, ok := state.InstrumentBook.Get("BTCUSDT")
+	require.True(t, ok)
+	require.Equal(t, domain.InstrumentHalted, inst.Status)
+}
+
+func TestInstrumentService_Upsert(t *testing.T) {
+	svc, _, store := newTestOrderService(t)
+	before := store.LastSequenceID()
+
+	// Identical spec does not publish a new event
+	require.NoError(t, svc.instruments.Upsert(testInstrument("BTCUSDT")))
+	require.Equal(t, before, store.LastSequenceID())
+
+	bad := testInstrument("ETHUSDT")
+	bad.MaintenanceMarginRate = decimal.MustParse("0.01") // 1% × 100x leaves no room
+	require.ErrorIs(t, svc.instruments.Upsert(bad), ErrInvalidInstrument)
+
+	bad = testInstrument("ETHUSDT")
+	bad.LiquidationStep = decimal.MustParse("0.0005") // off the lot grid
+	require.ErrorIs(t, svc.instruments.Upsert(bad), ErrInvalidInstrument)
+
+	bad = tieredInstrument("ETHUSDT")
+	bad.RiskTiers[1].MaintenanceAmount = decimal.FromInt(250) // maintenance margin jumps at 50k
+	require.ErrorIs(t, svc.instruments.Upsert(bad), ErrInvalidInstrument)
+
+	bad = testInstrument("ETHUSDT")
+	bad.QtyDecimals = 2 // the 0.001 lot needs three
+	require.ErrorIs(t, svc.instruments.Upsert(bad), ErrInvalidInstrument)
+
+	bad = testInstrument("ETHUSDT")
+	bad.PriceDecimals = decimal.Precision + 1
+	require.ErrorIs(t, svc.instruments.Upsert(bad), ErrInvalidInstrument)
+
+	// Quoted finer than the tick; a zero count follows the lot size
+	eth := testInstrument("ETHUSDT")
+	eth.PriceDecimals = 2
+	require.NoError(t, svc.instruments.Upsert(eth))
+	require.Equal(t, int32(2), eth.PriceScale())
+	require.Equal(t, int32(3), eth.QtyScale())
+
+	// Registry survives replay
+	snapMgr, err := snapshot.NewSnapshotManager(t.TempDir(), 1)
+	require.NoError(t, err)
+	replayed, err := snapshot.NewReplayEngine(store, snapMgr).Replay()
+	require.NoError(t, err)
+
+	inst, ok := replayed.InstrumentBook.Get("BTCUSDT")
+	require.True(t, ok)
+	require.Equal(t, testInstrument("BTCUSDT"), inst)
+	inst, ok = replayed.InstrumentBook.Get("ETHUSDT")
+	require.True(t, ok)
+	require.Equal(t, int32(2), inst.PriceDecimals)
+}
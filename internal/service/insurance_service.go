@@ -0,0 +1,75 @@
+package service
+
+import (
+	"fmt"
+
+	"oms-contract/internal/domain"
+	"oms-contract/pkg/decimal"
+)
+
+// InsuranceService runs the insurance fund: one balance per settlement
+// asset, held in the system INSURANCE ledger account. It takes what a
+// liquidation leaves above the bankruptcy price and pays for what it
+// loses below it, so one user's loss never reaches another's balance.
+// All state lives in the ledger; every movement is a LEDGER_POSTED event.
+type InsuranceService struct {
+	accounts *AccountService
+}
+
+func NewInsuranceService(accounts *AccountService) *InsuranceService {
+	return &InsuranceService{accounts: accounts}
+}
+
+func (s *InsuranceService) Balance(asset string) decimal.Decimal {
+	return s.accounts.SystemBalance(domain.BucketInsurance, asset)
+}
+
+// History returns the fund's most recent movements in an asset, newest first
+func (s *InsuranceService) History(asset string, limit int) []*domain.JournalEntry {
+	return s.accounts.book.SystemJournal(domain.BucketInsurance, asset, limit)
+}
+
+// Inject pays into the fund from outside the venue
+func (s *InsuranceService) Inject(asset string, amount decimal.Decimal) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+	return s.accounts.post(entry(domain.JournalInsuranceInject, asset, amount,
+		domain.SystemAccount(domain.BucketExternal), domain.SystemAccount(domain.BucketInsurance), 0))
+}
+
+// Absorb moves the surplus of a liquidation from the user into the fund
+func (s *InsuranceService) Absorb(userID int64, asset string, amount decimal.Decimal, ref int64) error {
+	if amount <= 0 {
+		return nil
+	}
+	return s.accounts.post(entry(domain.JournalLiquidation, asset, amount,
+		domain.UserAccount(userID, domain.BucketAvailable), domain.SystemAccount(domain.BucketInsurance), ref))
+}
+
+// Cover pays a liquidation's deficit to the user, as far as the fund
+// reaches, and returns the part it could not cover
+func (s *InsuranceService) Cover(userID int64, asset string, amount decimal.Decimal, ref int64) (decimal.Decimal, error) {
+	if amount <= 0 {
+		return decimal.Zero, nil
+	}
+
+	s.accounts.mu.Lock()
+	defer s.accounts.mu.Unlock()
+
+	covered := decimal.Min(amount, decimal.Max(s.Balance(asset), decimal.Zero))
+	if covered > 0 {
+		err := s.accounts.post(entry(domain.JournalInsuranceCover, asset, covered,
+			domain.SystemAccount(domain.BucketInsurance), domain.UserAccount(userID, domain.BucketAvailable), ref))
+		if err != nil {
+			return amount, err
+		}
+	}
+
+	shortfall := amount - covered
+	if shortfall > 0 {
+		fmt.Printf("[OMS] insurance fund short: user=%d asset=%s deficit=%s shortfall=%s\n",
+			userID, asset, amount, shortfall)
+	}
+	return shortfall, nil
+}
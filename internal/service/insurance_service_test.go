@@ -0,0 +1,108 @@
+package service
+
+import (
+	"testing"
+
+	"oms-contract/internal/domain"
+	"oms-contract/internal/engine"
+	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
+
+	"github.com/stretchr/testify/require"
+)
+
+// gapGateway fills every liquidation order in full at a fixed price,
+// as a gapping market would, whatever its limit
+type gapGateway struct {
+	*engineGateway
+	price decimal.Decimal
+}
+
+func (g *gapGateway) SendLiquidationOrder(o *domain.LiquidationOrder) ([]*domain.Trade, error) {
+	return []*domain.Trade{{TradeID: o.OrderID, OrderID: o.OrderID, UserID: o.UserID, Symbol: o.Symbol,
+		Side: o.Side, Qty: o.Quantity, Price: g.price, PositionSide: o.PositionSide}}, nil
+}
+
+// openLong puts user 100 long 1 BTCUSDT @ 30000 at 10x against user 200:
+// margin 3000, bankruptcy price 27000
+func openLong(t *testing.T, svc *OrderService) {
+	t.Helper()
+	fund(t, svc, 100000, 100, 200, 300)
+	_, err := svc.SetLeverage(200, "BTCUSDT", decimal.FromInt(1))
+	require.NoError(t, err)
+	_, err = svc.marks.Update("BTCUSDT", decimal.FromInt(30000))
+	require.NoError(t, err)
+
+	for _, o := range []*domain.Order{
+		{UserID: 200, Side: domain.Sell, Price: decimal.FromInt(30000)},
+		{UserID: 100, Side: domain.Buy, Price: decimal.FromInt(30000)},
+	} {
+		o.Symbol, o.Type, o.Quantity = "BTCUSDT", domain.Limit, decimal.FromInt(1)
+		_, err := svc.CreateOrder(o)
+		require.NoError(t, err)
+	}
+}
+
+func TestInsuranceService_AbsorbsSurplus(t *testing.T) {
+	svc, state, store := newTestOrderService(t)
+	svc.matching = &engineGateway{engine: engine.NewMatchingEngine()}
+	svc.liquidator.matching = svc.matching
+	openLong(t, svc)
+
+	// The liquidation sells into a bid 100 above the bankruptcy price
+	_, err := svc.CreateOrder(&domain.Order{UserID: 300, Symbol: "BTCUSDT", Side: domain.Buy, Type: domain.Limit,
+		Price: decimal.FromInt(27100), Quantity: decimal.FromInt(1)})
+	require.NoError(t, err)
+	_, err = svc.marks.Update("BTCUSDT", decimal.FromInt(26900))
+	require.NoError(t, err)
+
+	p, _ := state.PositionBook.Get(100, "BTCUSDT")
+	require.True(t, p.Qty.IsZero())
+
+	// The user loses exactly the margin; the surplus goes to the fund
+	a, _ := svc.accounts.Get(100, "USDT")
+	require.Equal(t, decimal.FromInt(97000), a.Total())
+	require.Equal(t, decimal.FromInt(100), svc.insurance.Balance("USDT"))
+
+	history := svc.insurance.History("USDT", 10)
+	require.Len(t, history, 1)
+	require.Equal(t, domain.JournalLiquidation, history[0].Type)
+
+	snapMgr, err := snapshot.NewSnapshotManager(t.TempDir(), 1)
+	require.NoError(t, err)
+	replayed, err := snapshot.NewReplayEngine(store, snapMgr).Replay()
+	require.NoError(t, err)
+	require.Equal(t, state.AccountBook.State(), replayed.AccountBook.State())
+}
+
+func TestInsuranceService_CoversDeficit(t *testing.T) {
+	svc, state, store := newTestOrderService(t)
+	gw := &engineGateway{engine: engine.NewMatchingEngine()}
+	svc.matching = gw
+	svc.liquidator.matching = &gapGateway{engineGateway: gw, price: decimal.FromInt(26900)}
+	openLong(t, svc)
+	require.NoError(t, svc.insurance.Inject("USDT", decimal.FromInt(50)))
+
+	// The close gaps 100 through the bankruptcy price; the fund holds only 50
+	_, err := svc.marks.Update("BTCUSDT", decimal.FromInt(26800))
+	require.NoError(t, err)
+
+	p, _ := state.PositionBook.Get(100, "BTCUSDT")
+	require.True(t, p.Qty.IsZero())
+
+	a, _ := svc.accounts.Get(100, "USDT")
+	require.Equal(t, decimal.FromInt(96950), a.Total())
+	require.True(t, svc.insurance.Balance("USDT").IsZero())
+
+	history := svc.insurance.History("USDT", 10)
+	require.Len(t, history, 2)
+	require.Equal(t, domain.JournalInsuranceCover, history[0].Type)
+	require.Equal(t, decimal.FromInt(50), history[0].Amount)
+	require.Equal(t, domain.JournalInsuranceInject, history[1].Type)
+
+	snapMgr, err := snapshot.NewSnapshotManager(t.TempDir(), 1)
+	require.NoError(t, err)
+	replayed, err := snapshot.NewReplayEngine(store, snapMgr).Replay()
+	require.NoError(t, err)
+	require.Equal(t, state.AccountBook.State(), replayed.AccountBook.State())
+}
@@ -1,47 +1,253 @@
 package service
 
 import (
+	"testing"
+	"time"
+
 	"oms-contract/internal/domain"
 	"oms-contract/internal/engine"
-	"oms-contract/internal/memory"
-	"testing"
+	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestLiquidationFlow(t *testing.T) {
-	// This test is deprecated as LiquidationEngine.OnMarkPrice method is commented out
-	// Liquidation functionality is now handled by LiquidationService
-	// See cmd/oms/main.go Scenario 4 for liquidation demonstration
-	t.Skip("LiquidationEngine.OnMarkPrice is deprecated, see LiquidationService instead")
-
-	match := engine.NewMatchingEngine()
-	positionBook := memory.NewPositionBook()
-	positionSvc := NewPositionService(positionBook, nil)
-	liq := NewLiquidationEngine(match, positionSvc)
-
-	// Open a 10x long at 50000
-	trade1 := &domain.Trade{
-		OrderID: 1,
-		UserID:  100,
-		Symbol:  "BTCUSDT",
-		Qty:     1,
-		Price:   50000,
-		IsMaker: false,
-	}
-
-	positionSvc.OnTrade(
-		trade1.UserID,
-		trade1.Symbol,
-		trade1.Qty,
-		trade1.Price,
-		10,
-	)
-
-	// Note: OnMarkPrice is now commented out in liquidation_engine.go
-	// This test would need to be rewritten to use LiquidationService
-	_ = liq
-
-	pos, _ := positionSvc.Get(100, "BTCUSDT")
-	if pos == nil {
-		t.Fatal("position should exist")
+	svc, state, _ := newTestOrderService(t)
+	svc.matching = &engineGateway{engine: engine.NewMatchingEngine()}
+	svc.liquidator.matching = svc.matching
+	fund(t, svc, 100000, 100, 101, 102)
+	fund(t, svc, 1000000, 200, 300)
+
+	place := func(user int64, side domain.Side, price int64, qty string) {
+		_, err := svc.CreateOrder(&domain.Order{UserID: user, Symbol: "BTCUSDT", Side: side, Type: domain.Limit,
+			Price: decimal.FromInt(price), Quantity: decimal.MustParse(qty)})
+		require.NoError(t, err)
+	}
+	qty := func(user int64) decimal.Decimal {
+		p, _ := state.PositionBook.Get(user, "BTCUSDT")
+		return p.Qty
+	}
+	atRisk := func(mark int64) []int64 {
+		var users []int64
+		for _, p := range svc.position.AtRisk("BTCUSDT", decimal.FromInt(mark)) {
+			users = append(users, p.UserID)
+		}
+		return users
+	}
+	index := func(price int64) {
+		t.Helper()
+		_, err := svc.marks.Update("BTCUSDT", decimal.FromInt(price))
+		require.NoError(t, err)
+	}
+
+	// Counterparties trade at 1x and are never at risk
+	for _, user := range []int64{200, 300} {
+		_, err := svc.SetLeverage(user, "BTCUSDT", decimal.FromInt(1))
+		require.NoError(t, err)
+	}
+	_, err := svc.SetLeverage(102, "BTCUSDT", decimal.FromInt(20))
+	require.NoError(t, err)
+	index(30000)
+
+	// 100 and 102 go long 1 @ 30000 at 10x and 20x, 101 short at 10x:
+	// liquidation prices ~27108, ~28614 and ~32868
+	place(200, domain.Sell, 30000, "2")
+	place(100, domain.Buy, 30000, "1")
+	place(102, domain.Buy, 30000, "1")
+	place(200, domain.Buy, 30000, "1")
+	place(101, domain.Sell, 30000, "1")
+
+	require.Empty(t, atRisk(29000))
+	require.Equal(t, []int64{102}, atRisk(28000))
+	require.Equal(t, []int64{102, 100}, atRisk(27000))
+	require.Equal(t, []int64{101}, atRisk(33000))
+
+	// The index falls: the mark update alone liquidates 102 into 300's bid,
+	// above its 28500 bankruptcy price
+	place(300, domain.Buy, 28600, "1")
+	index(28400)
+	require.True(t, qty(102).IsZero())
+	require.Equal(t, decimal.FromInt(1), qty(100))
+	require.Equal(t, []int64{100}, atRisk(27000))
+
+	// 100 is past its liquidation price too, but the only bid is below its
+	// bankruptcy price of 27000: the liquidation order does not trade there
+	p, _ := state.PositionBook.Get(100, "BTCUSDT")
+	require.Equal(t, decimal.FromInt(27000), p.BankruptcyPrice)
+	place(300, domain.Buy, 26900, "1")
+	index(26800)
+	require.Equal(t, decimal.FromInt(1), qty(100))
+
+	// A bid at the bankruptcy price takes it on the next mark, while the
+	// short only gains
+	place(300, domain.Buy, 27000, "1")
+	index(26700)
+	require.True(t, qty(100).IsZero())
+	require.Equal(t, decimal.FromInt(-1), qty(101))
+	require.Empty(t, atRisk(26800))
+	require.Equal(t, []int64{101}, atRisk(33000))
+
+	// The forced closes stay out of the liquidated users' VIP volume; the
+	// bids that took them count for 300
+	now := time.Now()
+	require.Equal(t, decimal.FromInt(30000), svc.fees.Volume(100, now))
+	require.Equal(t, decimal.FromInt(30000), svc.fees.Volume(102, now))
+	require.Equal(t, decimal.FromInt(28600+27000), svc.fees.Volume(300, now))
+}
+
+// stepGateway records the size of every liquidation order it passes on
+type stepGateway struct {
+	*engineGateway
+	sent []decimal.Decimal
+}
+
+func (g *stepGateway) SendLiquidationOrder(o *domain.LiquidationOrder) ([]*domain.Trade, error) {
+	g.sent = append(g.sent, o.Quantity)
+	return g.engineGateway.SendLiquidationOrder(o)
+}
+
+func TestLiquidationFlow_Steps(t *testing.T) {
+	d := decimal.MustParse
+	setup := func(t *testing.T, mode domain.MarginMode, deposit int64) (*OrderService, *stepGateway, func(int64, domain.Side, int64, string)) {
+		svc, _, _ := newTestOrderService(t)
+		inst := testInstrument("BTCUSDT")
+		inst.LiquidationStep = d("0.4")
+		require.NoError(t, svc.instruments.Upsert(inst))
+
+		gw := &stepGateway{engineGateway: &engineGateway{engine: engine.NewMatchingEngine()}}
+		svc.matching = gw.engineGateway
+		svc.liquidator.matching = gw
+		fund(t, svc, deposit, 100)
+		fund(t, svc, 1000000, 200, 300)
+		require.NoError(t, svc.SetMarginMode(100, mode))
+		for _, user := range []int64{200, 300} {
+			_, err := svc.SetLeverage(user, "BTCUSDT", decimal.FromInt(1))
+			require.NoError(t, err)
+		}
+		_, err := svc.marks.Update("BTCUSDT", decimal.FromInt(30000))
+		require.NoError(t, err)
+
+		place := func(user int64, side domain.Side, price int64, qty string) {
+			_, err := svc.CreateOrder(&domain.Order{UserID: user, Symbol: "BTCUSDT", Side: side, Type: domain.Limit,
+				Price: decimal.FromInt(price), Quantity: d(qty)})
+			require.NoError(t, err)
+		}
+		// 100 goes long 1 @ 30000 at 10x
+		place(200, domain.Sell, 30000, "1")
+		place(100, domain.Buy, 30000, "1")
+		return svc, gw, place
+	}
+
+	t.Run("isolated", func(t *testing.T) {
+		svc, gw, place := setup(t, domain.IsolatedMargin, 100000)
+
+		// Closing part of an isolated position leaves its margin ratio as
+		// it was, so it is stepped out in full, walking down the bids
+		place(300, domain.Buy, 27500, "0.5")
+		place(300, domain.Buy, 27200, "0.5")
+		_, err := svc.marks.Update("BTCUSDT", decimal.FromInt(26900))
+		require.NoError(t, err)
+
+		p, _ := svc.position.Get(100, "BTCUSDT")
+		require.True(t, p.Qty.IsZero())
+		require.Equal(t, []decimal.Decimal{d("0.4"), d("0.4"), d("0.2")}, gw.sent)
+	})
+
+	t.Run("cross", func(t *testing.T) {
+		svc, gw, place := setup(t, domain.CrossMargin, 3500)
+
+		// One step frees enough maintenance margin to bring the account
+		// back above it: the rest of the position stays open
+		place(300, domain.Buy, 26650, "1")
+		_, err := svc.marks.Update("BTCUSDT", decimal.FromInt(26470))
+		require.NoError(t, err)
+
+		p, _ := svc.position.Get(100, "BTCUSDT")
+		require.Equal(t, d("0.6"), p.Qty)
+		require.Equal(t, []decimal.Decimal{d("0.4")}, gw.sent)
+	})
+}
+
+func TestLiquidationFlow_Freeze(t *testing.T) {
+	svc, state, store := newTestOrderService(t)
+	svc.matching = &engineGateway{engine: engine.NewMatchingEngine()}
+	svc.liquidator.matching = svc.matching
+	fund(t, svc, 100000, 100)
+	fund(t, svc, 1000000, 200, 300)
+	for _, user := range []int64{200, 300} {
+		_, err := svc.SetLeverage(user, "BTCUSDT", decimal.FromInt(1))
+		require.NoError(t, err)
+	}
+
+	place := func(user int64, side domain.Side, price int64, qty string) (*domain.Order, error) {
+		o := &domain.Order{UserID: user, Symbol: "BTCUSDT", Side: side, Type: domain.Limit,
+			Price: decimal.FromInt(price), Quantity: decimal.MustParse(qty)}
+		_, err := svc.CreateOrder(o)
+		return o, err
+	}
+	position := func() *domain.Position {
+		p, _ := state.PositionBook.Get(100, "BTCUSDT")
+		return p
+	}
+	index := func(price int64) {
+		t.Helper()
+		_, err := svc.marks.Update("BTCUSDT", decimal.FromInt(price))
+		require.NoError(t, err)
+	}
+	replayedState := func() domain.LiquidationState {
+		snapMgr, err := snapshot.NewSnapshotManager(t.TempDir(), 1)
+		require.NoError(t, err)
+		replayed, err := snapshot.NewReplayEngine(store, snapMgr).Replay()
+		require.NoError(t, err)
+		p, _ := replayed.PositionBook.Get(100, "BTCUSDT")
+		return p.State
 	}
+
+	// 100 goes long 1 @ 30000 at 10x and keeps a bid resting
+	index(30000)
+	_, err := place(200, domain.Sell, 30000, "1")
+	require.NoError(t, err)
+	_, err = place(100, domain.Buy, 30000, "1")
+	require.NoError(t, err)
+	resting, err := place(100, domain.Buy, 25000, "0.5")
+	require.NoError(t, err)
+	require.Equal(t, domain.PositionNormal, position().State)
+
+	// The book takes only half: the position is frozen with the rest open
+	// and its resting order canceled
+	_, err = place(300, domain.Buy, 27100, "0.5")
+	require.NoError(t, err)
+	index(26900)
+	require.Equal(t, decimal.MustParse("0.5"), position().Qty)
+	require.Equal(t, domain.PositionLiquidating, position().State)
+	o, _ := state.OrderBook.Get(resting.ID)
+	require.Equal(t, domain.Canceled, o.Status)
+	require.Equal(t, domain.PositionLiquidating, replayedState())
+
+	// Only orders reducing the position are accepted meanwhile
+	_, err = place(100, domain.Buy, 26000, "0.1")
+	require.ErrorIs(t, err, ErrPositionLiquidating)
+	_, err = place(100, domain.Sell, 27500, "0.6")
+	require.ErrorIs(t, err, ErrPositionLiquidating)
+	_, err = svc.SetLeverage(100, "BTCUSDT", decimal.FromInt(5))
+	require.ErrorIs(t, err, ErrPositionLiquidating)
+	reducing, err := place(100, domain.Sell, 27500, "0.2")
+	require.NoError(t, err)
+
+	// The mark recovers and releases the position
+	index(30000)
+	require.Equal(t, domain.PositionNormal, position().State)
+	_, err = place(100, domain.Buy, 26000, "0.1")
+	require.NoError(t, err)
+
+	// A second fall closes it in the market
+	_, err = place(300, domain.Buy, 27100, "0.5")
+	require.NoError(t, err)
+	index(26900)
+	require.True(t, position().Qty.IsZero())
+	require.Equal(t, domain.PositionClosed, position().State)
+	o, _ = state.OrderBook.Get(reducing.ID)
+	require.Equal(t, domain.Canceled, o.Status)
+	require.Equal(t, domain.PositionClosed, replayedState())
 }
@@ -2,54 +2,148 @@ package service
 
 import (
 	"fmt"
+	"sort"
 
 	"oms-contract/internal/domain"
+	"oms-contract/pkg/decimal"
 	"oms-contract/pkg/idgen"
 )
 
-const (
-	MaintenanceMarginRate = 0.005 // 0.5%
+var (
+	// MaintenanceMarginRate is the fallback for symbols without an instrument spec
+	MaintenanceMarginRate = decimal.New(5, -3) // 0.5%
 )
 
 func (l *LiquidationService) Check(
 	p *domain.Position,
-	markPrice float64,
+	markPrice decimal.Decimal,
 ) bool {
+	if p.Qty.IsZero() {
+		return false
+	}
 
-	notional := abs(p.Qty) * markPrice
-	mm := notional * MaintenanceMarginRate
-	upnl := (markPrice - p.EntryPrice) * p.Qty
+	notional := p.Qty.Abs().Mul(markPrice)
+	mm := maintenanceMargin(l.instruments, p.Symbol, notional)
+	upnl := (markPrice - p.EntryPrice).Mul(p.Qty)
 
 	equity := p.Margin + upnl
 
 	return equity <= mm
 }
 
+// CheckAccount reports whether a cross-margin account must be liquidated:
+// its wallet balance plus the unrealized PnL of all positions is at or
+// below the maintenance margin summed across positions. mark prices each
+// position.
+func (l *LiquidationService) CheckAccount(
+	wallet decimal.Decimal,
+	positions []*domain.Position,
+	mark func(p *domain.Position) decimal.Decimal,
+) bool {
+	equity := wallet
+	var mm decimal.Decimal
+	for _, p := range positions {
+		if p.Qty.IsZero() {
+			continue
+		}
+		price := mark(p)
+		equity += (price - p.EntryPrice).Mul(p.Qty)
+		mm += maintenanceMargin(l.instruments, p.Symbol, p.Qty.Abs().Mul(price))
+	}
+	return mm > 0 && equity <= mm
+}
+
+// Prioritize orders a cross-margin account's positions for liquidation:
+// the largest unrealized loss first, ties broken by symbol and side so the
+// order is deterministic
+func (l *LiquidationService) Prioritize(
+	positions []*domain.Position,
+	mark func(p *domain.Position) decimal.Decimal,
+) []*domain.Position {
+	upnl := make(map[*domain.Position]decimal.Decimal, len(positions))
+	for _, p := range positions {
+		upnl[p] = (mark(p) - p.EntryPrice).Mul(p.Qty)
+	}
+
+	ordered := append([]*domain.Position(nil), positions...)
+	sort.Slice(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		if upnl[a] != upnl[b] {
+			return upnl[a] < upnl[b]
+		}
+		if a.Symbol != b.Symbol {
+			return a.Symbol < b.Symbol
+		}
+		return a.Side < b.Side
+	})
+	return ordered
+}
+
 func (l *LiquidationService) Liquidate(p *domain.Position) {
 	fmt.Printf(
-		"[LIQUIDATION] user=%d symbol=%s qty=%.2f\n",
+		"[LIQUIDATION] user=%d symbol=%s qty=%s\n",
 		p.UserID, p.Symbol, p.Qty,
 	)
 }
 
 type LiquidationService struct {
-	matching MatchingGateway
-	idGen    *idgen.Generator
+	instruments *InstrumentService
+	matching    MatchingGateway
+	idGen       *idgen.Generator
 }
 
 func NewLiquidationService(
+	instruments *InstrumentService,
 	matching MatchingGateway,
 	idGen *idgen.Generator,
 ) *LiquidationService {
 	return &LiquidationService{
-		matching: matching,
-		idGen:    idGen,
+		instruments: instruments,
+		matching:    matching,
+		idGen:       idGen,
 	}
 }
 
+// riskTier returns the risk tier a position of notional falls in, the
+// last one beyond it. Symbols without a spec use MaintenanceMarginRate.
+func riskTier(instruments *InstrumentService, symbol string, notional decimal.Decimal) domain.RiskTier {
+	if instruments != nil {
+		if inst, ok := instruments.Get(symbol); ok {
+			_, tier, _ := inst.TierFor(notional)
+			return tier
+		}
+	}
+	return domain.RiskTier{MaintenanceMarginRate: MaintenanceMarginRate}
+}
+
+func maintenanceMargin(instruments *InstrumentService, symbol string, notional decimal.Decimal) decimal.Decimal {
+	return riskTier(instruments, symbol, notional).MaintenanceMargin(notional)
+}
+
+// StepQty is how much of a position one liquidation order closes: the
+// instrument's liquidation step, or the whole position when it has none or
+// the position is smaller
+func (l *LiquidationService) StepQty(p *domain.Position) decimal.Decimal {
+	qty := p.Qty.Abs()
+	if l.instruments == nil {
+		return qty
+	}
+	if inst, ok := l.instruments.Get(p.Symbol); ok && inst.LiquidationStep > 0 {
+		return decimal.Min(qty, inst.LiquidationStep)
+	}
+	return qty
+}
+
+// Execute sends a reduce-only IOC order closing qty of the position, never
+// more than it holds, no worse than its bankruptcy price, so the close
+// never loses more than the position's margin, and returns its fills.
+// Whatever finds no liquidity at that price stays open for the next check.
 func (l *LiquidationService) Execute(
 	p *domain.Position,
-) {
+	qty decimal.Decimal,
+	bankruptcy decimal.Decimal,
+) []*domain.Trade {
+	qty = decimal.Min(qty, p.Qty.Abs())
 
 	side := domain.Sell
 	if p.Qty < 0 {
@@ -57,14 +151,17 @@ func (l *LiquidationService) Execute(
 	}
 
 	order := &domain.LiquidationOrder{
-		OrderID:     l.idGen.Next(),
-		UserID:      p.UserID,
-		Symbol:      p.Symbol,
-		Side:        side,
-		Quantity:    abs(p.Qty),
-		OrderType:   domain.Market,
-		TimeInForce: "IOC",
-		Reason:      "LIQUIDATION",
+		OrderID:      l.idGen.Next(),
+		UserID:       p.UserID,
+		Symbol:       p.Symbol,
+		Side:         side,
+		PositionSide: p.Side,
+		Quantity:     qty,
+		Price:        l.limitPrice(p.Symbol, side, bankruptcy),
+		OrderType:    domain.IOC,
+		TimeInForce:  "IOC",
+		Reason:       "LIQUIDATION",
+		ReduceOnly:   true,
 	}
 
 	fmt.Printf(
@@ -72,5 +169,32 @@ func (l *LiquidationService) Execute(
 		order,
 	)
 
-	_ = l.matching.SendLiquidationOrder(order)
+	if l.matching == nil {
+		return nil
+	}
+
+	trades, err := l.matching.SendLiquidationOrder(order)
+	if err != nil {
+		fmt.Printf("[OMS] liquidation order %d failed: %v\n", order.OrderID, err)
+		return nil
+	}
+	return trades
+}
+
+// limitPrice rounds a bankruptcy price onto the tick grid on the side that
+// stays within it: up for a sell closing a long, down for a buy
+func (l *LiquidationService) limitPrice(symbol string, side domain.Side, bankruptcy decimal.Decimal) decimal.Decimal {
+	if l.instruments == nil {
+		return bankruptcy
+	}
+	inst, ok := l.instruments.Get(symbol)
+	if !ok || inst.TickSize <= 0 {
+		return bankruptcy
+	}
+
+	price := bankruptcy.Div(inst.TickSize).Truncate(0).Mul(inst.TickSize)
+	if side == domain.Sell && price < bankruptcy {
+		price += inst.TickSize
+	}
+	return price
 }
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"testing"
+
+	"oms-contract/internal/domain"
+	"oms-contract/internal/engine"
+	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiquidationService_CrossMargin(t *testing.T) {
+	svc, state, store := newTestOrderService(t)
+	svc.matching = &engineGateway{engine: engine.NewMatchingEngine()}
+	svc.liquidator.matching = svc.matching
+	require.NoError(t, svc.instruments.Upsert(testInstrument("ETHUSDT")))
+	fund(t, svc, 1250, 100)
+	fund(t, svc, 1000000, 200, 300)
+
+	place := func(user int64, side domain.Side, symbol string, price int64, qty string) {
+		_, err := svc.CreateOrder(&domain.Order{UserID: user, Symbol: symbol, Side: side, Type: domain.Limit,
+			Price: decimal.FromInt(price), Quantity: decimal.MustParse(qty)})
+		require.NoError(t, err)
+	}
+	qty := func(symbol string) decimal.Decimal {
+		p, _ := state.PositionBook.Get(100, symbol)
+		return p.Qty
+	}
+
+	require.NoError(t, svc.SetMarginMode(100, domain.CrossMargin))
+	for _, symbol := range []string{"BTCUSDT", "ETHUSDT"} {
+		_, err := svc.SetLeverage(100, symbol, decimal.FromInt(100))
+		require.NoError(t, err)
+	}
+
+	// 100 goes long 1 BTC @ 30000 and 10 ETH @ 2000 on 1250 of wallet
+	place(200, domain.Sell, "BTCUSDT", 30000, "1")
+	place(100, domain.Buy, "BTCUSDT", 30000, "1")
+	place(200, domain.Sell, "ETHUSDT", 2000, "10")
+	place(100, domain.Buy, "ETHUSDT", 2000, "10")
+	require.ErrorIs(t, svc.SetMarginMode(100, domain.IsolatedMargin), ErrMarginModeChange)
+
+	// Cross positions carry no prices of their own: BTC alone going bankrupt
+	// takes the whole 1250 wallet, 30000 - 1250
+	btc, _ := state.PositionBook.Get(100, "BTCUSDT")
+	require.True(t, btc.BankruptcyPrice.IsZero())
+	_, bankruptcy := svc.RiskPrices(btc)
+	require.Equal(t, decimal.FromInt(28750), bankruptcy)
+
+	// GetPosition fills them into a copy, with the rest of the view
+	view, ok := svc.GetPosition(100, "BTCUSDT", domain.PositionBoth)
+	require.True(t, ok)
+	require.Equal(t, decimal.FromInt(28750), view.BankruptcyPrice)
+	require.Equal(t, svc.ADLQuantile(btc), view.ADLQuantile)
+	require.Equal(t, decimal.One, view.Qty)
+	require.True(t, btc.BankruptcyPrice.IsZero())
+	_, ok = svc.GetPosition(400, "BTCUSDT", domain.PositionBoth)
+	require.False(t, ok)
+
+	// BTC trades down to 29900 (-100), ETH to 1900 (-1000). Equity 150 is
+	// below the 195.6 maintenance margin of both positions. An isolated BTC
+	// position would be healthy and an isolated ETH one long gone.
+	place(200, domain.Buy, "BTCUSDT", 29900, "2")
+	place(300, domain.Sell, "BTCUSDT", 29900, "1")
+	place(200, domain.Buy, "ETHUSDT", 1900, "20")
+	place(300, domain.Sell, "ETHUSDT", 1900, "10")
+	require.Equal(t, decimal.FromInt(1), qty("BTCUSDT"))
+	require.Equal(t, decimal.FromInt(10), qty("ETHUSDT"))
+
+	svc.mu.Lock()
+	svc.checkLiquidation(100, "BTCUSDT")
+	svc.mu.Unlock()
+
+	// ETH, the bigger loss, goes first; the BTC maintenance margin of 119.6
+	// alone is covered by the remaining equity, so BTC survives
+	require.True(t, qty("ETHUSDT").IsZero())
+	require.Equal(t, decimal.FromInt(1), qty("BTCUSDT"))
+
+	a, _ := svc.accounts.Get(100, "USDT")
+	require.Equal(t, decimal.FromInt(250), a.Total())
+	require.True(t, ledgerSum(state, "USDT").IsZero())
+
+	snapMgr, err := snapshot.NewSnapshotManager(t.TempDir(), 1)
+	require.NoError(t, err)
+	replayed, err := snapshot.NewReplayEngine(store, snapMgr).Replay()
+	require.NoError(t, err)
+	require.Equal(t, domain.CrossMargin, replayed.PositionBook.MarginMode(100))
+}
+
+func TestLiquidationService_Prioritize(t *testing.T) {
+	liq := NewLiquidationService(nil, nil, nil)
+	d := decimal.MustParse
+	positions := []*domain.Position{
+		{Symbol: "ETHUSDT", Side: domain.PositionShort, Qty: d("-1"), EntryPrice: d("100")},
+		{Symbol: "BTCUSDT", Side: domain.PositionLong, Qty: d("1"), EntryPrice: d("110")},
+		{Symbol: "ETHUSDT", Side: domain.PositionLong, Qty: d("1"), EntryPrice: d("110")},
+		{Symbol: "SOLUSDT", Side: domain.PositionBoth, Qty: d("1"), EntryPrice: d("90")},
+	}
+	mark := func(*domain.Position) decimal.Decimal { return d("100") }
+
+	var got []string
+	for _, p := range liq.Prioritize(positions, mark) {
+		got = append(got, p.Symbol+":"+string(p.Side))
+	}
+	require.Equal(t, []string{"BTCUSDT:LONG", "ETHUSDT:LONG", "ETHUSDT:SHORT", "SOLUSDT:BOTH"}, got)
+}
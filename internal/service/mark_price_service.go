@@ -0,0 +1,209 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"oms-contract/internal/domain"
+	"oms-contract/internal/memory"
+	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
+)
+
+var (
+	// BasisSmoothing is the weight of each new basis sample in the moving average
+	BasisSmoothing = decimal.New(1, -1)
+	// MaxBasisRate caps the basis at this fraction of the index price
+	MaxBasisRate = decimal.New(5, -3)
+)
+
+var ErrNoIndexPrice = errors.New("no index source returned prices")
+
+// MarkPriceService derives the mark price of each symbol from its index.
+// Every refresh the index is the median across sources, and the basis is
+// a moving average of the gap between the last trade and the index,
+// decaying to zero while the symbol does not trade. Mark = index + basis,
+// with the basis capped at MaxBasisRate of the index, so a single
+// off-market print moves the mark by at most a fraction of that cap.
+type MarkPriceService struct {
+	book     *memory.MarkPriceBook
+	eventBus *snapshot.EventBus
+	sources  []IndexSource
+
+	mu        sync.Mutex
+	lastTrade map[string]decimal.Decimal // 本周期最后成交价，作为基差样本
+	listeners []func(*domain.MarkPrice)
+}
+
+func NewMarkPriceService(book *memory.MarkPriceBook, eb *snapshot.EventBus, sources ...IndexSource) *MarkPriceService {
+	return &MarkPriceService{
+		book:      book,
+		eventBus:  eb,
+		sources:   sources,
+		lastTrade: make(map[string]decimal.Decimal),
+	}
+}
+
+func (s *MarkPriceService) Get(symbol string) (*domain.MarkPrice, bool) {
+	return s.book.Get(symbol)
+}
+
+// Price returns the mark price of a symbol, if its index has been seen
+func (s *MarkPriceService) Price(symbol string) (decimal.Decimal, bool) {
+	m, ok := s.book.Get(symbol)
+	if !ok {
+		return decimal.Zero, false
+	}
+	return m.Price, true
+}
+
+// Subscribe registers fn to run after every mark price change, outside
+// the service lock so fn may read marks and trade
+func (s *MarkPriceService) Subscribe(fn func(*domain.MarkPrice)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, fn)
+}
+
+// OnTrade records a trade price as the next basis sample of its symbol
+func (s *MarkPriceService) OnTrade(symbol string, price decimal.Decimal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastTrade[symbol] = price
+}
+
+// Refresh polls every index source and updates the mark of each symbol
+// they cover. A failing source is skipped; the error is only returned
+// when no source answered.
+func (s *MarkPriceService) Refresh() ([]*domain.MarkPrice, error) {
+	quotes := make(map[string][]decimal.Decimal)
+	var errs []error
+	for _, src := range s.sources {
+		prices, err := src.IndexPrices()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for symbol, price := range prices {
+			if price > 0 {
+				quotes[symbol] = append(quotes[symbol], price)
+			}
+		}
+	}
+	if len(errs) > 0 && len(errs) == len(s.sources) {
+		return nil, fmt.Errorf("%w: %w", ErrNoIndexPrice, errors.Join(errs...))
+	}
+
+	symbols := make([]string, 0, len(quotes))
+	for symbol := range quotes {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	var updated []*domain.MarkPrice
+	for _, symbol := range symbols {
+		m, err := s.Update(symbol, median(quotes[symbol]))
+		if err != nil {
+			return updated, err
+		}
+		updated = append(updated, m)
+	}
+	return updated, nil
+}
+
+// Update moves a symbol's mark to a new index price, folding the last
+// trade since the previous update into the basis, and notifies subscribers
+// if the mark changed
+func (s *MarkPriceService) Update(symbol string, index decimal.Decimal) (*domain.MarkPrice, error) {
+	m, changed, err := s.update(symbol, index)
+	if err != nil || !changed {
+		return m, err
+	}
+
+	s.mu.Lock()
+	listeners := s.listeners
+	s.mu.Unlock()
+	for _, fn := range listeners {
+		fn(m)
+	}
+	return m, nil
+}
+
+func (s *MarkPriceService) update(symbol string, index decimal.Decimal) (*domain.MarkPrice, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var sample decimal.Decimal
+	if price, ok := s.lastTrade[symbol]; ok {
+		sample = price - index
+		delete(s.lastTrade, symbol)
+	}
+
+	var basis decimal.Decimal
+	cur, ok := s.book.Get(symbol)
+	if ok {
+		basis = cur.Basis
+	}
+	basis += (sample - basis).Mul(BasisSmoothing)
+	limit := index.Mul(MaxBasisRate)
+	basis = decimal.Max(-limit, decimal.Min(limit, basis))
+
+	if ok && cur.IndexPrice == index && cur.Basis == basis {
+		return cur, false, nil
+	}
+
+	m := &domain.MarkPrice{
+		Symbol:     symbol,
+		IndexPrice: index,
+		Basis:      basis,
+		Price:      index + basis,
+		UpdatedAt:  time.Now(),
+	}
+
+	event := snapshot.NewEvent(
+		0,
+		snapshot.EventMarkPriceUpdated,
+		snapshot.MarkPriceUpdatedData{MarkPrice: m},
+	)
+
+	if s.eventBus != nil {
+		if err := s.eventBus.Publish(event); err != nil {
+			return nil, false, fmt.Errorf("publish mark price updated event: %w", err)
+		}
+	} else {
+		s.book.Save(m)
+	}
+	return m, true, nil
+}
+
+// Run refreshes mark prices every interval until done is closed
+func (s *MarkPriceService) Run(interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.Refresh(); err != nil {
+				fmt.Printf("[OMS] mark price refresh failed: %v\n", err)
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+// median of a non-empty price list; the mean of the middle two for an even count
+func median(prices []decimal.Decimal) decimal.Decimal {
+	sorted := append([]decimal.Decimal(nil), prices...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]).Div(decimal.FromInt(2))
+	}
+	return sorted[mid]
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"testing"
+
+	"oms-contract/infra/index"
+	"oms-contract/internal/domain"
+	"oms-contract/internal/engine"
+	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkPriceService_Refresh(t *testing.T) {
+	svc, state, store := newTestOrderService(t)
+	d := decimal.MustParse
+	tick := func(price string) map[string]decimal.Decimal {
+		return map[string]decimal.Decimal{"BTCUSDT": d(price)}
+	}
+
+	// The third feed is off; the median ignores it
+	marks := NewMarkPriceService(state.MarkPriceBook, svc.eventBus,
+		index.NewReplaySource(tick("30000")),
+		index.NewReplaySource(tick("30100")),
+		index.NewReplaySource(tick("35000"), tick("30200")),
+	)
+	refresh := func(mark string) {
+		t.Helper()
+		updated, err := marks.Refresh()
+		require.NoError(t, err)
+		require.Len(t, updated, 1)
+		price, ok := marks.Price("BTCUSDT")
+		require.True(t, ok)
+		require.Equal(t, d(mark), price)
+	}
+
+	// No trade yet: mark is the index
+	refresh("30100")
+
+	// The last trade 500 over the index moves the basis a tenth of the way
+	marks.OnTrade("BTCUSDT", d("30400"))
+	marks.OnTrade("BTCUSDT", d("30600"))
+	refresh("30150")
+
+	// An off-market print is capped at 0.5% of the index
+	marks.OnTrade("BTCUSDT", d("40000"))
+	refresh("30250.5")
+
+	// Without trades the basis decays
+	refresh("30235.45")
+
+	snapMgr, err := snapshot.NewSnapshotManager(t.TempDir(), 1)
+	require.NoError(t, err)
+	replayed, err := snapshot.NewReplayEngine(store, snapMgr).Replay()
+	require.NoError(t, err)
+	require.Equal(t, state.MarkPriceBook.GetAll(), replayed.MarkPriceBook.GetAll())
+}
+
+func TestMarkPriceService_DrivesLiquidation(t *testing.T) {
+	svc, state, _ := newTestOrderService(t)
+	svc.matching = &engineGateway{engine: engine.NewMatchingEngine()}
+	svc.liquidator.matching = svc.matching
+	fund(t, svc, 100000, 100, 200)
+
+	place := func(user int64, side domain.Side, price int64, qty string) {
+		_, err := svc.CreateOrder(&domain.Order{UserID: user, Symbol: "BTCUSDT", Side: side, Type: domain.Limit,
+			Price: decimal.FromInt(price), Quantity: decimal.MustParse(qty)})
+		require.NoError(t, err)
+	}
+	qty := func() decimal.Decimal {
+		p, _ := state.PositionBook.Get(100, "BTCUSDT")
+		return p.Qty
+	}
+
+	_, err := svc.marks.Update("BTCUSDT", decimal.FromInt(30000))
+	require.NoError(t, err)
+
+	// 100 goes long 1 @ 30000 at 10x
+	place(200, domain.Sell, 30000, "1")
+	place(100, domain.Buy, 30000, "1")
+
+	// A print at 27000 would wipe out the margin, but the mark holds
+	place(200, domain.Buy, 27000, "1")
+	place(100, domain.Sell, 27000, "0.01")
+	require.Equal(t, decimal.MustParse("0.99"), qty())
+
+	// Once the index itself falls the position is liquidated
+	_, err = svc.marks.Update("BTCUSDT", decimal.FromInt(27000))
+	require.NoError(t, err)
+	require.True(t, qty().IsZero())
+}
@@ -1,7 +1,21 @@
 package service
 
-import "oms-contract/internal/domain"
+import (
+	"oms-contract/internal/domain"
+	"oms-contract/pkg/decimal"
+)
 
+// MatchingGateway is the OMS view of the matching engine.
+// Calls are synchronous: trades produced by a call are returned to the
+// caller, which feeds them back through OrderService.OnTrade.
 type MatchingGateway interface {
-	SendLiquidationOrder(order *domain.LiquidationOrder) error
+	// SubmitOrder matches the unfilled part of an order, resting any
+	// limit remainder on the book
+	SubmitOrder(order *domain.Order) ([]*domain.Trade, error)
+	SendLiquidationOrder(order *domain.LiquidationOrder) ([]*domain.Trade, error)
+	// CancelOrder pulls a resting order out of the matching book
+	CancelOrder(symbol string, orderID int64) error
+	// ReplaceOrder amends price and remaining quantity of a resting order,
+	// returning any trades produced if the new price crosses the book
+	ReplaceOrder(symbol string, orderID int64, price, remainingQty decimal.Decimal) ([]*domain.Trade, error)
 }
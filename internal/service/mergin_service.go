@@ -1,8 +1,231 @@
 package service
 
-import "oms-contract/internal/domain"
+import (
+	"errors"
+	"fmt"
+	"sync"
 
-type MarginService struct{}
+	"oms-contract/internal/domain"
+	"oms-contract/pkg/decimal"
+)
 
-func (m *MarginService) Freeze(o *domain.Order) error { return nil }
-func (m *MarginService) Settle(o *domain.Order)       {}
+var (
+	// DefaultLeverage applies until a user sets leverage for a symbol
+	DefaultLeverage = decimal.FromInt(10)
+	// EstimatedFeeRate is frozen on top of initial margin to cover the taker fee
+	EstimatedFeeRate = decimal.New(5, -4) // 0.05%
+
+	ErrNoReferencePrice  = errors.New("no reference price for market order")
+	ErrInvalidLeverage   = errors.New("leverage must be a whole number between 1 and the instrument max leverage")
+	ErrLeverageTooHigh   = errors.New("position margin would fall below maintenance margin")
+	ErrRiskLimitExceeded = errors.New("risk limit exceeded")
+	ErrInvalidRiskTier   = errors.New("invalid risk tier")
+)
+
+// MarginService freezes initial margin for open orders and owns the
+// leverage each user trades a symbol at.
+// A nil accounts service disables freezing.
+type MarginService struct {
+	accounts    *AccountService
+	instruments *InstrumentService
+	position    *PositionService
+	marks       *MarkPriceService
+
+	mu        sync.RWMutex
+	lastPrice map[string]decimal.Decimal // reference price for market orders
+}
+
+func NewMarginService(accounts *AccountService, instruments *InstrumentService, position *PositionService, marks *MarkPriceService) *MarginService {
+	return &MarginService{
+		accounts:    accounts,
+		instruments: instruments,
+		position:    position,
+		marks:       marks,
+		lastPrice:   make(map[string]decimal.Decimal),
+	}
+}
+
+// Leverage returns the user's leverage for a symbol: the one set on the
+// user's positions, else DefaultLeverage capped at the instrument max.
+// Both sides of a hedge-mode symbol share one leverage.
+func (m *MarginService) Leverage(userID int64, symbol string) decimal.Decimal {
+	// Sides of the current mode first, then a setting kept from the other mode
+	sides := append(m.position.Sides(userID), domain.PositionBoth, domain.PositionLong, domain.PositionShort)
+	for _, side := range sides {
+		if p, ok := m.position.GetSide(userID, symbol, side); ok && p.Leverage > 0 {
+			return p.Leverage
+		}
+	}
+	if m.instruments != nil {
+		if inst, ok := m.instruments.Get(symbol); ok {
+			return decimal.Min(DefaultLeverage, inst.MaxLeverage)
+		}
+	}
+	return DefaultLeverage
+}
+
+// SetLeverage changes a user's leverage for a symbol, on both sides in
+// hedge mode. Open positions are resized at their entry price: lowering
+// leverage draws the extra margin from available balance, raising it
+// releases margin only if every position stays above maintenance margin at
+// the mark price. Open orders keep the margin frozen at entry; the
+// difference is settled when they fill.
+func (m *MarginService) SetLeverage(userID int64, symbol string, leverage decimal.Decimal) ([]*domain.Position, error) {
+	if m.instruments == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSymbol, symbol)
+	}
+	inst, ok := m.instruments.Get(symbol)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSymbol, symbol)
+	}
+	if leverage < decimal.One || leverage > inst.MaxLeverage || !leverage.IsMultipleOf(decimal.One) {
+		return nil, fmt.Errorf("%w: leverage=%s max=%s", ErrInvalidLeverage, leverage, inst.MaxLeverage)
+	}
+
+	sides := m.position.Sides(userID)
+
+	var delta decimal.Decimal
+	for _, side := range sides {
+		p, ok := m.position.GetSide(userID, symbol, side)
+		if !ok || p.Qty.IsZero() {
+			continue
+		}
+		required := initialMargin(p.Qty, p.EntryPrice, leverage)
+
+		price, ok := m.markPrice(symbol)
+		if !ok {
+			price = p.EntryPrice
+		}
+		notional := p.Qty.Abs().Mul(price)
+		n, tier, _ := inst.TierFor(notional)
+		if leverage > tier.MaxLeverage {
+			return nil, fmt.Errorf("%w: %s notional=%s is in tier %d, max leverage=%s",
+				ErrRiskLimitExceeded, side, notional, n, tier.MaxLeverage)
+		}
+
+		if required < p.Margin {
+			equity := required + (price - p.EntryPrice).Mul(p.Qty)
+			maintenance := tier.MaintenanceMargin(notional)
+			if equity <= maintenance {
+				return nil, fmt.Errorf("%w: %s margin=%s maintenance=%s", ErrLeverageTooHigh, side, equity, maintenance)
+			}
+		}
+		delta += required - p.Margin
+	}
+
+	if m.accounts != nil {
+		if err := m.accounts.AdjustMargin(userID, inst.SettleAsset, delta); err != nil {
+			return nil, err
+		}
+	}
+
+	positions := make([]*domain.Position, 0, len(sides))
+	for _, side := range sides {
+		positions = append(positions, m.position.SetLeverage(userID, symbol, side, leverage))
+	}
+	return positions, nil
+}
+
+// Required returns the margin to freeze for the unfilled part of an order:
+// initial margin on the quantity that would open or add to a position, plus
+// the estimated fee on the whole notional. The part of a sell (buy) that
+// can close an existing long (short) on the order's position side needs
+// no initial margin.
+func (m *MarginService) Required(o *domain.Order) (decimal.Decimal, error) {
+	price := o.Price
+	if o.Type == domain.Market {
+		var ok bool
+		if price, ok = m.referencePrice(o.Symbol); !ok {
+			return decimal.Zero, ErrNoReferencePrice
+		}
+	}
+
+	qty := o.Quantity - o.FilledQty
+	openQty := qty
+	if p, ok := m.position.GetSide(o.UserID, o.Symbol, o.PositionSide); ok && p.Qty.Sign() == -signedQty(o.Side, qty).Sign() {
+		openQty = decimal.Max(decimal.Zero, qty-p.Qty.Abs())
+	}
+
+	margin := initialMargin(openQty, price, m.Leverage(o.UserID, o.Symbol))
+	fee := qty.Mul(price).Mul(EstimatedFeeRate)
+	return margin + fee, nil
+}
+
+// Freeze reserves the order's required margin and records it on the order
+func (m *MarginService) Freeze(o *domain.Order, asset string) error {
+	if m.accounts == nil {
+		return nil
+	}
+
+	amount, err := m.Required(o)
+	if err != nil {
+		return err
+	}
+	if err := m.accounts.Freeze(o.UserID, asset, amount, o.ID); err != nil {
+		return err
+	}
+	o.FrozenMargin = amount
+	return nil
+}
+
+// Amended returns the margin an order needs after an amend to price and
+// total quantity
+func (m *MarginService) Amended(o *domain.Order, price, quantity decimal.Decimal) (decimal.Decimal, error) {
+	if m.accounts == nil {
+		return decimal.Zero, nil
+	}
+
+	amended := *o
+	amended.Price = price
+	amended.Quantity = quantity
+	return m.Required(&amended)
+}
+
+// Refreeze freezes or releases the difference when an order's frozen
+// margin changes from one amount to another
+func (m *MarginService) Refreeze(o *domain.Order, asset string, from, to decimal.Decimal) error {
+	if m.accounts == nil {
+		return nil
+	}
+
+	switch delta := to - from; {
+	case delta > 0:
+		return m.accounts.Freeze(o.UserID, asset, delta, o.ID)
+	case delta < 0:
+		return m.accounts.Unfreeze(o.UserID, asset, -delta, o.ID)
+	}
+	return nil
+}
+
+// Release returns frozen margin of an order that will not trade any further
+func (m *MarginService) Release(o *domain.Order, asset string, amount decimal.Decimal) error {
+	if m.accounts == nil {
+		return nil
+	}
+	return m.accounts.Unfreeze(o.UserID, asset, amount, o.ID)
+}
+
+// OnTrade records the last trade price used to size market orders
+func (m *MarginService) OnTrade(symbol string, price decimal.Decimal) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastPrice[symbol] = price
+}
+
+func (m *MarginService) referencePrice(symbol string) (decimal.Decimal, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	p, ok := m.lastPrice[symbol]
+	return p, ok
+}
+
+// markPrice is the price positions of a symbol are valued at: the mark
+// price, or the last trade price for a symbol no index source covers yet
+func (m *MarginService) markPrice(symbol string) (decimal.Decimal, bool) {
+	if m.marks != nil {
+		if price, ok := m.marks.Price(symbol); ok {
+			return price, true
+		}
+	}
+	return m.referencePrice(symbol)
+}
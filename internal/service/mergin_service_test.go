@@ -0,0 +1,134 @@
+package service
+
+import (
+	"testing"
+
+	"oms-contract/internal/domain"
+	"oms-contract/internal/engine"
+	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarginService_OrderLifecycle(t *testing.T) {
+	svc, state, store := newTestOrderService(t)
+	svc.matching = &engineGateway{engine: engine.NewMatchingEngine()}
+	fund(t, svc, 10000, 100, 200)
+
+	place := func(user int64, side domain.Side, qty int64) (*domain.Order, error) {
+		o := &domain.Order{UserID: user, Symbol: "BTCUSDT", Side: side, Type: domain.Limit,
+			Price: decimal.FromInt(30000), Quantity: decimal.FromInt(qty)}
+		_, err := svc.CreateOrder(o)
+		return o, err
+	}
+	balances := func(user int64, available, frozen, margin string) {
+		t.Helper()
+		a, _ := svc.accounts.Get(user, "USDT")
+		require.Equal(t, decimal.MustParse(available), a.Available, "available")
+		require.Equal(t, decimal.MustParse(frozen), a.Frozen, "frozen")
+		require.Equal(t, decimal.MustParse(margin), a.Margin, "margin")
+	}
+
+	// 2 @ 30000 at 10x freezes 6000 margin plus 30 estimated fee
+	bid, err := place(100, domain.Buy, 2)
+	require.NoError(t, err)
+	require.Equal(t, decimal.FromInt(6030), bid.FrozenMargin)
+	balances(100, "3970", "6030", "0")
+
+	// Half fills: half the frozen margin moves to the position, the fee estimate returns
+	_, err = place(200, domain.Sell, 1)
+	require.NoError(t, err)
+	balances(100, "3985", "3015", "3000")
+
+	// Cancel releases the rest
+	require.NoError(t, svc.CancelOrder(bid.ID, 100))
+	balances(100, "7000", "0", "3000")
+
+	// Not enough available for another 3
+	o, err := place(100, domain.Buy, 3)
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+	require.Equal(t, domain.Rejected, o.Status)
+	balances(100, "7000", "0", "3000")
+
+	// Closing the long only needs the fee estimate
+	ask, err := place(100, domain.Sell, 1)
+	require.NoError(t, err)
+	require.Equal(t, decimal.FromInt(15), ask.FrozenMargin)
+
+	require.True(t, ledgerSum(state, "USDT").IsZero())
+
+	snapMgr, err := snapshot.NewSnapshotManager(t.TempDir(), 1)
+	require.NoError(t, err)
+	replayed, err := snapshot.NewReplayEngine(store, snapMgr).Replay()
+	require.NoError(t, err)
+	require.Equal(t, state.AccountBook.State(), replayed.AccountBook.State())
+
+	booked, _ := replayed.OrderBook.Get(ask.ID)
+	require.Equal(t, decimal.FromInt(15), booked.FrozenMargin)
+}
+
+func TestMarginService_SetLeverage(t *testing.T) {
+	svc, state, store := newTestOrderService(t)
+	svc.matching = &engineGateway{engine: engine.NewMatchingEngine()}
+	fund(t, svc, 100000, 100, 200, 300, 400)
+
+	place := func(user int64, side domain.Side, price int64) *domain.Order {
+		o := &domain.Order{UserID: user, Symbol: "BTCUSDT", Side: side, Type: domain.Limit,
+			Price: decimal.FromInt(price), Quantity: decimal.FromInt(1)}
+		_, err := svc.CreateOrder(o)
+		require.NoError(t, err)
+		return o
+	}
+	margin := func(user int64) decimal.Decimal {
+		a, _ := svc.accounts.Get(user, "USDT")
+		return a.Margin
+	}
+
+	_, err := svc.SetLeverage(100, "FOOUSDT", decimal.FromInt(10))
+	require.ErrorIs(t, err, ErrUnknownSymbol)
+	for _, lev := range []string{"0", "101", "2.5"} {
+		_, err := svc.SetLeverage(100, "BTCUSDT", decimal.MustParse(lev))
+		require.ErrorIs(t, err, ErrInvalidLeverage, lev)
+	}
+
+	// Leverage set while flat sizes the next order and position
+	_, err = svc.SetLeverage(400, "BTCUSDT", decimal.FromInt(20))
+	require.NoError(t, err)
+	require.Equal(t, decimal.FromInt(1515), place(400, domain.Buy, 30000).FrozenMargin)
+	place(300, domain.Sell, 30000)
+	require.Equal(t, decimal.FromInt(1500), margin(400))
+
+	// 100 goes long 1 @ 30000 at the default 10x, then the price drops to 29800
+	place(200, domain.Sell, 30000)
+	place(100, domain.Buy, 30000)
+	require.Equal(t, decimal.FromInt(3000), margin(100))
+	place(300, domain.Sell, 29800)
+	place(200, domain.Buy, 29800)
+
+	// 100x leaves 300 margin against a 200 loss: below maintenance
+	_, err = svc.SetLeverage(100, "BTCUSDT", decimal.FromInt(100))
+	require.ErrorIs(t, err, ErrLeverageTooHigh)
+
+	positions, err := svc.SetLeverage(100, "BTCUSDT", decimal.FromInt(50))
+	require.NoError(t, err)
+	require.Len(t, positions, 1)
+	require.Equal(t, decimal.FromInt(600), positions[0].Margin)
+	require.Equal(t, decimal.FromInt(600), margin(100))
+
+	_, err = svc.SetLeverage(100, "BTCUSDT", decimal.FromInt(5))
+	require.NoError(t, err)
+	require.Equal(t, decimal.FromInt(6000), margin(100))
+	require.True(t, ledgerSum(state, "USDT").IsZero())
+
+	snapMgr, err := snapshot.NewSnapshotManager(t.TempDir(), 1)
+	require.NoError(t, err)
+	replayed, err := snapshot.NewReplayEngine(store, snapMgr).Replay()
+	require.NoError(t, err)
+	require.Equal(t, state.AccountBook.State(), replayed.AccountBook.State())
+
+	rp, ok := replayed.PositionBook.Get(100, "BTCUSDT")
+	require.True(t, ok)
+	require.Equal(t, decimal.FromInt(5), rp.Leverage)
+	require.Equal(t, decimal.FromInt(6000), rp.Margin)
+}
@@ -1,51 +1,150 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"oms-contract/internal/domain"
 	"oms-contract/internal/memory"
 	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
 	"oms-contract/pkg/idgen"
 )
 
+var (
+	ErrOrderNotFound  = errors.New("order not found")
+	ErrOrderNotOwned  = errors.New("order does not belong to user")
+	ErrOrderNotActive = errors.New("order is no longer active")
+	ErrInvalidAmend   = errors.New("invalid amend: price must be positive and quantity must exceed filled quantity")
+
+	ErrInvalidPositionSide  = errors.New("position side does not match position mode")
+	ErrCloseExceedsPosition = errors.New("close quantity exceeds position")
+	ErrReduceOnly           = errors.New("reduce-only order would open or increase a position")
+	ErrPositionLiquidating  = errors.New("position is being liquidated: only orders reducing it are accepted")
+)
+
 type OrderService struct {
-	book     *memory.OrderBook
-	risk     *RiskService
-	margin   *MarginService
-	eventBus *snapshot.EventBus
-	idGen    *idgen.Generator
+	mu sync.Mutex // serializes order flow so fills apply in matching order
+
+	book        *memory.OrderBook
+	instruments *InstrumentService
+	accounts    *AccountService
+	risk        *RiskService
+	margin      *MarginService
+	marks       *MarkPriceService
+	matching    MatchingGateway
+	eventBus    *snapshot.EventBus
+	idGen       *idgen.Generator
 
 	position   *PositionService // ✅ 必须有
 	liquidator *LiquidationService
+	insurance  *InsuranceService
+	adl        *ADLService
+	funding    *FundingService
+	fees       *FeeService
+	stops      *StopService
+
+	traded map[string]priceRange // trade prices since stops were last checked
+}
+
+// priceRange is the lowest and highest trade price of a symbol
+type priceRange struct {
+	low, high decimal.Decimal
 }
 
 func NewOrderService(book *memory.OrderBook,
+	instruments *InstrumentService,
+	accounts *AccountService,
 	pos *PositionService,
 	liq *LiquidationService,
+	marks *MarkPriceService,
+	funding *FundingService,
+	fees *FeeService,
+	risk *RiskService,
+	stops *StopService,
+	matching MatchingGateway,
 	eb *snapshot.EventBus,
 	idGen *idgen.Generator) *OrderService {
-	return &OrderService{
-		book:       book,
-		risk:       &RiskService{},
-		margin:     &MarginService{},
-		position:   pos,
-		liquidator: liq,
-		eventBus:   eb,
-		idGen:      idGen,
+	s := &OrderService{
+		book:        book,
+		instruments: instruments,
+		accounts:    accounts,
+		risk:        risk,
+		margin:      NewMarginService(accounts, instruments, pos, marks),
+		marks:       marks,
+		matching:    matching,
+		position:    pos,
+		liquidator:  liq,
+		insurance:   NewInsuranceService(accounts),
+		adl:         NewADLService(pos, eb, idGen),
+		funding:     funding,
+		fees:        fees,
+		stops:       stops,
+		traded:      make(map[string]priceRange),
+		eventBus:    eb,
+		idGen:       idGen,
+	}
+	// Market orders and last-price stops are priced off the last trade,
+	// which a restart restores from the trade book
+	if fees != nil {
+		for symbol, price := range fees.trades.LastPrices() {
+			s.margin.OnTrade(symbol, price)
+		}
 	}
+	if marks != nil {
+		marks.Subscribe(func(m *domain.MarkPrice) { s.OnMarkPrice(m.Symbol) })
+	}
+	return s
+}
+
+// CreateOrder validates an order, records it and submits it for matching.
+// A rejected order is marked domain.Rejected and the reason is returned;
+// otherwise o reflects the status after matching. A close-position order
+// is sized here, so its quantity may be left zero.
+func (s *OrderService) CreateOrder(o *domain.Order) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.triggerStops("")
+
+	return s.createOrder(o)
 }
 
-func (s *OrderService) CreateOrder(o *domain.Order) int64 {
-	if err := s.risk.Check(o); err != nil {
+func (s *OrderService) createOrder(o *domain.Order) (int64, error) {
+	if err := s.checkPositionSide(o); err != nil {
 		o.Status = domain.Rejected
-		return 0
+		return 0, reject(domain.RejectPositionSide, err)
+	}
+	if o.ClosePosition {
+		o.ReduceOnly = true
+		if s.risk != nil {
+			o.Quantity = s.risk.Closable(o)
+		}
+		if o.Quantity <= 0 {
+			o.Status = domain.Rejected
+			return 0, reject(domain.RejectReduceOnly, fmt.Errorf("%w: no %s %s position left to close", ErrReduceOnly, o.Symbol, o.PositionSide))
+		}
+	}
+	if s.instruments != nil {
+		if err := s.instruments.ValidateOrder(o); err != nil {
+			o.Status = domain.Rejected
+			return 0, reject(domain.RejectInstrument, err)
+		}
+	}
+	if err := s.checkRisk(o, o.Price, o.Quantity); err != nil {
+		o.Status = domain.Rejected
+		return 0, err
 	}
-
-	_ = s.margin.Freeze(o)
 
 	o.ID = s.idGen.Next()
+
+	if err := s.margin.Freeze(o, s.settleAsset(o.Symbol)); err != nil {
+		o.Status = domain.Rejected
+		return 0, reject(domain.RejectMargin, err)
+	}
+
 	o.Status = domain.Submitted
 	o.CreatedAt = time.Now()
 
@@ -72,39 +171,1038 @@ func (s *OrderService) CreateOrder(o *domain.Order) int64 {
 		// Given strict event sourcing, direct manipulation breaks pattern.
 		// But for legacy tests...
 		s.book.Add(o)
+		if o.StopID != 0 && s.stops != nil {
+			s.stops.book.Activate(o.StopID, o.ID)
+		}
 	}
 
 	fmt.Printf("[OMS] order submitted: %+v\n", o)
-	return o.ID
+
+	// The book holds its own copy once the event is applied
+	booked, ok := s.book.Get(o.ID)
+	if !ok || s.matching == nil {
+		return o.ID, nil
+	}
+
+	trades, err := s.matching.SubmitOrder(booked)
+	if err != nil {
+		_ = s.publishCanceled(booked, "MATCHING_REJECTED")
+		o.Status = booked.Status
+		return 0, fmt.Errorf("submit order to matching: %w", err)
+	}
+
+	for _, t := range trades {
+		s.onTrade(t)
+	}
+
+	// Market and IOC remainders never rest on the book
+	if booked.Type != domain.Limit && booked.IsActive() {
+		if err := s.publishCanceled(booked, "EXPIRED"); err != nil {
+			fmt.Printf("[OMS] failed to expire order %d: %v\n", booked.ID, err)
+		}
+	}
+
+	o.FilledQty = booked.FilledQty
+	o.Status = booked.Status
+	return o.ID, nil
 }
 
+// CancelOrder removes a resting order from the matching book and marks it CANCELED
+func (s *OrderService) CancelOrder(orderID, userID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.book.Get(orderID)
+	if !ok {
+		return s.cancelStop(orderID, userID)
+	}
+	if o.UserID != userID {
+		return ErrOrderNotOwned
+	}
+	if !o.IsActive() {
+		return ErrOrderNotActive
+	}
+
+	if s.matching != nil {
+		if err := s.matching.CancelOrder(o.Symbol, o.ID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.publishCanceled(o, "USER"); err != nil {
+		return err
+	}
+
+	fmt.Printf("[OMS] order canceled: id=%d user=%d\n", o.ID, o.UserID)
+	return nil
+}
+
+// publishCanceled ends an order and releases the margin it still has frozen
+func (s *OrderService) publishCanceled(o *domain.Order, reason string) error {
+	frozen := o.FrozenMargin
+
+	event := snapshot.NewEvent(
+		0,
+		snapshot.EventOrderCanceled,
+		snapshot.OrderCanceledData{
+			OrderID: o.ID,
+			UserID:  o.UserID,
+			Symbol:  o.Symbol,
+			Reason:  reason,
+		},
+	)
+
+	if s.eventBus != nil {
+		if err := s.eventBus.Publish(event); err != nil {
+			return fmt.Errorf("publish order canceled event: %w", err)
+		}
+	} else {
+		o.Cancel()
+	}
+
+	return s.margin.Release(o, s.settleAsset(o.Symbol), frozen)
+}
+
+// ReplaceOrder amends price and total quantity of a resting order (cancel-replace).
+// A quantity reduction at the same price keeps time priority; a price change
+// or quantity increase loses it and the order gets a new CreatedAt.
+func (s *OrderService) ReplaceOrder(orderID, userID int64, price, quantity decimal.Decimal) (*domain.Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.triggerStops("")
+
+	o, ok := s.book.Get(orderID)
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+	if o.UserID != userID {
+		return nil, ErrOrderNotOwned
+	}
+	if !o.IsActive() {
+		return nil, ErrOrderNotActive
+	}
+	if price <= 0 || quantity <= o.FilledQty {
+		return nil, ErrInvalidAmend
+	}
+	if s.instruments != nil {
+		amended := *o
+		amended.Price, amended.Quantity = price, quantity
+		if err := s.instruments.ValidateOrder(&amended); err != nil {
+			return nil, reject(domain.RejectInstrument, err)
+		}
+	}
+	if err := s.checkRisk(o, price, quantity); err != nil {
+		return nil, err
+	}
+
+	createdAt := o.CreatedAt
+	if price != o.Price || quantity > o.Quantity {
+		createdAt = time.Now()
+	}
+
+	asset := s.settleAsset(o.Symbol)
+	frozen, err := s.margin.Amended(o, price, quantity)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.margin.Refreeze(o, asset, o.FrozenMargin, frozen); err != nil {
+		return nil, err
+	}
+
+	var trades []*domain.Trade
+	if s.matching != nil {
+		trades, err = s.matching.ReplaceOrder(o.Symbol, o.ID, price, quantity-o.FilledQty)
+		if err != nil {
+			_ = s.margin.Refreeze(o, asset, frozen, o.FrozenMargin)
+			return nil, err
+		}
+	}
+
+	event := snapshot.NewEvent(
+		0,
+		snapshot.EventOrderReplaced,
+		snapshot.OrderReplacedData{
+			OrderID:   o.ID,
+			UserID:    o.UserID,
+			Symbol:    o.Symbol,
+			Price:     price,
+			Quantity:  quantity,
+			CreatedAt: createdAt,
+
+			FrozenMargin: frozen,
+		},
+	)
+
+	if s.eventBus != nil {
+		if err := s.eventBus.Publish(event); err != nil {
+			return nil, fmt.Errorf("publish order replaced event: %w", err)
+		}
+	} else {
+		o.Price = price
+		o.Quantity = quantity
+		o.CreatedAt = createdAt
+		o.FrozenMargin = frozen
+	}
+
+	fmt.Printf("[OMS] order replaced: id=%d price=%s qty=%s\n", o.ID, price, quantity)
+
+	for _, t := range trades {
+		s.onTrade(t)
+	}
+	return o, nil
+}
+
+// SetLeverage changes a user's leverage for a symbol, moving position
+// margin to match, and returns the positions it applies to
+func (s *OrderService) SetLeverage(userID int64, symbol string, leverage decimal.Decimal) ([]*domain.Position, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, side := range s.position.Sides(userID) {
+		if p, ok := s.position.GetSide(userID, symbol, side); ok && p.Liquidating() {
+			return nil, fmt.Errorf("%w: %s %s", ErrPositionLiquidating, symbol, side)
+		}
+	}
+
+	positions, err := s.margin.SetLeverage(userID, symbol, leverage)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[OMS] leverage set: user=%d symbol=%s leverage=%s\n", userID, symbol, leverage)
+	return positions, nil
+}
+
+// RiskLimit returns a symbol's risk tiers, the tier the user has capped
+// its positions at (0 when uncapped) and the tier its largest position
+// falls in at the mark price
+func (s *OrderService) RiskLimit(userID int64, symbol string) (tiers []domain.RiskTier, selected, current int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inst, ok := s.instrument(symbol)
+	if !ok {
+		return nil, 0, 0, fmt.Errorf("%w: %s", ErrUnknownSymbol, symbol)
+	}
+	current, _, _ = inst.TierFor(s.positionNotional(userID, symbol))
+	return inst.Tiers(), s.position.RiskLimit(userID, symbol), current, nil
+}
+
+// SetRiskLimit caps the notional a user may hold in a symbol at a risk
+// tier, 0 lifts the cap. The user's positions must already fit the tier.
+func (s *OrderService) SetRiskLimit(userID int64, symbol string, tier int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inst, ok := s.instrument(symbol)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownSymbol, symbol)
+	}
+	if tier < 0 || tier > len(inst.Tiers()) {
+		return fmt.Errorf("%w: %d of %d", ErrInvalidRiskTier, tier, len(inst.Tiers()))
+	}
+	if tier > 0 {
+		notional := s.positionNotional(userID, symbol)
+		if current, _, _ := inst.TierFor(notional); current > tier {
+			return fmt.Errorf("%w: notional=%s is in tier %d", ErrRiskLimitExceeded, notional, current)
+		}
+	}
+	if err := s.position.SetRiskLimit(userID, symbol, tier); err != nil {
+		return err
+	}
+
+	fmt.Printf("[OMS] risk limit set: user=%d symbol=%s tier=%d\n", userID, symbol, tier)
+	return nil
+}
+
+// SetPositionMode switches a user between one-way and hedge mode.
+// The user must have no open orders or positions.
+func (s *OrderService) SetPositionMode(userID int64, mode domain.PositionMode) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if orders := s.book.GetActiveByUser(userID); len(orders) > 0 {
+		return fmt.Errorf("%w: %d open orders", ErrPositionModeChange, len(orders))
+	}
+	if err := s.position.SetMode(userID, mode); err != nil {
+		return err
+	}
+
+	fmt.Printf("[OMS] position mode set: user=%d mode=%s\n", userID, mode)
+	return nil
+}
+
+// SetMarginMode switches a user between isolated and cross margin.
+// The user must have no open orders or positions.
+func (s *OrderService) SetMarginMode(userID int64, mode domain.MarginMode) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if orders := s.book.GetActiveByUser(userID); len(orders) > 0 {
+		return fmt.Errorf("%w: %d open orders", ErrMarginModeChange, len(orders))
+	}
+	if err := s.position.SetMarginMode(userID, mode); err != nil {
+		return err
+	}
+
+	fmt.Printf("[OMS] margin mode set: user=%d mode=%s\n", userID, mode)
+	return nil
+}
+
+// checkPositionSide defaults an order's position side for the user's mode;
+// in hedge mode LONG or SHORT is required
+func (s *OrderService) checkPositionSide(o *domain.Order) error {
+	if s.position.Mode(o.UserID) != domain.HedgeMode {
+		if o.PositionSide == "" {
+			o.PositionSide = domain.PositionBoth
+		}
+		if o.PositionSide != domain.PositionBoth {
+			return fmt.Errorf("%w: %s in one-way mode", ErrInvalidPositionSide, o.PositionSide)
+		}
+		return nil
+	}
+
+	if o.PositionSide != domain.PositionLong && o.PositionSide != domain.PositionShort {
+		return fmt.Errorf("%w: hedge mode requires LONG or SHORT", ErrInvalidPositionSide)
+	}
+	return nil
+}
+
+// OnTrade applies a fill reported by the matching engine
 func (s *OrderService) OnTrade(t *domain.Trade) {
-	o, ok := s.book.Get(t.OrderID)
-	if ok {
-		// 普通订单成交
-		o.FilledQty += t.Qty
-		if o.FilledQty >= o.Quantity {
-			o.Status = domain.Filled
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.onTrade(t)
+	s.triggerStops("")
+}
+
+func (s *OrderService) onTrade(t *domain.Trade) {
+	s.applyTrade(t)
+
+	// 成交后立即做强平检查
+	s.checkLiquidation(t.UserID, t.Symbol)
+}
+
+// applyTrade books a fill to the order, position and ledger
+func (s *OrderService) applyTrade(t *domain.Trade) {
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = time.Now()
+	}
+	asset := s.settleAsset(t.Symbol)
+
+	// Margin the fill releases from the order, read before the fill applies.
+	// Only fills of user orders pay fees and count toward VIP volume:
+	// liquidation closes and ADL are settled against the bankruptcy price,
+	// which leaves no room for a fee, and are not the user's trading.
+	var unfrozen decimal.Decimal
+	if o, ok := s.book.Get(t.OrderID); ok {
+		unfrozen = o.FrozenFor(t.Qty)
+		if s.fees != nil {
+			t.Fee, t.FeeAsset = s.fees.Fee(t), asset
 		}
+	} else {
+		t.Forced = true
+	}
+	s.margin.OnTrade(t.Symbol, t.Price)
+	if s.marks != nil {
+		s.marks.OnTrade(t.Symbol, t.Price)
+	}
+	if r, ok := s.traded[t.Symbol]; ok {
+		s.traded[t.Symbol] = priceRange{decimal.Min(r.low, t.Price), decimal.Max(r.high, t.Price)}
+	} else {
+		s.traded[t.Symbol] = priceRange{t.Price, t.Price}
+	}
+
+	event := snapshot.NewEvent(
+		0,
+		snapshot.EventTradeExecuted,
+		snapshot.TradeExecutedData{Trade: t},
+	)
+
+	if s.eventBus != nil {
+		if err := s.eventBus.Publish(event); err != nil {
+			fmt.Printf("[OMS] failed to publish trade executed event: %v\n", err)
+		}
+	} else if o, ok := s.book.Get(t.OrderID); ok {
+		// 普通订单成交
+		o.Fill(t.Qty)
 	}
 
 	// 更新仓位（正负 qty）
-	s.position.OnTrade(
+	change := s.position.OnTrade(
 		t.UserID,
 		t.Symbol,
-		signedQty(o.Side, t.Qty),
+		t.PositionSide,
+		signedQty(t.Side, t.Qty),
 		t.Price,
-		10,
+		s.margin.Leverage(t.UserID, t.Symbol),
 	)
 
-	// 成交后立即做强平检查
-	p, ok := s.position.Get(t.UserID, t.Symbol)
-	if ok && s.liquidator.Check(p, t.Price) {
-		s.liquidator.Execute(p)
+	// 保证金与已实现盈亏入账
+	if s.accounts != nil {
+		if err := s.accounts.SettleTrade(t.UserID, asset, t.TradeID, unfrozen, change, t.Fee); err != nil {
+			fmt.Printf("[OMS] failed to settle trade %d: %v\n", t.TradeID, err)
+		}
+	}
+
+	s.trimReduceOnly(t.UserID, t.Symbol, t.PositionSide)
+}
+
+// PlaceStopOrder validates a stop order as the order it becomes and
+// records it until its trigger price reaches the stop price
+func (s *OrderService) PlaceStopOrder(stop *domain.StopOrder) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stops == nil {
+		return 0, fmt.Errorf("%w: stop orders are not enabled", ErrInvalidStopOrder)
+	}
+	o := stop.Order()
+	if err := s.checkPositionSide(o); err != nil {
+		return 0, reject(domain.RejectPositionSide, err)
+	}
+	stop.PositionSide = o.PositionSide
+	if s.instruments != nil {
+		validate := s.instruments.ValidateOrder
+		// A close-position stop is sized when it triggers
+		if stop.ClosePosition {
+			validate = s.instruments.ValidatePrice
+		}
+		if err := validate(o); err != nil {
+			return 0, reject(domain.RejectInstrument, err)
+		}
+	}
+
+	var current decimal.Decimal
+	if stop.TriggerBy == domain.TriggerMarkPrice {
+		current, _ = s.margin.markPrice(stop.Symbol)
+	} else {
+		current, _ = s.margin.referencePrice(stop.Symbol)
+	}
+	if err := s.stops.Place(stop, current); err != nil {
+		switch {
+		case errors.Is(err, ErrUnknownSymbol):
+			return 0, reject(domain.RejectInstrument, err)
+		case errors.Is(err, ErrInvalidStopOrder),
+			errors.Is(err, ErrStopWouldTrigger),
+			errors.Is(err, ErrInvalidTickSize):
+			return 0, reject(domain.RejectStopPrice, err)
+		}
+		return 0, err
+	}
+	return stop.ID, nil
+}
+
+// StopOrders returns a user's pending stop orders, optionally for one symbol
+func (s *OrderService) StopOrders(userID int64, symbol string) []*domain.StopOrder {
+	if s.stops == nil {
+		return nil
+	}
+	return s.stops.Pending(userID, symbol)
+}
+
+// cancelStop cancels a pending stop order by its ID
+func (s *OrderService) cancelStop(stopID, userID int64) error {
+	if s.stops == nil {
+		return ErrOrderNotFound
+	}
+	stop, ok := s.stops.Get(stopID)
+	if !ok {
+		return ErrOrderNotFound
+	}
+	if stop.UserID != userID {
+		return ErrOrderNotOwned
+	}
+	if !stop.IsPending() {
+		return ErrOrderNotActive
+	}
+	return s.stops.Cancel(stop, "USER")
+}
+
+// triggerStops submits every stop order the trades since the last call,
+// or the mark price of markSymbol, have reached. Orders trigger one at a
+// time and their own fills count, so stops can cascade.
+func (s *OrderService) triggerStops(markSymbol string) {
+	defer func() { s.traded = make(map[string]priceRange) }()
+	if s.stops == nil {
+		return
+	}
+
+	for {
+		stop, price, ok := s.nextStop(markSymbol)
+		if !ok {
+			return
+		}
+		if err := s.fireStop(stop, price); err != nil {
+			fmt.Printf("[OMS] failed to trigger stop order %d: %v\n", stop.ID, err)
+			return
+		}
+	}
+}
+
+// fireStop triggers a stop order and submits its order. An accepted order
+// activates the stop order in its own ORDER_CREATED event; a rejected one
+// is recorded on the stop order.
+func (s *OrderService) fireStop(stop *domain.StopOrder, price decimal.Decimal) error {
+	if err := s.stops.Trigger(stop, price); err != nil {
+		return err
+	}
+	if _, err := s.createOrder(stop.Order()); err != nil {
+		return s.stops.Reject(stop, err)
+	}
+	return nil
+}
+
+// nextStop finds the next stop order to trigger: last price stops of the
+// symbols that traded, in symbol order, then mark price stops of markSymbol
+func (s *OrderService) nextStop(markSymbol string) (*domain.StopOrder, decimal.Decimal, bool) {
+	symbols := make([]string, 0, len(s.traded))
+	for symbol := range s.traded {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	for _, symbol := range symbols {
+		r := s.traded[symbol]
+		if stop, price, ok := s.stops.Next(symbol, domain.TriggerLastPrice, r.low, r.high); ok {
+			return stop, price, true
+		}
+	}
+
+	if markSymbol != "" && s.marks != nil {
+		if mark, ok := s.marks.Price(markSymbol); ok {
+			return s.stops.Next(markSymbol, domain.TriggerMarkPrice, mark, mark)
+		}
+	}
+	return nil, decimal.Zero, false
+}
+
+// trimReduceOnly keeps a user's resting reduce-only orders on a position
+// within what is left of it after a fill. In time priority, each order
+// keeps what the position still covers and is cut down to it, keeping its
+// place in the queue; one the position no longer covers, or no longer
+// trades against, is canceled.
+func (s *OrderService) trimReduceOnly(userID int64, symbol string, side domain.PositionSide) {
+	var orders []*domain.Order
+	for _, o := range s.book.GetActiveByUser(userID) {
+		if o.ReduceOnly && o.Symbol == symbol && o.PositionSide == side {
+			orders = append(orders, o)
+		}
+	}
+	if len(orders) == 0 {
+		return
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		if !orders[i].CreatedAt.Equal(orders[j].CreatedAt) {
+			return orders[i].CreatedAt.Before(orders[j].CreatedAt)
+		}
+		return orders[i].ID < orders[j].ID
+	})
+
+	var pos decimal.Decimal
+	if p, ok := s.position.GetSide(userID, symbol, side); ok {
+		pos = p.Qty
+	}
+	left := pos.Abs()
+	for _, o := range orders {
+		remaining := o.Quantity - o.FilledQty
+		if signedQty(o.Side, decimal.One).Sign() == pos.Sign() {
+			left = decimal.Zero // the position flipped: nothing left to reduce
+		}
+		switch {
+		case left.IsZero():
+			if s.matching != nil {
+				if err := s.matching.CancelOrder(o.Symbol, o.ID); err != nil {
+					fmt.Printf("[OMS] failed to cancel reduce-only order %d: %v\n", o.ID, err)
+					continue
+				}
+			}
+			if err := s.publishCanceled(o, "REDUCE_ONLY"); err != nil {
+				fmt.Printf("[OMS] failed to cancel reduce-only order %d: %v\n", o.ID, err)
+			}
+		case remaining > left:
+			if err := s.shrink(o, o.FilledQty+left); err != nil {
+				fmt.Printf("[OMS] failed to trim reduce-only order %d: %v\n", o.ID, err)
+			}
+			left = decimal.Zero
+		default:
+			left -= remaining
+		}
+	}
+}
+
+// shrink cuts a resting order's total quantity down to quantity at the
+// same price, which keeps its time priority, and releases the margin the
+// cut frees
+func (s *OrderService) shrink(o *domain.Order, quantity decimal.Decimal) error {
+	frozen, err := s.margin.Amended(o, o.Price, quantity)
+	if err != nil {
+		return err
+	}
+	if s.matching != nil {
+		if _, err := s.matching.ReplaceOrder(o.Symbol, o.ID, o.Price, quantity-o.FilledQty); err != nil {
+			return err
+		}
+	}
+
+	from := o.FrozenMargin
+	event := snapshot.NewEvent(
+		0,
+		snapshot.EventOrderReplaced,
+		snapshot.OrderReplacedData{
+			OrderID:   o.ID,
+			UserID:    o.UserID,
+			Symbol:    o.Symbol,
+			Price:     o.Price,
+			Quantity:  quantity,
+			CreatedAt: o.CreatedAt,
+
+			FrozenMargin: frozen,
+		},
+	)
+	if s.eventBus != nil {
+		if err := s.eventBus.Publish(event); err != nil {
+			return fmt.Errorf("publish order replaced event: %w", err)
+		}
+	} else {
+		o.Quantity = quantity
+		o.FrozenMargin = frozen
+	}
+
+	fmt.Printf("[OMS] reduce-only order trimmed: id=%d qty=%s\n", o.ID, quantity)
+	return s.margin.Refreeze(o, s.settleAsset(o.Symbol), from, frozen)
+}
+
+// checkLiquidation liquidates what a fill left under maintenance margin,
+// one liquidation step at a time, re-checking after each step so a large
+// position is only reduced as far as it has to be. An isolated position
+// stands alone, on each side in hedge mode. A cross account is checked as
+// a whole and loses its worst position first, until it is healthy or
+// nothing more can be closed.
+func (s *OrderService) checkLiquidation(userID int64, symbol string) {
+	if s.position.MarginMode(userID) != domain.CrossMargin {
+		for _, side := range s.position.Sides(userID) {
+			for {
+				p, ok := s.position.GetSide(userID, symbol, side)
+				if !ok {
+					break
+				}
+				if !s.liquidator.Check(p, s.markPrice(p)) {
+					s.thaw(p)
+					break
+				}
+				if !s.liquidate(p, p.BankruptcyPrice) {
+					break
+				}
+			}
+		}
+		return
+	}
+
+	asset := s.settleAsset(symbol)
+	var positions []*domain.Position
+	for _, p := range s.position.OpenPositions(userID) {
+		if s.settleAsset(p.Symbol) == asset {
+			positions = append(positions, p)
+		}
+	}
+
+	var wallet decimal.Decimal
+	if s.accounts != nil {
+		if a, ok := s.accounts.Get(userID, asset); ok {
+			wallet = a.Total()
+		}
+	}
+
+	if !s.liquidator.CheckAccount(wallet, positions, s.markPrice) {
+		for _, p := range positions {
+			s.thaw(p)
+		}
+		return
+	}
+	worst := s.liquidator.Prioritize(positions, s.markPrice)[0]
+	_, bankruptcy := s.crossPrices(worst, wallet, positions)
+	if s.liquidate(worst, bankruptcy) {
+		s.checkLiquidation(userID, symbol)
+	}
+}
+
+// liquidate closes one liquidation step of p in the market no worse than
+// its bankruptcy price and squares the result with the insurance fund.
+// What the market cannot take of the step once the fund is exhausted is
+// auto-deleveraged. p stays frozen until it is closed or healthy again.
+// Reports whether any of p was closed.
+func (s *OrderService) liquidate(p *domain.Position, bankruptcy decimal.Decimal) bool {
+	s.freeze(p)
+
+	held := *p // the fills below move the booked position
+	asset := s.settleAsset(held.Symbol)
+	cross := s.position.MarginMode(held.UserID) == domain.CrossMargin
+
+	closed := false
+	unfilled := s.liquidator.StepQty(p)
+	fills := s.liquidator.Execute(p, unfilled, bankruptcy)
+	for _, t := range fills {
+		if t.UserID == held.UserID && !t.IsMaker {
+			s.applyTrade(t)
+			unfilled -= t.Qty
+			closed = true
+		} else {
+			s.onTrade(t)
+		}
+	}
+	if cross && len(fills) > 0 {
+		s.settleCross(held.UserID, asset, fills[0].OrderID)
+	} else if !cross {
+		s.settleIsolated(&held, fills)
+	}
+
+	rest, ok := s.position.GetSide(held.UserID, held.Symbol, held.Side)
+	if !ok {
+		return closed
+	}
+	var adl []*domain.Trade
+	if !rest.Qty.IsZero() && unfilled > 0 && s.insurance.Balance(asset) <= 0 {
+		s.adl.Deleverage(rest, decimal.Min(unfilled, rest.Qty.Abs()), bankruptcy, s.markPrice(rest), func(t *domain.Trade) {
+			s.applyTrade(t)
+			adl = append(adl, t)
+			closed = true
+		})
+		if cross && len(adl) > 0 {
+			s.settleCross(held.UserID, asset, adl[0].TradeID)
+		}
+		rest, _ = s.position.GetSide(held.UserID, held.Symbol, held.Side)
+	}
+
+	switch {
+	case !rest.Qty.IsZero():
+	case len(adl) > 0:
+		s.position.SetState(rest, domain.PositionADL, "ADL")
+	default:
+		s.position.SetState(rest, domain.PositionClosed, "LIQUIDATED")
+	}
+	return closed
+}
+
+// freeze puts a position under liquidation: the user's open orders in the
+// symbol are canceled so none trades against the liquidation, and until
+// the position is closed or healthy again only orders reducing it are
+// accepted
+func (s *OrderService) freeze(p *domain.Position) {
+	if p.Liquidating() {
+		return
+	}
+	s.position.SetState(p, domain.PositionLiquidating, "LIQUIDATION")
+
+	for _, o := range s.book.GetActiveByUser(p.UserID) {
+		if o.Symbol != p.Symbol {
+			continue
+		}
+		if s.matching != nil {
+			if err := s.matching.CancelOrder(o.Symbol, o.ID); err != nil {
+				fmt.Printf("[OMS] failed to cancel order %d for liquidation: %v\n", o.ID, err)
+				continue
+			}
+		}
+		if err := s.publishCanceled(o, "LIQUIDATION"); err != nil {
+			fmt.Printf("[OMS] failed to cancel order %d for liquidation: %v\n", o.ID, err)
+		}
+	}
+}
+
+// thaw returns a frozen position the mark has brought back above
+// maintenance margin to normal trading
+func (s *OrderService) thaw(p *domain.Position) {
+	if p.Liquidating() && !p.Qty.IsZero() {
+		s.position.SetState(p, domain.PositionNormal, "RECOVERED")
+	}
+}
+
+// checkRisk runs an order through the pre-trade rule chain at price and
+// quantity, pricing a market order at the last trade
+func (s *OrderService) checkRisk(o *domain.Order, price, quantity decimal.Decimal) error {
+	if s.risk == nil {
+		return nil
+	}
+	if o.Type == domain.Market {
+		price, _ = s.margin.referencePrice(o.Symbol)
+	}
+	mark, _ := s.margin.markPrice(o.Symbol)
+	return s.risk.Check(&RiskRequest{
+		Order:    o,
+		Price:    price,
+		Quantity: quantity,
+		Mark:     mark,
+		Leverage: s.margin.Leverage(o.UserID, o.Symbol),
+	})
+}
+
+// positionNotional is the notional of a user's largest position side in a
+// symbol at the mark price
+func (s *OrderService) positionNotional(userID int64, symbol string) decimal.Decimal {
+	var notional decimal.Decimal
+	for _, side := range s.position.Sides(userID) {
+		if p, ok := s.position.GetSide(userID, symbol, side); ok {
+			notional = decimal.Max(notional, p.Qty.Abs().Mul(s.markPrice(p)))
+		}
+	}
+	return notional
+}
+
+// settleIsolated squares a liquidated isolated position with the insurance
+// fund. Closing at the bankruptcy price uses up exactly the position's
+// margin: what the fills made above it goes to the fund, what they lost
+// below it the fund pays back, so the user never loses more than the margin.
+func (s *OrderService) settleIsolated(p *domain.Position, fills []*domain.Trade) {
+	if s.accounts == nil {
+		return
+	}
+	direction := decimal.FromInt(int64(p.Qty.Sign()))
+	var diff decimal.Decimal
+	var ref int64
+	for _, t := range fills {
+		if t.UserID == p.UserID && !t.IsMaker {
+			diff += (t.Price - p.BankruptcyPrice).Mul(t.Qty).Mul(direction)
+			ref = t.OrderID
+		}
+	}
+
+	asset := s.settleAsset(p.Symbol)
+	switch {
+	case diff > 0:
+		if err := s.insurance.Absorb(p.UserID, asset, diff, ref); err != nil {
+			fmt.Printf("[OMS] failed to move liquidation surplus of order %d: %v\n", ref, err)
+		}
+	case diff < 0:
+		if _, err := s.insurance.Cover(p.UserID, asset, -diff, ref); err != nil {
+			fmt.Printf("[OMS] failed to cover liquidation deficit of order %d: %v\n", ref, err)
+		}
+	}
+}
+
+// settleCross covers a cross account that its liquidation left with a
+// negative wallet. The surplus of a cross liquidation stays with the
+// account, which keeps its other positions.
+func (s *OrderService) settleCross(userID int64, asset string, ref int64) {
+	if s.accounts == nil {
+		return
+	}
+	a, ok := s.accounts.Get(userID, asset)
+	if !ok || a.Total() >= 0 {
+		return
+	}
+	if _, err := s.insurance.Cover(userID, asset, -a.Total(), ref); err != nil {
+		fmt.Printf("[OMS] failed to cover liquidation deficit of order %d: %v\n", ref, err)
+	}
+}
+
+// OnMarkPrice liquidates what a new mark price of symbol puts under
+// maintenance margin: isolated positions the mark has carried past their
+// liquidation price, found through the position book's sorted index, and
+// cross accounts holding the symbol, which have no such price and are
+// checked as a whole.
+func (s *OrderService) OnMarkPrice(symbol string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.triggerStops(symbol)
+
+	mark, ok := s.margin.markPrice(symbol)
+	if !ok {
+		return
+	}
+
+	var users []int64
+	seen := make(map[int64]bool)
+	for _, p := range s.position.AtRisk(symbol, mark) {
+		if !seen[p.UserID] {
+			seen[p.UserID] = true
+			users = append(users, p.UserID)
+		}
+	}
+	for _, p := range s.position.AllBySymbol(symbol) {
+		if !seen[p.UserID] && (p.Liquidating() || s.position.MarginMode(p.UserID) == domain.CrossMargin) {
+			seen[p.UserID] = true
+			users = append(users, p.UserID)
+		}
+	}
+
+	for _, uid := range users {
+		s.checkLiquidation(uid, symbol)
+	}
+}
+
+// PositionView is a copy of a position with the figures derived from it,
+// all taken from the same state: its liquidation and bankruptcy prices and
+// its unrealized PnL at the mark price are filled in
+type PositionView struct {
+	domain.Position
+	MarkPrice   decimal.Decimal // 0 when the symbol has no mark price
+	ADLQuantile int
+}
+
+// GetPosition returns a view of a user's position on one side of a symbol
+func (s *OrderService) GetPosition(userID int64, symbol string, side domain.PositionSide) (*PositionView, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.position.GetSide(userID, symbol, side)
+	if !ok {
+		return nil, false
+	}
+	v := &PositionView{Position: *p}
+	v.LiquidationPrice, v.BankruptcyPrice = s.riskPrices(p)
+	v.ADLQuantile = s.adl.Quantile(p, s.markPrice(p))
+	if mark, ok := s.margin.markPrice(symbol); ok {
+		v.MarkPrice = mark
+		v.UnrealizedPnL = (mark - p.EntryPrice).Mul(p.Qty)
+	}
+	return v, true
+}
+
+// RiskPrices returns the liquidation and bankruptcy price of a position.
+// Isolated positions carry their own; a cross position's are where its
+// mark alone would take the whole account to maintenance margin or to zero
+// equity, every other position held at its current mark.
+func (s *OrderService) RiskPrices(p *domain.Position) (liquidation, bankruptcy decimal.Decimal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.riskPrices(p)
+}
+
+func (s *OrderService) riskPrices(p *domain.Position) (liquidation, bankruptcy decimal.Decimal) {
+	if s.position.MarginMode(p.UserID) != domain.CrossMargin {
+		return p.LiquidationPrice, p.BankruptcyPrice
+	}
+
+	asset := s.settleAsset(p.Symbol)
+	var positions []*domain.Position
+	for _, o := range s.position.OpenPositions(p.UserID) {
+		if s.settleAsset(o.Symbol) == asset {
+			positions = append(positions, o)
+		}
+	}
+	var wallet decimal.Decimal
+	if s.accounts != nil {
+		if a, ok := s.accounts.Get(p.UserID, asset); ok {
+			wallet = a.Total()
+		}
+	}
+	return s.crossPrices(p, wallet, positions)
+}
+
+// ADLQuantile returns a position's auto-deleveraging indicator, from
+// ADLQuantiles for the first to be deleveraged down to 0 for a position
+// ADL would not touch
+func (s *OrderService) ADLQuantile(p *domain.Position) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.adl.Quantile(p, s.markPrice(p))
+}
+
+// SubscribeADL registers fn to run for every position auto-deleveraging
+// closes; see ADLService.Subscribe
+func (s *OrderService) SubscribeADL(fn func(*domain.ADLRecord)) {
+	s.adl.Subscribe(fn)
+}
+
+// crossPrices treats the wallet plus the unrealized PnL of the account's
+// other positions as the margin of p, less their maintenance margin for
+// the liquidation price
+func (s *OrderService) crossPrices(p *domain.Position, wallet decimal.Decimal, positions []*domain.Position) (liquidation, bankruptcy decimal.Decimal) {
+	equity := wallet
+	var mm decimal.Decimal
+	for _, o := range positions {
+		if o.Symbol == p.Symbol && o.Side == p.Side {
+			continue
+		}
+		mark := s.markPrice(o)
+		equity += (mark - o.EntryPrice).Mul(o.Qty)
+		mm += maintenanceMargin(s.instruments, o.Symbol, o.Qty.Abs().Mul(mark))
+	}
+	tier := riskTier(s.instruments, p.Symbol, p.Qty.Abs().Mul(s.markPrice(p)))
+	return p.LiquidationPriceAt(equity-mm+tier.MaintenanceAmount, tier.MaintenanceMarginRate), p.BankruptcyPriceAt(equity)
+}
+
+// SettleFunding settles funding of every trading symbol with a mark price
+// at funding time at, then re-checks every user who paid for liquidation
+func (s *OrderService) SettleFunding(at time.Time) {
+	if s.funding == nil || s.instruments == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.triggerStops("")
+
+	instruments := s.instruments.List()
+	sort.Slice(instruments, func(i, j int) bool { return instruments[i].Symbol < instruments[j].Symbol })
+	for _, inst := range instruments {
+		if inst.Status != domain.InstrumentTrading {
+			continue
+		}
+		_, payments, err := s.funding.Settle(inst.Symbol, at)
+		if errors.Is(err, ErrNoIndexPrice) {
+			continue
+		}
+		if err != nil {
+			fmt.Printf("[OMS] funding settlement of %s failed: %v\n", inst.Symbol, err)
+		}
+		for _, pay := range payments {
+			if pay.Amount < 0 {
+				s.checkLiquidation(pay.UserID, pay.Symbol)
+			}
+		}
+	}
+}
+
+// RunFunding settles funding at every funding time until done is closed
+func (s *OrderService) RunFunding(done <-chan struct{}) {
+	if s.funding == nil {
+		return
+	}
+	for {
+		next := s.funding.NextFundingTime(time.Now())
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-timer.C:
+			s.SettleFunding(next)
+		case <-done:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// MarkPrice is the price positions of a symbol are valued and liquidated at
+func (s *OrderService) MarkPrice(symbol string) (decimal.Decimal, bool) {
+	return s.margin.markPrice(symbol)
+}
+
+// markPrice is the price a position is valued and liquidated at
+func (s *OrderService) markPrice(p *domain.Position) decimal.Decimal {
+	if price, ok := s.margin.markPrice(p.Symbol); ok {
+		return price
+	}
+	return p.EntryPrice
+}
+
+func (s *OrderService) instrument(symbol string) (*domain.Instrument, bool) {
+	if s.instruments == nil {
+		return nil, false
+	}
+	return s.instruments.Get(symbol)
+}
+
+// settleAsset is the asset margin and PnL of a symbol are booked in
+func (s *OrderService) settleAsset(symbol string) string {
+	if s.instruments != nil {
+		if inst, ok := s.instruments.Get(symbol); ok {
+			return inst.SettleAsset
+		}
 	}
+	return DefaultSettleAsset
 }
 
-func signedQty(side domain.Side, qty float64) float64 {
+func signedQty(side domain.Side, qty decimal.Decimal) decimal.Decimal {
 	if side == domain.Sell {
 		return -qty
 	}
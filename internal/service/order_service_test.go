@@ -0,0 +1,471 @@
+package service
+
+import (
+	"testing"
+
+	"oms-contract/internal/domain"
+	"oms-contract/internal/engine"
+	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
+	"oms-contract/pkg/idgen"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestOrderService wires an OrderService on top of a real event store
+func newTestOrderService(t *testing.T) (*OrderService, *snapshot.SystemState, *snapshot.EventStore) {
+	t.Helper()
+
+	store, err := snapshot.NewEventStore(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+
+	state := snapshot.NewSystemState()
+	return restoreTestOrderService(t, store, state), state, store
+}
+
+// restoreTestOrderService wires the services onto state, as a restart does
+// onto the replayed state
+func restoreTestOrderService(t *testing.T, store *snapshot.EventStore, state *snapshot.SystemState) *OrderService {
+	t.Helper()
+
+	bus := snapshot.NewEventBus(store, state)
+	idGen := idgen.New()
+
+	instruments := NewInstrumentService(state.InstrumentBook, bus)
+	require.NoError(t, instruments.Upsert(testInstrument("BTCUSDT")))
+
+	pos := NewPositionService(state.PositionBook, instruments, bus)
+	liq := NewLiquidationService(instruments, nil, idGen)
+	accounts := NewAccountService(state.AccountBook, bus)
+	marks := NewMarkPriceService(state.MarkPriceBook, bus)
+	funding := NewFundingService(state.FundingBook, instruments, pos, accounts, marks, bus, idGen)
+	fees := NewFeeService(state.TradeBook, instruments, accounts)
+	// Trading is free unless a test loads a fee schedule
+	require.NoError(t, fees.SetTiers([]domain.FeeTier{{}}))
+	risk := NewRiskService(state.OrderBook, instruments, pos)
+	stops := NewStopService(state.StopBook, instruments, bus, idGen)
+	return NewOrderService(state.OrderBook, instruments, accounts, pos, liq, marks, funding, fees, risk, stops, nil, bus, idGen)
+}
+
+// fund deposits USDT for each user
+func fund(t *testing.T, svc *OrderService, amount int64, users ...int64) {
+	t.Helper()
+	for _, uid := range users {
+		require.NoError(t, svc.accounts.Deposit(uid, "USDT", decimal.FromInt(amount)))
+	}
+}
+
+func TestOrderService_CancelOrder(t *testing.T) {
+	svc, state, store := newTestOrderService(t)
+	fund(t, svc, 10000, 100)
+
+	id, err := svc.CreateOrder(&domain.Order{
+		UserID:   100,
+		Symbol:   "BTCUSDT",
+		Side:     domain.Buy,
+		Type:     domain.Limit,
+		Price:    decimal.FromInt(30000),
+		Quantity: decimal.FromInt(1),
+	})
+	require.NoError(t, err)
+
+	require.ErrorIs(t, svc.CancelOrder(id, 200), ErrOrderNotOwned)
+	require.ErrorIs(t, svc.CancelOrder(id+1, 100), ErrOrderNotFound)
+
+	require.NoError(t, svc.CancelOrder(id, 100))
+	o, ok := state.OrderBook.Get(id)
+	require.True(t, ok)
+	require.Equal(t, domain.Canceled, o.Status)
+
+	require.ErrorIs(t, svc.CancelOrder(id, 100), ErrOrderNotActive)
+
+	// Cancellation survives replay
+	snapMgr, err := snapshot.NewSnapshotManager(t.TempDir(), 1)
+	require.NoError(t, err)
+	replayed, err := snapshot.NewReplayEngine(store, snapMgr).Replay()
+	require.NoError(t, err)
+
+	o, ok = replayed.OrderBook.Get(id)
+	require.True(t, ok)
+	require.Equal(t, domain.Canceled, o.Status)
+}
+
+func TestOrderService_ReplaceOrder(t *testing.T) {
+	svc, state, _ := newTestOrderService(t)
+	fund(t, svc, 10000, 100)
+
+	id, err := svc.CreateOrder(&domain.Order{
+		UserID:   100,
+		Symbol:   "BTCUSDT",
+		Side:     domain.Sell,
+		Type:     domain.Limit,
+		Price:    decimal.FromInt(30000),
+		Quantity: decimal.FromInt(2),
+	})
+	require.NoError(t, err)
+	o, _ := state.OrderBook.Get(id)
+	createdAt := o.CreatedAt
+
+	_, err = svc.ReplaceOrder(id, 100, decimal.FromInt(30000), decimal.Zero)
+	require.ErrorIs(t, err, ErrInvalidAmend)
+
+	// Same price, smaller size: priority kept
+	o, err = svc.ReplaceOrder(id, 100, decimal.FromInt(30000), decimal.FromInt(1))
+	require.NoError(t, err)
+	require.Equal(t, decimal.FromInt(1), o.Quantity)
+	require.True(t, o.CreatedAt.Equal(createdAt))
+
+	// New price: priority lost
+	o, err = svc.ReplaceOrder(id, 100, decimal.FromInt(30100), decimal.FromInt(1))
+	require.NoError(t, err)
+	require.Equal(t, decimal.FromInt(30100), o.Price)
+	require.True(t, o.CreatedAt.After(createdAt))
+
+	// Amends must fit the instrument like new orders
+	for _, tc := range []struct {
+		price, qty string
+		err        error
+	}{
+		{"100.03", "1", ErrInvalidTickSize},
+		{"30100", "1.0005", ErrInvalidLotSize},
+		{"30100", "500", ErrQuantityTooLarge},
+	} {
+		_, err = svc.ReplaceOrder(id, 100, decimal.MustParse(tc.price), decimal.MustParse(tc.qty))
+		require.ErrorIs(t, err, tc.err)
+		require.Equal(t, domain.RejectInstrument, RejectCodeOf(err))
+	}
+	o, _ = state.OrderBook.Get(id)
+	require.Equal(t, decimal.FromInt(30100), o.Price)
+	require.Equal(t, decimal.FromInt(1), o.Quantity)
+}
+
+// engineGateway drives a real single-threaded MatchingEngine from the tests
+type engineGateway struct {
+	engine *engine.MatchingEngine
+}
+
+func (g *engineGateway) SubmitOrder(o *domain.Order) ([]*domain.Trade, error) {
+	cp := *o
+	cp.Quantity = o.Quantity - o.FilledQty
+	return g.engine.SubmitOrder(&cp), nil
+}
+
+func (g *engineGateway) SendLiquidationOrder(o *domain.LiquidationOrder) ([]*domain.Trade, error) {
+	return g.engine.SubmitOrder(&domain.Order{ID: o.OrderID, UserID: o.UserID, Symbol: o.Symbol,
+		Side: o.Side, Type: o.OrderType, Price: o.Price, Quantity: o.Quantity, PositionSide: o.PositionSide}), nil
+}
+
+func (g *engineGateway) CancelOrder(symbol string, orderID int64) error {
+	g.engine.CancelOrder(symbol, orderID)
+	return nil
+}
+
+func (g *engineGateway) ReplaceOrder(symbol string, orderID int64, price, qty decimal.Decimal) ([]*domain.Trade, error) {
+	trades, _ := g.engine.ReplaceOrder(symbol, orderID, price, qty)
+	return trades, nil
+}
+
+func TestOrderService_MatchingFlow(t *testing.T) {
+	svc, state, store := newTestOrderService(t)
+	svc.matching = &engineGateway{engine: engine.NewMatchingEngine()}
+	fund(t, svc, 100000, 100, 200, 300)
+
+	place := func(user int64, side domain.Side, typ domain.OrderType, price, qty int64) *domain.Order {
+		o := &domain.Order{UserID: user, Symbol: "BTCUSDT", Side: side, Type: typ,
+			Price: decimal.FromInt(price), Quantity: decimal.FromInt(qty)}
+		_, err := svc.CreateOrder(o)
+		require.NoError(t, err)
+		return o
+	}
+
+	maker := place(100, domain.Sell, domain.Limit, 30000, 1)
+	require.Equal(t, domain.Submitted, maker.Status)
+
+	taker := place(200, domain.Buy, domain.Limit, 30100, 3)
+	require.Equal(t, domain.PartFilled, taker.Status)
+	require.Equal(t, decimal.FromInt(1), taker.FilledQty)
+
+	booked, _ := state.OrderBook.Get(maker.ID)
+	require.Equal(t, domain.Filled, booked.Status)
+
+	// Market sell takes the resting bid; the unfilled remainder expires
+	mkt := place(300, domain.Sell, domain.Market, 0, 5)
+	require.Equal(t, domain.Canceled, mkt.Status)
+	require.Equal(t, decimal.FromInt(2), mkt.FilledQty)
+
+	booked, _ = state.OrderBook.Get(taker.ID)
+	require.Equal(t, domain.Filled, booked.Status)
+
+	for user, qty := range map[int64]int64{100: -1, 200: 3, 300: -2} {
+		p, ok := state.PositionBook.Get(user, "BTCUSDT")
+		require.True(t, ok)
+		require.Equal(t, decimal.FromInt(qty), p.Qty, "user %d", user)
+	}
+
+	// Fills survive replay
+	snapMgr, err := snapshot.NewSnapshotManager(t.TempDir(), 1)
+	require.NoError(t, err)
+	replayed, err := snapshot.NewReplayEngine(store, snapMgr).Replay()
+	require.NoError(t, err)
+
+	for _, id := range []int64{maker.ID, taker.ID, mkt.ID} {
+		live, _ := state.OrderBook.Get(id)
+		o, ok := replayed.OrderBook.Get(id)
+		require.True(t, ok)
+		require.Equal(t, live.Status, o.Status)
+		require.Equal(t, live.FilledQty, o.FilledQty)
+	}
+}
+
+func TestOrderService_RestoreReferencePrice(t *testing.T) {
+	svc, _, store := newTestOrderService(t)
+	svc.matching = &engineGateway{engine: engine.NewMatchingEngine()}
+	fund(t, svc, 100000, 100, 200, 300)
+
+	place := func(svc *OrderService, user int64, side domain.Side, typ domain.OrderType, price int64) *domain.Order {
+		o := &domain.Order{UserID: user, Symbol: "BTCUSDT", Side: side, Type: typ,
+			Price: decimal.FromInt(price), Quantity: decimal.One}
+		_, err := svc.CreateOrder(o)
+		require.NoError(t, err)
+		return o
+	}
+	place(svc, 100, domain.Sell, domain.Limit, 30000)
+	place(svc, 200, domain.Buy, domain.Limit, 30000)
+
+	// After a restart the last price comes back with the replayed trades
+	snapMgr, err := snapshot.NewSnapshotManager(t.TempDir(), 1)
+	require.NoError(t, err)
+	replayed, err := snapshot.NewReplayEngine(store, snapMgr).Replay()
+	require.NoError(t, err)
+	restored := restoreTestOrderService(t, store, replayed)
+	restored.matching = &engineGateway{engine: engine.NewMatchingEngine()}
+
+	place(restored, 100, domain.Sell, domain.Limit, 30010)
+	mkt := place(restored, 300, domain.Buy, domain.Market, 0)
+	require.Equal(t, domain.Filled, mkt.Status)
+
+	// A last-price stop is placed against 30010, not zero
+	stop := &domain.StopOrder{UserID: 300, Symbol: "BTCUSDT", Side: domain.Sell,
+		Type: domain.StopMarket, StopPrice: decimal.FromInt(29000), ClosePosition: true}
+	_, err = restored.PlaceStopOrder(stop)
+	require.NoError(t, err)
+	require.False(t, stop.TriggerAbove)
+}
+
+func TestOrderService_HedgeMode(t *testing.T) {
+	svc, state, store := newTestOrderService(t)
+	svc.matching = &engineGateway{engine: engine.NewMatchingEngine()}
+	fund(t, svc, 100000, 100, 200)
+
+	place := func(user int64, side domain.Side, ps domain.PositionSide, price, qty int64) (*domain.Order, error) {
+		o := &domain.Order{UserID: user, Symbol: "BTCUSDT", Side: side, Type: domain.Limit,
+			Price: decimal.FromInt(price), Quantity: decimal.FromInt(qty), PositionSide: ps}
+		_, err := svc.CreateOrder(o)
+		return o, err
+	}
+
+	require.NoError(t, svc.SetPositionMode(100, domain.HedgeMode))
+
+	o, err := place(100, domain.Buy, "", 30000, 1)
+	require.ErrorIs(t, err, ErrInvalidPositionSide)
+	require.Equal(t, domain.Rejected, o.Status)
+	_, err = place(200, domain.Buy, domain.PositionLong, 30000, 1)
+	require.ErrorIs(t, err, ErrInvalidPositionSide)
+
+	// 100 opens a long and a short at the same time
+	_, err = place(200, domain.Sell, "", 30000, 1)
+	require.NoError(t, err)
+	_, err = place(100, domain.Buy, domain.PositionLong, 30000, 1)
+	require.NoError(t, err)
+	_, err = place(200, domain.Buy, "", 30000, 1)
+	require.NoError(t, err)
+	_, err = place(100, domain.Sell, domain.PositionShort, 30000, 1)
+	require.NoError(t, err)
+
+	long, _ := state.PositionBook.GetSide(100, "BTCUSDT", domain.PositionLong)
+	short, _ := state.PositionBook.GetSide(100, "BTCUSDT", domain.PositionShort)
+	require.Equal(t, decimal.FromInt(1), long.Qty)
+	require.Equal(t, decimal.FromInt(-1), short.Qty)
+	a, _ := svc.accounts.Get(100, "USDT")
+	require.Equal(t, decimal.FromInt(6000), a.Margin)
+
+	// Closing orders are capped by the position they close
+	_, err = place(100, domain.Sell, domain.PositionLong, 31000, 2)
+	require.ErrorIs(t, err, ErrCloseExceedsPosition)
+	require.ErrorIs(t, svc.SetPositionMode(100, domain.OneWayMode), ErrPositionModeChange)
+
+	// Closing the long leaves the short untouched
+	_, err = place(100, domain.Sell, domain.PositionLong, 31000, 1)
+	require.NoError(t, err)
+	_, err = place(200, domain.Buy, "", 31000, 1)
+	require.NoError(t, err)
+
+	long, _ = state.PositionBook.GetSide(100, "BTCUSDT", domain.PositionLong)
+	short, _ = state.PositionBook.GetSide(100, "BTCUSDT", domain.PositionShort)
+	require.True(t, long.Qty.IsZero())
+	require.Equal(t, decimal.FromInt(1000), long.RealizedPnL)
+	require.Equal(t, decimal.FromInt(-1), short.Qty)
+
+	// Mode and both sides survive replay
+	snapMgr, err := snapshot.NewSnapshotManager(t.TempDir(), 1)
+	require.NoError(t, err)
+	replayed, err := snapshot.NewReplayEngine(store, snapMgr).Replay()
+	require.NoError(t, err)
+
+	require.Equal(t, domain.HedgeMode, replayed.PositionBook.Mode(100))
+	require.Equal(t, domain.OneWayMode, replayed.PositionBook.Mode(200))
+	for _, side := range []domain.PositionSide{domain.PositionLong, domain.PositionShort} {
+		live, _ := state.PositionBook.GetSide(100, "BTCUSDT", side)
+		p, ok := replayed.PositionBook.GetSide(100, "BTCUSDT", side)
+		require.True(t, ok)
+		require.Equal(t, live, p)
+	}
+}
+
+// tieredInstrument is testInstrument with three risk tiers: up to 50k at
+// 0.4% and 100x, up to 250k at 1% and 20x, up to 1M at 2.5% and 10x
+func tieredInstrument(symbol string) *domain.Instrument {
+	inst := testInstrument(symbol)
+	inst.RiskTiers = []domain.RiskTier{
+		{MaxNotional: decimal.FromInt(50000), MaintenanceMarginRate: decimal.MustParse("0.004"),
+			MaxLeverage: decimal.FromInt(100)},
+		{MaxNotional: decimal.FromInt(250000), MaintenanceMarginRate: decimal.MustParse("0.01"),
+			MaintenanceAmount: decimal.FromInt(300), MaxLeverage: decimal.FromInt(20)},
+		{MaxNotional: decimal.FromInt(1000000), MaintenanceMarginRate: decimal.MustParse("0.025"),
+			MaintenanceAmount: decimal.FromInt(4050), MaxLeverage: decimal.FromInt(10)},
+	}
+	return inst
+}
+
+func TestOrderService_RiskLimit(t *testing.T) {
+	svc, state, store := newTestOrderService(t)
+	svc.matching = &engineGateway{engine: engine.NewMatchingEngine()}
+	require.NoError(t, svc.instruments.Upsert(tieredInstrument("BTCUSDT")))
+	fund(t, svc, 1000000, 100, 200)
+
+	buy := func(qty int64) error {
+		_, err := svc.CreateOrder(&domain.Order{UserID: 100, Symbol: "BTCUSDT", Side: domain.Buy, Type: domain.Limit,
+			Price: decimal.FromInt(30000), Quantity: decimal.FromInt(qty)})
+		return err
+	}
+	_, err := svc.CreateOrder(&domain.Order{UserID: 200, Symbol: "BTCUSDT", Side: domain.Sell, Type: domain.Limit,
+		Price: decimal.FromInt(30000), Quantity: decimal.FromInt(2)})
+	require.NoError(t, err)
+
+	// 60k notional falls in tier 2, which allows at most 20x
+	_, err = svc.SetLeverage(100, "BTCUSDT", decimal.FromInt(50))
+	require.NoError(t, err)
+	require.ErrorIs(t, buy(2), ErrRiskLimitExceeded)
+	_, err = svc.SetLeverage(100, "BTCUSDT", decimal.FromInt(10))
+	require.NoError(t, err)
+
+	// A user capped at tier 1 cannot grow past 50k
+	require.ErrorIs(t, svc.SetRiskLimit(100, "BTCUSDT", 4), ErrInvalidRiskTier)
+	require.NoError(t, svc.SetRiskLimit(100, "BTCUSDT", 1))
+	require.ErrorIs(t, buy(2), ErrRiskLimitExceeded)
+	require.ErrorIs(t, buy(40), ErrRiskLimitExceeded) // past the last tier
+	require.NoError(t, svc.SetRiskLimit(100, "BTCUSDT", 0))
+	require.NoError(t, buy(2))
+
+	// Tier 2 maintenance: 2P × 1% - 300 = 6000 + (P - 30000) × 2
+	p, ok := state.PositionBook.Get(100, "BTCUSDT")
+	require.True(t, ok)
+	require.Equal(t, decimal.MustParse("27121.21212121"), p.LiquidationPrice)
+	require.False(t, svc.liquidator.Check(p, decimal.FromInt(27122)))
+	require.True(t, svc.liquidator.Check(p, decimal.FromInt(27121)))
+
+	tiers, selected, current, err := svc.RiskLimit(100, "BTCUSDT")
+	require.NoError(t, err)
+	require.Len(t, tiers, 3)
+	require.Equal(t, 0, selected)
+	require.Equal(t, 2, current)
+
+	// The position no longer fits tier 1 nor 50x
+	require.ErrorIs(t, svc.SetRiskLimit(100, "BTCUSDT", 1), ErrRiskLimitExceeded)
+	_, err = svc.SetLeverage(100, "BTCUSDT", decimal.FromInt(50))
+	require.ErrorIs(t, err, ErrRiskLimitExceeded)
+	require.NoError(t, svc.SetRiskLimit(100, "BTCUSDT", 2))
+
+	snapMgr, err := snapshot.NewSnapshotManager(t.TempDir(), 1)
+	require.NoError(t, err)
+	replayed, err := snapshot.NewReplayEngine(store, snapMgr).Replay()
+	require.NoError(t, err)
+	require.Equal(t, 2, replayed.PositionBook.RiskLimit(100, "BTCUSDT"))
+	inst, _ := replayed.InstrumentBook.Get("BTCUSDT")
+	require.Equal(t, tieredInstrument("BTCUSDT"), inst)
+}
+
+func TestOrderService_ReduceOnly(t *testing.T) {
+	svc, state, store := newTestOrderService(t)
+	svc.matching = &engineGateway{engine: engine.NewMatchingEngine()}
+	fund(t, svc, 100000, 100, 200)
+
+	place := func(o *domain.Order) (*domain.Order, error) {
+		o.Symbol, o.Type = "BTCUSDT", domain.Limit
+		_, err := svc.CreateOrder(o)
+		return o, err
+	}
+	qty := decimal.MustParse
+
+	// Nothing to reduce yet
+	_, err := place(&domain.Order{UserID: 100, Side: domain.Sell, Price: decimal.FromInt(30000), Quantity: qty("1"), ReduceOnly: true})
+	require.ErrorIs(t, err, ErrReduceOnly)
+	require.Equal(t, domain.RejectReduceOnly, RejectCodeOf(err))
+	_, err = place(&domain.Order{UserID: 100, Side: domain.Sell, Price: decimal.FromInt(30000), ClosePosition: true})
+	require.ErrorIs(t, err, ErrReduceOnly)
+
+	_, err = place(&domain.Order{UserID: 200, Side: domain.Sell, Price: decimal.FromInt(30000), Quantity: qty("2")})
+	require.NoError(t, err)
+	_, err = place(&domain.Order{UserID: 100, Side: domain.Buy, Price: decimal.FromInt(30000), Quantity: qty("2")})
+	require.NoError(t, err)
+
+	_, err = place(&domain.Order{UserID: 100, Side: domain.Buy, Price: decimal.FromInt(29000), Quantity: qty("1"), ReduceOnly: true})
+	require.ErrorIs(t, err, ErrReduceOnly)
+
+	// Reduce-only orders may add up to the position, no further
+	tp, err := place(&domain.Order{UserID: 100, Side: domain.Sell, Price: decimal.FromInt(31000), Quantity: qty("1.5"), ReduceOnly: true})
+	require.NoError(t, err)
+	closer, err := place(&domain.Order{UserID: 100, Side: domain.Sell, Price: decimal.FromInt(32000), ClosePosition: true})
+	require.NoError(t, err)
+	require.True(t, closer.ReduceOnly)
+	require.Equal(t, qty("0.5"), closer.Quantity)
+	_, err = place(&domain.Order{UserID: 100, Side: domain.Sell, Price: decimal.FromInt(33000), Quantity: qty("0.1"), ReduceOnly: true})
+	require.ErrorIs(t, err, ErrCloseExceedsPosition)
+
+	// A plain sell halves the position: the first reduce-only order is cut
+	// to what is left, the later one canceled
+	_, err = place(&domain.Order{UserID: 200, Side: domain.Buy, Price: decimal.FromInt(30000), Quantity: qty("1")})
+	require.NoError(t, err)
+	_, err = place(&domain.Order{UserID: 100, Side: domain.Sell, Price: decimal.FromInt(30000), Quantity: qty("1")})
+	require.NoError(t, err)
+
+	booked, _ := state.OrderBook.Get(tp.ID)
+	require.Equal(t, domain.Submitted, booked.Status)
+	require.Equal(t, qty("1"), booked.Quantity)
+	require.True(t, tp.CreatedAt.Equal(booked.CreatedAt))
+	booked, _ = state.OrderBook.Get(closer.ID)
+	require.Equal(t, domain.Canceled, booked.Status)
+
+	// The trimmed order fills only what it was cut to
+	_, err = place(&domain.Order{UserID: 200, Side: domain.Buy, Price: decimal.FromInt(31000), Quantity: qty("2")})
+	require.NoError(t, err)
+	p, _ := svc.position.Get(100, "BTCUSDT")
+	require.True(t, p.Qty.IsZero())
+	require.True(t, ledgerSum(state, "USDT").IsZero())
+
+	snapMgr, err := snapshot.NewSnapshotManager(t.TempDir(), 1)
+	require.NoError(t, err)
+	replayed, err := snapshot.NewReplayEngine(store, snapMgr).Replay()
+	require.NoError(t, err)
+	for _, id := range []int64{tp.ID, closer.ID} {
+		live, _ := state.OrderBook.Get(id)
+		o, ok := replayed.OrderBook.Get(id)
+		require.True(t, ok)
+		require.Equal(t, live.Status, o.Status)
+		require.Equal(t, live.Quantity, o.Quantity)
+		require.Equal(t, live.FilledQty, o.FilledQty)
+		require.True(t, o.ReduceOnly)
+	}
+	require.Equal(t, state.AccountBook.State(), replayed.AccountBook.State())
+}
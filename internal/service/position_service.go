@@ -1,20 +1,38 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+
 	"oms-contract/internal/domain"
 	"oms-contract/internal/memory"
 	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
+)
+
+var (
+	ErrPositionModeChange = errors.New("position mode can only change with no open positions or orders")
+	ErrMarginModeChange   = errors.New("margin mode can only change with no open positions or orders")
 )
 
+// PositionChange is the effect of one fill on a position's margin and PnL
+type PositionChange struct {
+	MarginLocked   decimal.Decimal
+	MarginReleased decimal.Decimal
+	RealizedPnL    decimal.Decimal
+}
+
 type PositionService struct {
-	book     *memory.PositionBook
-	eventBus *snapshot.EventBus
+	book        *memory.PositionBook
+	instruments *InstrumentService
+	eventBus    *snapshot.EventBus
 }
 
-func NewPositionService(book *memory.PositionBook, eb *snapshot.EventBus) *PositionService {
+func NewPositionService(book *memory.PositionBook, instruments *InstrumentService, eb *snapshot.EventBus) *PositionService {
 	return &PositionService{
-		book:     book,
-		eventBus: eb,
+		book:        book,
+		instruments: instruments,
+		eventBus:    eb,
 	}
 }
 
@@ -25,41 +43,336 @@ func (s *PositionService) Get(
 	return s.book.Get(uid, symbol)
 }
 
+// GetSide returns the position on one side; PositionBoth is the one-way position
+func (s *PositionService) GetSide(uid int64, symbol string, side domain.PositionSide) (*domain.Position, bool) {
+	return s.book.GetSide(uid, symbol, side)
+}
+
+// Mode returns the user's position mode
+func (s *PositionService) Mode(uid int64) domain.PositionMode {
+	return s.book.Mode(uid)
+}
+
+// Sides returns the position sides a user's orders may use in their mode
+func (s *PositionService) Sides(uid int64) []domain.PositionSide {
+	if s.book.Mode(uid) == domain.HedgeMode {
+		return []domain.PositionSide{domain.PositionLong, domain.PositionShort}
+	}
+	return []domain.PositionSide{domain.PositionBoth}
+}
+
+// SetMode switches a user between one-way and hedge mode. Positions on one
+// side cannot be carried to the other layout, so every position must be
+// flat; the caller makes sure there are no open orders.
+func (s *PositionService) SetMode(uid int64, mode domain.PositionMode) error {
+	if mode != domain.OneWayMode && mode != domain.HedgeMode {
+		return fmt.Errorf("%w: unknown mode %q", ErrPositionModeChange, mode)
+	}
+	if err := s.checkFlat(uid, ErrPositionModeChange); err != nil {
+		return err
+	}
+	if s.book.Mode(uid) == mode {
+		return nil
+	}
+
+	event := snapshot.NewEvent(
+		0,
+		snapshot.EventPositionModeSet,
+		snapshot.PositionModeSetData{UserID: uid, Mode: mode},
+	)
+
+	if s.eventBus != nil {
+		if err := s.eventBus.Publish(event); err != nil {
+			return fmt.Errorf("publish position mode set event: %w", err)
+		}
+	} else {
+		s.book.SetMode(uid, mode)
+	}
+	return nil
+}
+
+// MarginMode returns the user's margin mode
+func (s *PositionService) MarginMode(uid int64) domain.MarginMode {
+	return s.book.MarginMode(uid)
+}
+
+// SetMarginMode switches a user between isolated and cross margin.
+// Position margin is booked differently in each mode, so every position
+// must be flat; the caller makes sure there are no open orders.
+func (s *PositionService) SetMarginMode(uid int64, mode domain.MarginMode) error {
+	if mode != domain.IsolatedMargin && mode != domain.CrossMargin {
+		return fmt.Errorf("%w: unknown mode %q", ErrMarginModeChange, mode)
+	}
+	if err := s.checkFlat(uid, ErrMarginModeChange); err != nil {
+		return err
+	}
+	if s.book.MarginMode(uid) == mode {
+		return nil
+	}
+
+	event := snapshot.NewEvent(
+		0,
+		snapshot.EventMarginModeSet,
+		snapshot.MarginModeSetData{UserID: uid, Mode: mode},
+	)
+
+	if s.eventBus != nil {
+		if err := s.eventBus.Publish(event); err != nil {
+			return fmt.Errorf("publish margin mode set event: %w", err)
+		}
+	} else {
+		s.book.SetMarginMode(uid, mode)
+	}
+	return nil
+}
+
+// RiskLimit is the risk tier a user's positions in a symbol are capped
+// at, 0 when uncapped
+func (s *PositionService) RiskLimit(uid int64, symbol string) int {
+	return s.book.RiskLimit(uid, symbol)
+}
+
+// SetRiskLimit caps a user's positions in a symbol at a risk tier, 0
+// lifts the cap. Callers check the tier against the instrument.
+func (s *PositionService) SetRiskLimit(uid int64, symbol string, tier int) error {
+	if s.book.RiskLimit(uid, symbol) == tier {
+		return nil
+	}
+
+	event := snapshot.NewEvent(
+		0,
+		snapshot.EventRiskLimitSet,
+		snapshot.RiskLimitSetData{UserID: uid, Symbol: symbol, Tier: tier},
+	)
+
+	if s.eventBus != nil {
+		if err := s.eventBus.Publish(event); err != nil {
+			return fmt.Errorf("publish risk limit set event: %w", err)
+		}
+	} else {
+		s.book.SetRiskLimit(uid, symbol, tier)
+	}
+	return nil
+}
+
+// SetState moves a position through its liquidation lifecycle
+func (s *PositionService) SetState(p *domain.Position, to domain.LiquidationState, reason string) {
+	from := p.State
+	if from == "" {
+		from = domain.PositionNormal
+	}
+	if from == to {
+		return
+	}
+
+	event := snapshot.NewEvent(
+		0,
+		snapshot.EventPositionStateChanged,
+		snapshot.PositionStateChangedData{
+			UserID: p.UserID,
+			Symbol: p.Symbol,
+			Side:   p.Side,
+			From:   from,
+			To:     to,
+			Reason: reason,
+		},
+	)
+
+	if s.eventBus != nil {
+		if err := s.eventBus.Publish(event); err != nil {
+			fmt.Printf("[OMS] failed to publish position state changed event: %v\n", err)
+			return
+		}
+	} else {
+		s.book.SetState(p.UserID, p.Symbol, p.Side, to)
+	}
+	fmt.Printf("[OMS] position state %s → %s (%s): user=%d symbol=%s side=%s\n", from, to, reason, p.UserID, p.Symbol, p.Side)
+}
+
+// OpenPositions returns the user's non-flat positions
+func (s *PositionService) OpenPositions(uid int64) []*domain.Position {
+	var list []*domain.Position
+	for _, p := range s.book.GetByUser(uid) {
+		if !p.Qty.IsZero() {
+			list = append(list, p)
+		}
+	}
+	return list
+}
+
+// AllBySymbol returns every open position in a symbol
+func (s *PositionService) AllBySymbol(symbol string) []*domain.Position {
+	return s.book.AllBySymbol(symbol)
+}
+
+// AtRisk returns the isolated positions in a symbol whose liquidation
+// price the mark has reached
+func (s *PositionService) AtRisk(symbol string, mark decimal.Decimal) []*domain.Position {
+	return s.book.AtRisk(symbol, mark)
+}
+
+func (s *PositionService) checkFlat(uid int64, reason error) error {
+	if open := s.OpenPositions(uid); len(open) > 0 {
+		return fmt.Errorf("%w: open %s %s position", reason, open[0].Symbol, open[0].Side)
+	}
+	return nil
+}
+
+// OnTrade applies a signed fill (qty > 0 buys, qty < 0 sells) to a position.
+// Fills in the position's direction increase it at a weighted entry price;
+// opposite fills reduce it, realizing PnL against the entry price and
+// releasing margin pro rata. A fill larger than the position closes it and
+// opens the remainder in the other direction at the fill price: on the same
+// position in one-way mode, on the opposite side in hedge mode, where a
+// side only ever holds its own direction.
 func (s *PositionService) OnTrade(
 	userID int64,
 	symbol string,
-	qty float64,
-	price float64,
-	leverage float64,
-) {
+	side domain.PositionSide,
+	qty decimal.Decimal,
+	price decimal.Decimal,
+	leverage decimal.Decimal,
+) PositionChange {
+
+	if side == "" {
+		side = domain.PositionBoth
+	}
 
-	p, ok := s.book.Get(userID, symbol)
+	var c PositionChange
+	p, ok := s.book.GetSide(userID, symbol, side)
 	if !ok {
+		p = &domain.Position{
+			UserID: userID,
+			Symbol: symbol,
+			Side:   side,
+		}
+	}
+	hedged := side != domain.PositionBoth
+
+	switch {
+	case p.Qty.IsZero() && hedged && signedQty(side.OpeningSide(), qty.Abs()) != qty:
+		// 双向持仓：该方向无仓可平，转入反方向
+		return s.OnTrade(userID, symbol, side.Opposite(), qty, price, leverage)
+
+	case p.Qty.IsZero():
 		// 开新仓
-		notional := abs(qty) * price
-		margin := notional / leverage
+		c.MarginLocked = s.open(p, qty, price, leverage, "OPEN")
 
-		p = &domain.Position{
-			UserID:     userID,
-			Symbol:     symbol,
-			Qty:        qty,
-			EntryPrice: price,
-			Leverage:   leverage,
-			Margin:     margin,
+	case p.Qty.Sign() == qty.Sign():
+		// 加仓
+		newQty := p.Qty + qty
+		p.EntryPrice = (p.EntryPrice.Mul(p.Qty) + price.Mul(qty)).Div(newQty)
+		p.Qty = newQty
+		c.MarginLocked = initialMargin(qty, price, p.Leverage)
+		p.Margin += c.MarginLocked
+		s.publish(snapshot.EventPositionUpdated, p, "INCREASE")
+
+	case qty.Abs() < p.Qty.Abs():
+		// 减仓
+		c.MarginReleased, c.RealizedPnL = s.reduce(p, qty.Abs(), price)
+		s.publish(snapshot.EventPositionUpdated, p, "REDUCE")
+
+	default:
+		// 平仓，剩余部分反向开仓
+		remainder := p.Qty + qty
+		c.MarginReleased, c.RealizedPnL = s.reduce(p, p.Qty.Abs(), price)
+		s.publish(snapshot.EventPositionClosed, p, "CLOSE")
+
+		switch {
+		case remainder.IsZero():
+		case hedged:
+			r := s.OnTrade(userID, symbol, side.Opposite(), remainder, price, leverage)
+			c.MarginLocked += r.MarginLocked
+			c.MarginReleased += r.MarginReleased
+			c.RealizedPnL += r.RealizedPnL
+		default:
+			c.MarginLocked = s.open(p, remainder, price, leverage, "FLIP")
 		}
+	}
+	return c
+}
+
+// open starts a position and returns the margin it locks
+func (s *PositionService) open(p *domain.Position, qty, price, leverage decimal.Decimal, reason string) decimal.Decimal {
+	p.Qty = qty
+	p.EntryPrice = price
+	p.Leverage = leverage
+	p.Margin = initialMargin(qty, price, leverage)
+	p.State = domain.PositionNormal
+	s.publish(snapshot.EventPositionOpened, p, reason)
+	return p.Margin
+}
+
+// SetLeverage changes the leverage of a user's position in a symbol and
+// resizes its margin to match. A flat position just records the leverage
+// for the next open. The caller moves the margin difference in the ledger.
+func (s *PositionService) SetLeverage(userID int64, symbol string, side domain.PositionSide, leverage decimal.Decimal) *domain.Position {
+	p := &domain.Position{UserID: userID, Symbol: symbol, Side: side}
+	if cur, ok := s.book.GetSide(userID, symbol, side); ok {
+		cp := *cur
+		p = &cp
+	}
+
+	p.Leverage = leverage
+	p.Margin = initialMargin(p.Qty, p.EntryPrice, leverage)
+	s.publish(snapshot.EventPositionUpdated, p, "LEVERAGE")
+	return p
+}
+
+// AddMargin moves an open position's margin by delta, for funding booked
+// to an isolated position. The caller moves the same amount in the ledger.
+func (s *PositionService) AddMargin(p *domain.Position, delta decimal.Decimal, reason string) *domain.Position {
+	cp := *p
+	cp.Margin += delta
+	s.publish(snapshot.EventPositionUpdated, &cp, reason)
+	return &cp
+}
+
+// initialMargin is the margin a position of qty at price needs at leverage
+func initialMargin(qty, price, leverage decimal.Decimal) decimal.Decimal {
+	return qty.Abs().Mul(price).Div(leverage)
+}
+
+// reduce closes closeQty (unsigned) of the position at price,
+// returning the margin released and the PnL realized
+func (s *PositionService) reduce(p *domain.Position, closeQty, price decimal.Decimal) (released, pnl decimal.Decimal) {
+	direction := decimal.FromInt(int64(p.Qty.Sign()))
+	pnl = (price - p.EntryPrice).Mul(closeQty).Mul(direction)
+
+	if closeQty == p.Qty.Abs() {
+		released = p.Margin
+		p.Qty = decimal.Zero
+		p.EntryPrice = decimal.Zero
+		p.Margin = decimal.Zero
+		p.UnrealizedPnL = decimal.Zero
 	} else {
-		// 加仓（简化：同方向）
-		p.EntryPrice = (p.EntryPrice*p.Qty + price*qty) / (p.Qty + qty)
-		p.Qty += qty
+		released = p.Margin.Mul(closeQty).Div(p.Qty.Abs())
+		p.Margin -= released
+		p.Qty -= closeQty.Mul(direction)
+	}
+	p.RealizedPnL += pnl
+	return released, pnl
+}
+
+func (s *PositionService) publish(eventType snapshot.EventType, p *domain.Position, reason string) {
+	// A cross position shares the account's equity; its prices move with
+	// every mark and are computed on demand
+	p.LiquidationPrice, p.BankruptcyPrice = decimal.Zero, decimal.Zero
+	if s.book.MarginMode(p.UserID) != domain.CrossMargin {
+		// The tier is taken at the entry notional; the maintenance
+		// amount lowers the margin the position has to keep
+		tier := riskTier(s.instruments, p.Symbol, p.Qty.Abs().Mul(p.EntryPrice))
+		p.LiquidationPrice = p.LiquidationPriceAt(p.Margin+tier.MaintenanceAmount, tier.MaintenanceMarginRate)
+		p.BankruptcyPrice = p.BankruptcyPriceAt(p.Margin)
 	}
 
 	// Persist via EventBus
 	event := snapshot.NewEvent(
 		0,
-		snapshot.EventPositionUpdated,
+		eventType,
 		snapshot.PositionUpdatedData{
 			Position: p,
-			Reason:   "TRADE",
+			Reason:   reason,
 		},
 	)
 
@@ -72,10 +385,3 @@ func (s *PositionService) OnTrade(
 		s.book.Save(p)
 	}
 }
-
-func abs(v float64) float64 {
-	if v < 0 {
-		return -v
-	}
-	return v
-}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"testing"
+
+	"oms-contract/internal/domain"
+	"oms-contract/internal/memory"
+	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPositionService_ReduceCloseFlip(t *testing.T) {
+	svc := NewPositionService(memory.NewPositionBook(), nil, nil)
+	lev := decimal.FromInt(10)
+	d := decimal.MustParse
+
+	trade := func(qty, price string) *domain.Position {
+		svc.OnTrade(100, "BTCUSDT", domain.PositionBoth, d(qty), d(price), lev)
+		p, ok := svc.Get(100, "BTCUSDT")
+		require.True(t, ok)
+		return p
+	}
+
+	// Open and add: weighted entry, margin grows with notional
+	p := trade("1", "30000")
+	p = trade("1", "32000")
+	require.Equal(t, d("2"), p.Qty)
+	require.Equal(t, d("31000"), p.EntryPrice)
+	require.Equal(t, d("6200"), p.Margin)
+
+	// Reduce: entry unchanged, PnL realized, margin released pro rata
+	p = trade("-0.5", "33000")
+	require.Equal(t, d("1.5"), p.Qty)
+	require.Equal(t, d("31000"), p.EntryPrice)
+	require.Equal(t, d("1000"), p.RealizedPnL)
+	require.Equal(t, d("4650"), p.Margin)
+
+	// Close: no division by zero, everything released
+	p = trade("-1.5", "30000")
+	require.True(t, p.Qty.IsZero())
+	require.True(t, p.Margin.IsZero())
+	require.Equal(t, d("-500"), p.RealizedPnL)
+
+	// Reopen short, then flip long in one fill
+	p = trade("-1", "30000")
+	p = trade("3", "29000")
+	require.Equal(t, d("2"), p.Qty)
+	require.Equal(t, d("29000"), p.EntryPrice)
+	require.Equal(t, d("5800"), p.Margin)
+	require.Equal(t, d("500"), p.RealizedPnL)
+}
+
+func TestPositionService_HedgeSides(t *testing.T) {
+	svc := NewPositionService(memory.NewPositionBook(), nil, nil)
+	require.NoError(t, svc.SetMode(100, domain.HedgeMode))
+	lev := decimal.FromInt(10)
+	d := decimal.MustParse
+
+	side := func(s domain.PositionSide) decimal.Decimal {
+		p, ok := svc.GetSide(100, "BTCUSDT", s)
+		if !ok {
+			return decimal.Zero
+		}
+		return p.Qty
+	}
+
+	// Long and short are held side by side
+	svc.OnTrade(100, "BTCUSDT", domain.PositionLong, d("1"), d("30000"), lev)
+	svc.OnTrade(100, "BTCUSDT", domain.PositionShort, d("-2"), d("30000"), lev)
+	require.Equal(t, d("1"), side(domain.PositionLong))
+	require.Equal(t, d("-2"), side(domain.PositionShort))
+	require.True(t, side(domain.PositionBoth).IsZero())
+
+	// Selling more than the long closes it and adds the rest to the short
+	c := svc.OnTrade(100, "BTCUSDT", domain.PositionLong, d("-3"), d("31000"), lev)
+	require.True(t, side(domain.PositionLong).IsZero())
+	require.Equal(t, d("-4"), side(domain.PositionShort))
+	require.Equal(t, d("1000"), c.RealizedPnL)
+	require.Equal(t, d("3000"), c.MarginReleased)
+	require.Equal(t, d("6200"), c.MarginLocked)
+
+	require.ErrorIs(t, svc.SetMode(100, domain.OneWayMode), ErrPositionModeChange)
+}
+
+func TestPositionService_Events(t *testing.T) {
+	store, err := snapshot.NewEventStore(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+
+	state := snapshot.NewSystemState()
+	svc := NewPositionService(state.PositionBook, nil, snapshot.NewEventBus(store, state))
+	lev := decimal.FromInt(10)
+
+	svc.OnTrade(100, "BTCUSDT", domain.PositionBoth, decimal.FromInt(1), decimal.FromInt(30000), lev)
+	svc.OnTrade(100, "BTCUSDT", domain.PositionBoth, decimal.FromInt(-2), decimal.FromInt(31000), lev)
+
+	events, err := store.ReadAll()
+	require.NoError(t, err)
+
+	var types []snapshot.EventType
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	require.Equal(t, []snapshot.EventType{
+		snapshot.EventPositionOpened,
+		snapshot.EventPositionClosed,
+		snapshot.EventPositionOpened,
+	}, types)
+
+	p, _ := state.PositionBook.Get(100, "BTCUSDT")
+	require.Equal(t, decimal.FromInt(-1), p.Qty)
+	require.Equal(t, decimal.FromInt(1000), p.RealizedPnL)
+}
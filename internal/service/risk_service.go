@@ -1,10 +1,427 @@
 package service
 
-import "oms-contract/internal/domain"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
 
-type RiskService struct{}
+	"oms-contract/internal/domain"
+	"oms-contract/internal/memory"
+	"oms-contract/pkg/decimal"
+)
 
-func (r *RiskService) Check(o *domain.Order) error {
-	// demo: 永远通过
+var (
+	ErrOrderQtyExceeded      = errors.New("order quantity exceeds limit")
+	ErrOrderNotionalExceeded = errors.New("order notional exceeds limit")
+	ErrPriceOutOfBand        = errors.New("order price too far from mark price")
+	ErrTooManyOpenOrders     = errors.New("too many open orders")
+	ErrInvalidRiskRules      = errors.New("invalid risk rules")
+)
+
+// DefaultRiskRules apply to every symbol and user until rules are loaded
+var DefaultRiskRules = domain.RiskRules{MaxOpenOrders: 200}
+
+// RejectError is an order rejection tagged with the code of the check
+// that made it
+type RejectError struct {
+	Code domain.RejectCode
+	Err  error
+}
+
+func (e *RejectError) Error() string { return e.Err.Error() }
+func (e *RejectError) Unwrap() error { return e.Err }
+
+// reject tags err with code; nil stays nil
+func reject(code domain.RejectCode, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &RejectError{Code: code, Err: err}
+}
+
+// RejectCodeOf returns the reject code err carries, RejectOther for an
+// untagged error
+func RejectCodeOf(err error) domain.RejectCode {
+	if err == nil {
+		return domain.RejectNone
+	}
+	var re *RejectError
+	if errors.As(err, &re) {
+		return re.Code
+	}
+	return domain.RejectOther
+}
+
+// RiskRequest is an order as the rule chain sees it: a new order, or a
+// resting one amended to Price and Quantity
+type RiskRequest struct {
+	Order    *domain.Order
+	Price    decimal.Decimal // the limit price, the last trade price for a market order; zero if unknown
+	Quantity decimal.Decimal // total quantity, of which Quantity - Order.FilledQty is left to fill
+	Mark     decimal.Decimal // zero when the symbol has no mark price yet
+	Leverage decimal.Decimal
+	Rules    domain.RiskRules // resolved for the user and symbol
+}
+
+// Remaining is the quantity the order may still fill
+func (r *RiskRequest) Remaining() decimal.Decimal {
+	return r.Quantity - r.Order.FilledQty
+}
+
+// RiskRule is one pre-trade check of the rule chain
+type RiskRule interface {
+	// Code tags the rejections of the rule
+	Code() domain.RejectCode
+	// Check returns nil to pass the order on to the next rule
+	Check(req *RiskRequest) error
+}
+
+// RiskConfig is the risk rules config file: user groups by name, and the
+// rules of each symbol and group
+type RiskConfig struct {
+	Groups map[string][]int64
+	Rules  []domain.RiskRules
+}
+
+// RiskService runs every order through a chain of pre-trade rules before
+// it reaches the book; the first rule to fail rejects the order with its
+// code. Limits are configured per symbol and user group, and the most
+// specific rules apply: symbol and group, then group, then symbol, then
+// those for every symbol and user. Rules must run with the order flow
+// locked, as they read open orders and positions.
+type RiskService struct {
+	book        *memory.OrderBook
+	instruments *InstrumentService
+	position    *PositionService
+
+	mu     sync.RWMutex
+	rules  []domain.RiskRules
+	groups map[int64]string
+	chain  []RiskRule
+}
+
+func NewRiskService(book *memory.OrderBook, instruments *InstrumentService, position *PositionService) *RiskService {
+	s := &RiskService{
+		book:        book,
+		instruments: instruments,
+		position:    position,
+		rules:       []domain.RiskRules{DefaultRiskRules},
+		groups:      make(map[int64]string),
+	}
+	s.chain = []RiskRule{
+		reduceOnlyRule{s},
+		maxOrderQtyRule{},
+		maxOrderNotionalRule{},
+		priceBandRule{},
+		maxOpenOrdersRule{s},
+		riskLimitRule{s},
+	}
+	return s
+}
+
+// Use appends rules to the end of the chain
+func (s *RiskService) Use(rules ...RiskRule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chain = append(s.chain, rules...)
+}
+
+// Check runs an order through the chain and returns the first rejection
+// as a *RejectError. req.Rules is filled in for the order's user and symbol.
+func (s *RiskService) Check(req *RiskRequest) error {
+	o := req.Order
+	req.Rules = s.Rules(o.UserID, o.Symbol)
+
+	s.mu.RLock()
+	chain := s.chain
+	s.mu.RUnlock()
+
+	for _, rule := range chain {
+		if err := rule.Check(req); err != nil {
+			return reject(rule.Code(), err)
+		}
+	}
+	return nil
+}
+
+// Rules returns the rules a user's orders in a symbol are checked against
+func (s *RiskService) Rules(userID int64, symbol string) domain.RiskRules {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	group := s.groups[userID]
+	best, rank := domain.RiskRules{}, -1
+	for _, r := range s.rules {
+		if (r.Symbol != "" && r.Symbol != symbol) || (r.Group != "" && r.Group != group) {
+			continue
+		}
+		n := 0
+		if r.Symbol != "" {
+			n++
+		}
+		if r.Group != "" {
+			n += 2
+		}
+		if n > rank {
+			best, rank = r, n
+		}
+	}
+	return best
+}
+
+// Group returns the user group a user belongs to, empty for none
+func (s *RiskService) Group(userID int64) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.groups[userID]
+}
+
+// SetConfig replaces the rules and user groups. Limits must not be
+// negative, a price band must stay below 100%, a symbol and group pair may
+// appear once, and a user belongs to one group at most.
+func (s *RiskService) SetConfig(cfg RiskConfig) error {
+	groups := make(map[int64]string)
+	for name, users := range cfg.Groups {
+		if name == "" {
+			return fmt.Errorf("%w: empty group name", ErrInvalidRiskRules)
+		}
+		for _, uid := range users {
+			if other, ok := groups[uid]; ok && other != name {
+				return fmt.Errorf("%w: user %d in groups %s and %s", ErrInvalidRiskRules, uid, other, name)
+			}
+			groups[uid] = name
+		}
+	}
+
+	seen := make(map[[2]string]bool)
+	for _, r := range cfg.Rules {
+		key := [2]string{r.Symbol, r.Group}
+		switch {
+		case seen[key]:
+			return fmt.Errorf("%w: duplicate rules for symbol %q group %q", ErrInvalidRiskRules, r.Symbol, r.Group)
+		case r.Group != "" && cfg.Groups[r.Group] == nil:
+			return fmt.Errorf("%w: unknown group %s", ErrInvalidRiskRules, r.Group)
+		case r.MaxOrderQty < 0 || r.MaxOrderNotional < 0 || r.MaxOpenOrders < 0:
+			return fmt.Errorf("%w: negative limit for symbol %q group %q", ErrInvalidRiskRules, r.Symbol, r.Group)
+		case r.PriceBand < 0 || r.PriceBand >= decimal.One:
+			return fmt.Errorf("%w: price band must be in [0, 1) for symbol %q group %q", ErrInvalidRiskRules, r.Symbol, r.Group)
+		}
+		seen[key] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules = append([]domain.RiskRules(nil), cfg.Rules...)
+	s.groups = groups
+	return nil
+}
+
+// LoadFile replaces the rules and user groups with a JSON config file
+func (s *RiskService) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var cfg RiskConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	return s.SetConfig(cfg)
+}
+
+// exposure is the largest absolute position a user's side of a symbol
+// could reach if all its open orders filled, with order o counted at
+// quantity in place of its booked one
+func (s *RiskService) exposure(userID int64, symbol string, side domain.PositionSide, o *domain.Order, quantity decimal.Decimal) decimal.Decimal {
+	var pos, buys, sells decimal.Decimal
+	if p, ok := s.position.GetSide(userID, symbol, side); ok {
+		pos = p.Qty
+	}
+	add := func(order *domain.Order, qty decimal.Decimal) {
+		if order.Side == domain.Buy {
+			buys += qty
+		} else {
+			sells += qty
+		}
+	}
+	for _, other := range s.book.GetActiveByUser(userID) {
+		if other.Symbol == symbol && other.PositionSide == side && (o == nil || other.ID != o.ID) {
+			add(other, other.Quantity-other.FilledQty)
+		}
+	}
+	if o != nil {
+		add(o, quantity-o.FilledQty)
+	}
+	return decimal.Max((pos + buys).Abs(), (pos - sells).Abs())
+}
+
+// reducing reports whether an order may only reduce its position: a
+// reduce-only order, or one closing a hedge-mode side
+func reducing(o *domain.Order) bool {
+	return o.ReduceOnly || (o.PositionSide != domain.PositionBoth && o.Side != o.PositionSide.OpeningSide())
+}
+
+// Closable is how much of its position order o may still reduce: the
+// position net of the user's other reducing orders on the same side, zero
+// if o trades in the position's direction
+func (s *RiskService) Closable(o *domain.Order) decimal.Decimal {
+	p, ok := s.position.GetSide(o.UserID, o.Symbol, o.PositionSide)
+	if !ok || p.Qty.IsZero() || signedQty(o.Side, decimal.One).Sign() == p.Qty.Sign() {
+		return decimal.Zero
+	}
+	closable := p.Qty.Abs()
+	for _, other := range s.book.GetActiveByUser(o.UserID) {
+		if other.ID != o.ID && other.Symbol == o.Symbol && other.PositionSide == o.PositionSide &&
+			other.Side == o.Side && reducing(other) {
+			closable -= other.Quantity - other.FilledQty
+		}
+	}
+	return decimal.Max(closable, decimal.Zero)
+}
+
+// reduceOnlyRule holds orders that may only reduce a position to that. A
+// reduce-only order must trade against its position, and it or an order
+// closing a hedge-mode side may not exceed the position net of the user's
+// other reducing orders, so they can all fill without flipping it. A
+// position under liquidation only accepts orders reducing it.
+type reduceOnlyRule struct{ s *RiskService }
+
+func (reduceOnlyRule) Code() domain.RejectCode { return domain.RejectReduceOnly }
+
+func (r reduceOnlyRule) Check(req *RiskRequest) error {
+	o := req.Order
+	p, ok := r.s.position.GetSide(o.UserID, o.Symbol, o.PositionSide)
+
+	if o.ReduceOnly && (!ok || p.Qty.IsZero() || signedQty(o.Side, decimal.One).Sign() == p.Qty.Sign()) {
+		return fmt.Errorf("%w: %s %s %s", ErrReduceOnly, o.Side, o.Symbol, o.PositionSide)
+	}
+	if reducing(o) {
+		if remaining, closable := req.Remaining(), r.s.Closable(o); remaining > closable {
+			return fmt.Errorf("%w: qty=%s closable=%s", ErrCloseExceedsPosition, remaining, closable)
+		}
+	}
+
+	if ok && p.Liquidating() && !p.Reduces(signedQty(o.Side, req.Remaining())) {
+		return fmt.Errorf("%w: %s %s", ErrPositionLiquidating, o.Symbol, p.Side)
+	}
+	return nil
+}
+
+// maxOrderQtyRule caps the quantity of one order
+type maxOrderQtyRule struct{}
+
+func (maxOrderQtyRule) Code() domain.RejectCode { return domain.RejectMaxOrderQty }
+
+func (maxOrderQtyRule) Check(req *RiskRequest) error {
+	if limit := req.Rules.MaxOrderQty; limit > 0 && req.Quantity > limit {
+		return fmt.Errorf("%w: qty=%s max=%s", ErrOrderQtyExceeded, req.Quantity, limit)
+	}
+	return nil
+}
+
+// maxOrderNotionalRule caps the notional of one order at its price
+type maxOrderNotionalRule struct{}
+
+func (maxOrderNotionalRule) Code() domain.RejectCode { return domain.RejectMaxOrderNotional }
+
+func (maxOrderNotionalRule) Check(req *RiskRequest) error {
+	limit := req.Rules.MaxOrderNotional
+	if limit <= 0 || req.Price <= 0 {
+		return nil
+	}
+	if notional := req.Quantity.Mul(req.Price); notional > limit {
+		return fmt.Errorf("%w: notional=%s max=%s", ErrOrderNotionalExceeded, notional, limit)
+	}
+	return nil
+}
+
+// priceBandRule stops fat-finger orders: a priced order may not be further
+// from the mark price than the band, on either side
+type priceBandRule struct{}
+
+func (priceBandRule) Code() domain.RejectCode { return domain.RejectPriceBand }
+
+func (priceBandRule) Check(req *RiskRequest) error {
+	band := req.Rules.PriceBand
+	if band <= 0 || req.Order.Type == domain.Market || req.Mark <= 0 {
+		return nil
+	}
+	low, high := req.Mark.Mul(decimal.One-band), req.Mark.Mul(decimal.One+band)
+	if req.Price < low || req.Price > high {
+		return fmt.Errorf("%w: price=%s mark=%s band=[%s, %s]", ErrPriceOutOfBand, req.Price, req.Mark, low, high)
+	}
+	return nil
+}
+
+// maxOpenOrdersRule caps a user's open orders in one symbol. Amending a
+// resting order never counts as opening one.
+type maxOpenOrdersRule struct{ s *RiskService }
+
+func (maxOpenOrdersRule) Code() domain.RejectCode { return domain.RejectMaxOpenOrders }
+
+func (r maxOpenOrdersRule) Check(req *RiskRequest) error {
+	o := req.Order
+	limit := req.Rules.MaxOpenOrders
+	if limit <= 0 {
+		return nil
+	}
+	if _, booked := r.s.book.Get(o.ID); booked {
+		return nil
+	}
+	open := 0
+	for _, other := range r.s.book.GetActiveByUser(o.UserID) {
+		if other.Symbol == o.Symbol {
+			open++
+		}
+	}
+	if open >= limit {
+		return fmt.Errorf("%w: %s has %d open orders, max=%d", ErrTooManyOpenOrders, o.Symbol, open, limit)
+	}
+	return nil
+}
+
+// riskLimitRule rejects an order that would take the user's exposure in a
+// symbol past the last risk tier, past the tier the user capped it at, or
+// into a tier whose max leverage is below the user's leverage. Exposure is
+// the position plus every open order on its side filling, valued at the
+// order price; orders that do not raise it always pass.
+type riskLimitRule struct{ s *RiskService }
+
+func (riskLimitRule) Code() domain.RejectCode { return domain.RejectRiskLimit }
+
+func (r riskLimitRule) Check(req *RiskRequest) error {
+	o := req.Order
+	if r.s.instruments == nil || req.Price <= 0 {
+		return nil // a market order without a price is left to the margin check
+	}
+	inst, ok := r.s.instruments.Get(o.Symbol)
+	if !ok {
+		return nil
+	}
+	before := r.s.exposure(o.UserID, o.Symbol, o.PositionSide, nil, decimal.Zero)
+	after := r.s.exposure(o.UserID, o.Symbol, o.PositionSide, o, req.Quantity)
+	if after <= before {
+		return nil
+	}
+
+	// Each order fits in range, but hundreds of them at a new price may not
+	notional, err := after.MulChecked(req.Price)
+	if err != nil {
+		return fmt.Errorf("%w: qty=%s at price=%s is out of range", ErrRiskLimitExceeded, after, req.Price)
+	}
+	n, tier, ok := inst.TierFor(notional)
+	if !ok {
+		return fmt.Errorf("%w: notional=%s above tier %d max=%s", ErrRiskLimitExceeded, notional, n, tier.MaxNotional)
+	}
+	if limit := r.s.position.RiskLimit(o.UserID, o.Symbol); limit > 0 && n > limit {
+		return fmt.Errorf("%w: notional=%s is in tier %d, capped at tier %d", ErrRiskLimitExceeded, notional, n, limit)
+	}
+	if req.Leverage > tier.MaxLeverage {
+		return fmt.Errorf("%w: notional=%s is in tier %d, leverage=%s max=%s",
+			ErrRiskLimitExceeded, notional, n, req.Leverage, tier.MaxLeverage)
+	}
 	return nil
 }
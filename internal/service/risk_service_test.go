@@ -0,0 +1,108 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"oms-contract/internal/domain"
+	"oms-contract/pkg/decimal"
+
+	"github.com/stretchr/testify/require"
+)
+
+// haltUser turns away every order of one user
+type haltUser int64
+
+func (haltUser) Code() domain.RejectCode { return domain.RejectOther }
+
+func (h haltUser) Check(req *RiskRequest) error {
+	if req.Order.UserID == int64(h) {
+		return errors.New("user halted")
+	}
+	return nil
+}
+
+func TestRiskService_RuleChain(t *testing.T) {
+	svc, _, _ := newTestOrderService(t)
+	fund(t, svc, 1000000, 100, 200, 300)
+
+	require.ErrorIs(t, svc.risk.SetConfig(RiskConfig{
+		Rules: []domain.RiskRules{{Group: "vip"}},
+	}), ErrInvalidRiskRules)
+	require.ErrorIs(t, svc.risk.SetConfig(RiskConfig{
+		Rules: []domain.RiskRules{{PriceBand: decimal.One}},
+	}), ErrInvalidRiskRules)
+	require.NoError(t, svc.risk.SetConfig(RiskConfig{
+		Groups: map[string][]int64{"vip": {200}},
+		Rules: []domain.RiskRules{
+			{MaxOpenOrders: 5},
+			{Symbol: "BTCUSDT", MaxOrderQty: decimal.FromInt(10), MaxOrderNotional: decimal.FromInt(100000),
+				MaxOpenOrders: 2, PriceBand: decimal.MustParse("0.05")},
+			{Group: "vip", MaxOrderNotional: decimal.FromInt(1000000), MaxOpenOrders: 3},
+		},
+	}))
+	require.Equal(t, 2, svc.risk.Rules(100, "BTCUSDT").MaxOpenOrders)
+	require.Equal(t, 3, svc.risk.Rules(200, "BTCUSDT").MaxOpenOrders)
+	require.Equal(t, 5, svc.risk.Rules(100, "ETHUSDT").MaxOpenOrders)
+	svc.margin.OnTrade("BTCUSDT", decimal.FromInt(30000))
+
+	order := func(uid int64, price, qty string) error {
+		_, err := svc.CreateOrder(&domain.Order{UserID: uid, Symbol: "BTCUSDT", Side: domain.Buy,
+			Type: domain.Limit, Price: decimal.MustParse(price), Quantity: decimal.MustParse(qty)})
+		return err
+	}
+	code := func(err error) domain.RejectCode {
+		require.Error(t, err)
+		return RejectCodeOf(err)
+	}
+
+	require.Equal(t, domain.RejectInstrument, code(order(100, "30000.05", "1")))
+	require.Equal(t, domain.RejectMaxOrderQty, code(order(100, "30000", "11")))
+	err := order(100, "30000", "4")
+	require.ErrorIs(t, err, ErrOrderNotionalExceeded)
+	require.Equal(t, domain.RejectMaxOrderNotional, code(err))
+	require.Equal(t, domain.RejectPriceBand, code(order(100, "31600", "1")))
+	require.Equal(t, domain.RejectPriceBand, code(order(100, "28400", "1")))
+
+	require.NoError(t, order(100, "29000", "1"))
+	require.NoError(t, order(100, "29000", "1"))
+	require.Equal(t, domain.RejectMaxOpenOrders, code(order(100, "29000", "1")))
+
+	// The group rules replace the symbol's: a larger order, no price band
+	require.NoError(t, order(200, "20000", "4"))
+
+	svc.risk.Use(haltUser(300))
+	require.Equal(t, domain.RejectOther, code(order(300, "29000", "1")))
+	require.Equal(t, domain.RejectMargin, code(order(400, "29000", "1"))) // unfunded
+}
+
+func TestRiskService_ExposureOverflow(t *testing.T) {
+	svc, _, _ := newTestOrderService(t)
+	fund(t, svc, 1000000, 100)
+
+	order := func(price string) (*domain.Order, error) {
+		o := &domain.Order{UserID: 100, Symbol: "BTCUSDT", Side: domain.Buy, Type: domain.Limit,
+			Price: decimal.MustParse(price), Quantity: decimal.FromInt(100)}
+		_, err := svc.CreateOrder(o)
+		return o, err
+	}
+
+	// Max-qty orders resting at a low price, with no mark for a price band
+	var low *domain.Order
+	for i := 0; i < DefaultRiskRules.MaxOpenOrders-1; i++ {
+		o, err := order("1")
+		require.NoError(t, err)
+		low = o
+	}
+
+	// One more at a high price fits on its own, but the exposure it adds up
+	// to does not: a rejection rather than a panic
+	_, err := order("90000000")
+	require.ErrorIs(t, err, ErrRiskLimitExceeded)
+	require.Equal(t, domain.RejectRiskLimit, RejectCodeOf(err))
+
+	// Repricing a resting order adds no quantity; the margin check turns it
+	// away
+	_, err = svc.ReplaceOrder(low.ID, 100, decimal.FromInt(90000000), decimal.FromInt(100))
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"oms-contract/internal/domain"
+	"oms-contract/internal/memory"
+	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
+	"oms-contract/pkg/idgen"
+)
+
+var (
+	ErrStopOrderNotFound = errors.New("stop order not found")
+	ErrInvalidStopOrder  = errors.New("invalid stop order")
+	ErrStopWouldTrigger  = errors.New("stop order would trigger immediately")
+)
+
+// StopService keeps stop-market and stop-limit orders until the last trade
+// price or the mark price, as each order selects, reaches their stop
+// price. Placing, canceling, triggering and activating a stop order are
+// each an event, so replay rebuilds the stop book exactly; OrderService
+// decides when orders trigger and submits what they become.
+type StopService struct {
+	book        *memory.StopBook
+	instruments *InstrumentService
+	eventBus    *snapshot.EventBus
+	idGen       *idgen.Generator
+}
+
+func NewStopService(book *memory.StopBook, instruments *InstrumentService, eb *snapshot.EventBus, idGen *idgen.Generator) *StopService {
+	return &StopService{
+		book:        book,
+		instruments: instruments,
+		eventBus:    eb,
+		idGen:       idGen,
+	}
+}
+
+// Place validates and records a stop order. The trigger price defaults to
+// the last price; current is that price now, zero if unknown. A stop price
+// above it triggers on a rise and one below on a fall; without a current
+// price a buy stop waits for a rise and a sell stop for a fall.
+func (s *StopService) Place(o *domain.StopOrder, current decimal.Decimal) error {
+	if o.TriggerBy == "" {
+		o.TriggerBy = domain.TriggerLastPrice
+	}
+	if err := s.validate(o); err != nil {
+		return err
+	}
+	if current > 0 {
+		if o.StopPrice == current {
+			return fmt.Errorf("%w: stop price %s is the %s", ErrStopWouldTrigger, o.StopPrice, o.TriggerBy)
+		}
+		o.TriggerAbove = o.StopPrice > current
+	} else {
+		o.TriggerAbove = o.Side == domain.Buy
+	}
+
+	o.ID = s.idGen.Next()
+	o.Status = domain.StopPending
+	o.CreatedAt = time.Now()
+
+	event := snapshot.NewEvent(
+		0,
+		snapshot.EventStopOrderCreated,
+		snapshot.StopOrderCreatedData{Order: o},
+	)
+	if s.eventBus != nil {
+		if err := s.eventBus.Publish(event); err != nil {
+			return fmt.Errorf("publish stop order created event: %w", err)
+		}
+	} else {
+		s.book.Add(o)
+	}
+
+	fmt.Printf("[OMS] stop order placed: id=%d %s %s %s stop=%s by=%s\n",
+		o.ID, o.Symbol, o.Side, o.Type, o.StopPrice, o.TriggerBy)
+	return nil
+}
+
+func (s *StopService) validate(o *domain.StopOrder) error {
+	switch {
+	case o.Type != domain.StopMarket && o.Type != domain.StopLimit:
+		return fmt.Errorf("%w: type %s", ErrInvalidStopOrder, o.Type)
+	case o.TriggerBy != domain.TriggerLastPrice && o.TriggerBy != domain.TriggerMarkPrice:
+		return fmt.Errorf("%w: trigger price %s", ErrInvalidStopOrder, o.TriggerBy)
+	case o.StopPrice <= 0:
+		return fmt.Errorf("%w: stop price must be positive", ErrInvalidStopOrder)
+	}
+	if s.instruments == nil {
+		return nil
+	}
+	inst, ok := s.instruments.Get(o.Symbol)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownSymbol, o.Symbol)
+	}
+	if !o.StopPrice.IsMultipleOf(inst.TickSize) {
+		return fmt.Errorf("%w: stop price=%s tick=%s", ErrInvalidTickSize, o.StopPrice, inst.TickSize)
+	}
+	return nil
+}
+
+func (s *StopService) Get(id int64) (*domain.StopOrder, bool) {
+	return s.book.Get(id)
+}
+
+// Pending returns a user's stop orders still waiting for their trigger,
+// optionally filtered by symbol
+func (s *StopService) Pending(userID int64, symbol string) []*domain.StopOrder {
+	return s.book.PendingByUser(userID, symbol)
+}
+
+// Next returns the first pending stop order of a symbol a trigger price
+// moving between low and high has reached, and the price that reached it
+func (s *StopService) Next(symbol string, by domain.TriggerPrice, low, high decimal.Decimal) (*domain.StopOrder, decimal.Decimal, bool) {
+	return s.book.Next(symbol, by, low, high)
+}
+
+// Cancel ends a pending stop order
+func (s *StopService) Cancel(o *domain.StopOrder, reason string) error {
+	event := snapshot.NewEvent(
+		0,
+		snapshot.EventStopOrderCanceled,
+		snapshot.StopOrderCanceledData{
+			StopID: o.ID,
+			UserID: o.UserID,
+			Symbol: o.Symbol,
+			Reason: reason,
+		},
+	)
+	if s.eventBus != nil {
+		if err := s.eventBus.Publish(event); err != nil {
+			return fmt.Errorf("publish stop order canceled event: %w", err)
+		}
+	} else {
+		s.book.Cancel(o.ID)
+	}
+
+	return nil
+}
+
+// Trigger takes a stop order off its queue, triggered at price
+func (s *StopService) Trigger(o *domain.StopOrder, price decimal.Decimal) error {
+	event := snapshot.NewEvent(
+		0,
+		snapshot.EventStopOrderTriggered,
+		snapshot.StopOrderTriggeredData{
+			StopID:      o.ID,
+			Symbol:      o.Symbol,
+			Price:       price,
+			TriggeredAt: time.Now(),
+		},
+	)
+	if s.eventBus != nil {
+		if err := s.eventBus.Publish(event); err != nil {
+			return fmt.Errorf("publish stop order triggered event: %w", err)
+		}
+	} else {
+		s.book.Trigger(o.ID, price, time.Now())
+	}
+	return nil
+}
+
+// Reject records the error that rejected the order a triggered stop order
+// submitted. An accepted order activates its stop order as it is created.
+func (s *StopService) Reject(o *domain.StopOrder, rejected error) error {
+	event := snapshot.NewEvent(
+		0,
+		snapshot.EventStopOrderRejected,
+		snapshot.StopOrderRejectedData{StopID: o.ID, Reason: rejected.Error()},
+	)
+	if s.eventBus != nil {
+		if err := s.eventBus.Publish(event); err != nil {
+			return fmt.Errorf("publish stop order rejected event: %w", err)
+		}
+	} else {
+		s.book.Reject(o.ID, rejected.Error())
+	}
+	return nil
+}
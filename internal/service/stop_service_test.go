@@ -0,0 +1,162 @@
+package service
+
+import (
+	"testing"
+
+	"oms-contract/internal/domain"
+	"oms-contract/internal/engine"
+	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStopService_Trigger(t *testing.T) {
+	svc, state, store := newTestOrderService(t)
+	svc.matching = &engineGateway{engine: engine.NewMatchingEngine()}
+	fund(t, svc, 100000, 100, 200, 300)
+	qty := decimal.MustParse
+
+	place := func(user int64, side domain.Side, price int64, q string) *domain.Order {
+		o := &domain.Order{UserID: user, Symbol: "BTCUSDT", Side: side, Type: domain.Limit,
+			Price: decimal.FromInt(price), Quantity: qty(q)}
+		_, err := svc.CreateOrder(o)
+		require.NoError(t, err)
+		return o
+	}
+
+	_, err := svc.marks.Update("BTCUSDT", decimal.FromInt(30000))
+	require.NoError(t, err)
+
+	// 100 goes long 1 @ 30000
+	place(200, domain.Sell, 30000, "1")
+	place(100, domain.Buy, 30000, "1")
+
+	// A stop at the last price would trigger at once
+	_, err = svc.PlaceStopOrder(&domain.StopOrder{UserID: 100, Symbol: "BTCUSDT", Side: domain.Sell,
+		Type: domain.StopMarket, StopPrice: decimal.FromInt(30000), ClosePosition: true})
+	require.ErrorIs(t, err, ErrStopWouldTrigger)
+	require.Equal(t, domain.RejectStopPrice, RejectCodeOf(err))
+
+	// A stop loss on the last price, a breakout entry on the mark price and
+	// one the user cancels
+	stopLoss := &domain.StopOrder{UserID: 100, Symbol: "BTCUSDT", Side: domain.Sell,
+		Type: domain.StopMarket, StopPrice: decimal.FromInt(29000), ClosePosition: true}
+	_, err = svc.PlaceStopOrder(stopLoss)
+	require.NoError(t, err)
+	require.False(t, stopLoss.TriggerAbove)
+
+	breakout := &domain.StopOrder{UserID: 300, Symbol: "BTCUSDT", Side: domain.Buy, Type: domain.StopLimit,
+		StopPrice: decimal.FromInt(31000), TriggerBy: domain.TriggerMarkPrice,
+		Price: decimal.FromInt(31000), Quantity: qty("1")}
+	_, err = svc.PlaceStopOrder(breakout)
+	require.NoError(t, err)
+	require.True(t, breakout.TriggerAbove)
+
+	canceled := &domain.StopOrder{UserID: 100, Symbol: "BTCUSDT", Side: domain.Buy,
+		Type: domain.StopMarket, StopPrice: decimal.FromInt(35000), Quantity: qty("0.1")}
+	_, err = svc.PlaceStopOrder(canceled)
+	require.NoError(t, err)
+	require.ErrorIs(t, svc.CancelOrder(canceled.ID, 200), ErrOrderNotOwned)
+	require.NoError(t, svc.CancelOrder(canceled.ID, 100))
+	require.ErrorIs(t, svc.CancelOrder(canceled.ID, 100), ErrOrderNotActive)
+	require.Len(t, svc.StopOrders(100, ""), 1)
+
+	// A print at 28950 triggers the stop loss, which sells the whole
+	// position into the bid
+	place(200, domain.Buy, 28950, "2")
+	place(300, domain.Sell, 28950, "0.5")
+
+	stop, _ := state.StopBook.Get(stopLoss.ID)
+	require.Equal(t, domain.StopActivated, stop.Status)
+	require.Equal(t, decimal.FromInt(28950), stop.TriggerPrice)
+	o, ok := state.OrderBook.Get(stop.OrderID)
+	require.True(t, ok)
+	require.Equal(t, domain.Filled, o.Status)
+	require.Equal(t, stopLoss.ID, o.StopID)
+	p, _ := svc.position.Get(100, "BTCUSDT")
+	require.True(t, p.Qty.IsZero())
+	require.Empty(t, svc.StopOrders(100, ""))
+
+	// The last price never reached 31000, but the mark does
+	stop, _ = state.StopBook.Get(breakout.ID)
+	require.Equal(t, domain.StopPending, stop.Status)
+	_, err = svc.marks.Update("BTCUSDT", decimal.FromInt(32000))
+	require.NoError(t, err)
+	require.Equal(t, domain.StopActivated, stop.Status)
+	o, _ = state.OrderBook.Get(stop.OrderID)
+	require.Equal(t, domain.Limit, o.Type)
+	require.Equal(t, decimal.FromInt(31000), o.Price)
+	require.Equal(t, domain.Submitted, o.Status)
+
+	snapMgr, err := snapshot.NewSnapshotManager(t.TempDir(), 1)
+	require.NoError(t, err)
+	replayed, err := snapshot.NewReplayEngine(store, snapMgr).Replay()
+	require.NoError(t, err)
+	for _, id := range []int64{stopLoss.ID, breakout.ID, canceled.ID} {
+		live, _ := state.StopBook.Get(id)
+		s, ok := replayed.StopBook.Get(id)
+		require.True(t, ok)
+		require.Equal(t, live.Status, s.Status)
+		require.Equal(t, live.TriggerPrice, s.TriggerPrice)
+		require.Equal(t, live.OrderID, s.OrderID)
+		require.True(t, live.TriggeredAt.Equal(s.TriggeredAt))
+	}
+	require.Equal(t, domain.StopCanceled, replayed.StopBook.GetAll()[canceled.ID].Status)
+	require.Empty(t, replayed.StopBook.PendingByUser(300, ""))
+	require.Equal(t, state.AccountBook.State(), replayed.AccountBook.State())
+}
+
+func TestStopService_Rejected(t *testing.T) {
+	svc, state, store := newTestOrderService(t)
+	svc.matching = &engineGateway{engine: engine.NewMatchingEngine()}
+	fund(t, svc, 100000, 100, 200, 300)
+
+	_, err := svc.marks.Update("BTCUSDT", decimal.FromInt(30000))
+	require.NoError(t, err)
+	closeStop := func() *domain.StopOrder {
+		return &domain.StopOrder{UserID: 100, Symbol: "BTCUSDT", Side: domain.Sell, Type: domain.StopLimit,
+			StopPrice: decimal.FromInt(29000), Price: decimal.FromInt(28900), ClosePosition: true}
+	}
+
+	// A close-position stop has no quantity yet, but its price and symbol
+	// are checked on entry
+	stop := closeStop()
+	stop.Price = decimal.MustParse("28900.05")
+	_, err = svc.PlaceStopOrder(stop)
+	require.ErrorIs(t, err, ErrInvalidTickSize)
+	require.Equal(t, domain.RejectInstrument, RejectCodeOf(err))
+
+	require.NoError(t, svc.instruments.SetStatus("BTCUSDT", domain.InstrumentHalted))
+	_, err = svc.PlaceStopOrder(closeStop())
+	require.ErrorIs(t, err, ErrSymbolNotTrading)
+	require.NoError(t, svc.instruments.SetStatus("BTCUSDT", domain.InstrumentTrading))
+
+	// With no position to close when it triggers, its order is rejected
+	// and the reason kept on the stop order
+	stop = closeStop()
+	_, err = svc.PlaceStopOrder(stop)
+	require.NoError(t, err)
+	for _, o := range []*domain.Order{
+		{UserID: 200, Side: domain.Sell},
+		{UserID: 300, Side: domain.Buy},
+	} {
+		o.Symbol, o.Type, o.Price, o.Quantity = "BTCUSDT", domain.Limit, decimal.FromInt(28900), decimal.One
+		_, err = svc.CreateOrder(o)
+		require.NoError(t, err)
+	}
+
+	s, _ := state.StopBook.Get(stop.ID)
+	require.Equal(t, domain.StopRejected, s.Status)
+	require.Zero(t, s.OrderID)
+	require.Contains(t, s.RejectReason, ErrReduceOnly.Error())
+
+	snapMgr, err := snapshot.NewSnapshotManager(t.TempDir(), 1)
+	require.NoError(t, err)
+	replayed, err := snapshot.NewReplayEngine(store, snapMgr).Replay()
+	require.NoError(t, err)
+	r, ok := replayed.StopBook.Get(stop.ID)
+	require.True(t, ok)
+	require.Equal(t, domain.StopRejected, r.Status)
+	require.Equal(t, s.RejectReason, r.RejectReason)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"oms-contract/internal/domain"
+	"oms-contract/pkg/decimal"
 )
 
 // EventType represents the type of event in the system
@@ -16,11 +17,28 @@ const (
 	EventOrderCreated    EventType = "ORDER_CREATED"
 	EventOrderFilled     EventType = "ORDER_FILLED"
 	EventOrderCanceled   EventType = "ORDER_CANCELED"
+	EventOrderReplaced   EventType = "ORDER_REPLACED"
 	EventTradeExecuted   EventType = "TRADE_EXECUTED"
 	EventPositionOpened  EventType = "POSITION_OPENED"
 	EventPositionUpdated EventType = "POSITION_UPDATED"
 	EventPositionClosed  EventType = "POSITION_CLOSED"
 	EventLiquidation     EventType = "LIQUIDATION"
+
+	EventInstrumentUpdated EventType = "INSTRUMENT_UPDATED"
+	EventLedgerPosted      EventType = "LEDGER_POSTED"
+	EventPositionModeSet   EventType = "POSITION_MODE_SET"
+	EventMarginModeSet     EventType = "MARGIN_MODE_SET"
+	EventMarkPriceUpdated  EventType = "MARK_PRICE_UPDATED"
+	EventADLExecuted       EventType = "ADL_EXECUTED"
+
+	EventPositionStateChanged EventType = "POSITION_STATE_CHANGED"
+	EventRiskLimitSet         EventType = "RISK_LIMIT_SET"
+	EventFundingSettled       EventType = "FUNDING_SETTLED"
+
+	EventStopOrderCreated   EventType = "STOP_ORDER_CREATED"
+	EventStopOrderCanceled  EventType = "STOP_ORDER_CANCELED"
+	EventStopOrderTriggered EventType = "STOP_ORDER_TRIGGERED"
+	EventStopOrderRejected  EventType = "STOP_ORDER_REJECTED"
 )
 
 // Event represents a single event in the event sourcing system
@@ -37,6 +55,26 @@ type OrderCreatedData struct {
 	Order *domain.Order `json:"order"`
 }
 
+// OrderCanceledData contains data for ORDER_CANCELED event
+type OrderCanceledData struct {
+	OrderID int64  `json:"order_id"`
+	UserID  int64  `json:"user_id"`
+	Symbol  string `json:"symbol"`
+	Reason  string `json:"reason"`
+}
+
+// OrderReplacedData contains data for ORDER_REPLACED event
+type OrderReplacedData struct {
+	OrderID   int64           `json:"order_id"`
+	UserID    int64           `json:"user_id"`
+	Symbol    string          `json:"symbol"`
+	Price     decimal.Decimal `json:"price"`
+	Quantity  decimal.Decimal `json:"quantity"`
+	CreatedAt time.Time       `json:"created_at"`
+
+	FrozenMargin decimal.Decimal `json:"frozen_margin"`
+}
+
 // TradeExecutedData contains data for TRADE_EXECUTED event
 type TradeExecutedData struct {
 	Trade *domain.Trade `json:"trade"`
@@ -50,11 +88,98 @@ type PositionUpdatedData struct {
 
 // LiquidationData contains data for LIQUIDATION event
 type LiquidationData struct {
-	UserID   int64   `json:"user_id"`
-	Symbol   string  `json:"symbol"`
-	Quantity float64 `json:"quantity"`
-	Price    float64 `json:"price"`
-	Reason   string  `json:"reason"`
+	UserID   int64           `json:"user_id"`
+	Symbol   string          `json:"symbol"`
+	Quantity decimal.Decimal `json:"quantity"`
+	Price    decimal.Decimal `json:"price"`
+	Reason   string          `json:"reason"`
+}
+
+// InstrumentUpdatedData contains data for INSTRUMENT_UPDATED event
+type InstrumentUpdatedData struct {
+	Instrument *domain.Instrument `json:"instrument"`
+}
+
+// LedgerPostedData contains data for LEDGER_POSTED event.
+// Entries of one event belong to one business operation and apply together.
+type LedgerPostedData struct {
+	Entries []*domain.JournalEntry `json:"entries"`
+}
+
+// PositionModeSetData contains data for POSITION_MODE_SET event
+type PositionModeSetData struct {
+	UserID int64               `json:"user_id"`
+	Mode   domain.PositionMode `json:"mode"`
+}
+
+// MarginModeSetData contains data for MARGIN_MODE_SET event
+type MarginModeSetData struct {
+	UserID int64             `json:"user_id"`
+	Mode   domain.MarginMode `json:"mode"`
+}
+
+// MarkPriceUpdatedData contains data for MARK_PRICE_UPDATED event
+type MarkPriceUpdatedData struct {
+	MarkPrice *domain.MarkPrice `json:"mark_price"`
+}
+
+// ADLExecutedData contains data for ADL_EXECUTED event. It records the
+// deleveraging itself; the positions and balances it moved are carried by
+// the POSITION_* and LEDGER_POSTED events of its trades.
+type ADLExecutedData struct {
+	ADL *domain.ADLRecord `json:"adl"`
+}
+
+// PositionStateChangedData contains data for POSITION_STATE_CHANGED event
+type PositionStateChangedData struct {
+	UserID int64                   `json:"user_id"`
+	Symbol string                  `json:"symbol"`
+	Side   domain.PositionSide     `json:"side"`
+	From   domain.LiquidationState `json:"from"`
+	To     domain.LiquidationState `json:"to"`
+	Reason string                  `json:"reason"`
+}
+
+// RiskLimitSetData contains data for RISK_LIMIT_SET event
+type RiskLimitSetData struct {
+	UserID int64  `json:"user_id"`
+	Symbol string `json:"symbol"`
+	Tier   int    `json:"tier"` // 0 lifts the cap
+}
+
+// FundingSettledData contains data for FUNDING_SETTLED event
+type FundingSettledData struct {
+	Rate     *domain.FundingRate      `json:"rate"`
+	Payments []*domain.FundingPayment `json:"payments"`
+}
+
+// StopOrderCreatedData contains data for STOP_ORDER_CREATED event
+type StopOrderCreatedData struct {
+	Order *domain.StopOrder `json:"order"`
+}
+
+// StopOrderCanceledData contains data for STOP_ORDER_CANCELED event
+type StopOrderCanceledData struct {
+	StopID int64  `json:"stop_id"`
+	UserID int64  `json:"user_id"`
+	Symbol string `json:"symbol"`
+	Reason string `json:"reason"`
+}
+
+// StopOrderTriggeredData contains data for STOP_ORDER_TRIGGERED event
+type StopOrderTriggeredData struct {
+	StopID      int64           `json:"stop_id"`
+	Symbol      string          `json:"symbol"`
+	Price       decimal.Decimal `json:"price"` // the trigger price that reached the stop price
+	TriggeredAt time.Time       `json:"triggered_at"`
+}
+
+// StopOrderRejectedData contains data for STOP_ORDER_REJECTED event: the
+// order a triggered stop order submitted was rejected. An accepted order
+// activates its stop order through its own ORDER_CREATED event.
+type StopOrderRejectedData struct {
+	StopID int64  `json:"stop_id"`
+	Reason string `json:"reason"`
 }
 
 // NewEvent creates a new event with auto-generated checksum
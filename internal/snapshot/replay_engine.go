@@ -87,6 +87,48 @@ func (re *ReplayEngine) restoreFromSnapshot(snapshot *Snapshot) *SystemState {
 	for _, position := range snapshot.Positions {
 		state.PositionBook.Save(position)
 	}
+	for uid, mode := range snapshot.PositionModes {
+		state.PositionBook.SetMode(uid, mode)
+	}
+	for uid, mode := range snapshot.MarginModes {
+		state.PositionBook.SetMarginMode(uid, mode)
+	}
+	for uid, limits := range snapshot.RiskLimits {
+		for symbol, tier := range limits {
+			state.PositionBook.SetRiskLimit(uid, symbol, tier)
+		}
+	}
+
+	// Restore instruments
+	for _, instrument := range snapshot.Instruments {
+		state.InstrumentBook.Save(instrument)
+	}
+
+	// Restore mark prices
+	for _, mark := range snapshot.MarkPrices {
+		state.MarkPriceBook.Save(mark)
+	}
+
+	// Restore funding rates and the premium sampled since
+	for _, rate := range snapshot.FundingRates {
+		state.FundingBook.Save(rate)
+	}
+	state.FundingBook.RestorePremiums(snapshot.Premiums)
+
+	// Restore stop orders
+	for _, o := range snapshot.StopOrders {
+		state.StopBook.Add(o)
+	}
+
+	// Restore accounts and journal
+	if snapshot.Accounts != nil {
+		state.AccountBook.Restore(snapshot.Accounts)
+	}
+
+	// Restore trade history and volume
+	if snapshot.Trades != nil {
+		state.TradeBook.Restore(snapshot.Trades)
+	}
 
 	return state
 }
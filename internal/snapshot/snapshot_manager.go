@@ -13,15 +13,27 @@ import (
 	"time"
 
 	"oms-contract/internal/domain"
+	"oms-contract/internal/memory"
 )
 
 // Snapshot represents a point-in-time snapshot of the system state
 type Snapshot struct {
-	SequenceID int64                       `json:"sequence_id"`
-	Timestamp  int64                       `json:"timestamp"`
-	Orders     map[int64]*domain.Order     `json:"orders"`
-	Positions  map[string]*domain.Position `json:"positions"`
-	Checksum   string                      `json:"checksum"`
+	SequenceID  int64                         `json:"sequence_id"`
+	Timestamp   int64                         `json:"timestamp"`
+	Orders      map[int64]*domain.Order       `json:"orders"`
+	Positions   map[string]*domain.Position   `json:"positions"`
+	Instruments map[string]*domain.Instrument `json:"instruments"`
+	Accounts    *memory.AccountBookState      `json:"accounts"`
+	Checksum    string                        `json:"checksum"`
+
+	PositionModes map[int64]domain.PositionMode    `json:"position_modes,omitempty"`
+	MarginModes   map[int64]domain.MarginMode      `json:"margin_modes,omitempty"`
+	MarkPrices    map[string]*domain.MarkPrice     `json:"mark_prices,omitempty"`
+	RiskLimits    map[int64]map[string]int         `json:"risk_limits,omitempty"`
+	FundingRates  map[string]*domain.FundingRate   `json:"funding_rates,omitempty"`
+	Premiums      map[string]memory.PremiumSamples `json:"premiums,omitempty"`
+	Trades        *memory.TradeBookState           `json:"trades,omitempty"`
+	StopOrders    map[int64]*domain.StopOrder      `json:"stop_orders,omitempty"`
 }
 
 // SnapshotInfo contains metadata about a snapshot
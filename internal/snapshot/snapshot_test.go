@@ -7,6 +7,7 @@ import (
 
 	"oms-contract/internal/domain"
 	"oms-contract/internal/snapshot"
+	"oms-contract/pkg/decimal"
 )
 
 func TestSnapshotAndReplay(t *testing.T) {
@@ -29,10 +30,10 @@ func TestSnapshotAndReplay(t *testing.T) {
 	systemState := snapshot.NewSystemState()
 
 	// 1. Generate some events
-	order1 := &domain.Order{ID: 1, UserID: 101, Symbol: "BTCUSDT", Price: 50000, Quantity: 1.0, Type: domain.Limit, Side: domain.Buy}
+	order1 := &domain.Order{ID: 1, UserID: 101, Symbol: "BTCUSDT", Price: decimal.FromInt(50000), Quantity: decimal.FromInt(1), Type: domain.Limit, Side: domain.Buy}
 	event1 := snapshot.NewEvent(1, snapshot.EventOrderCreated, snapshot.OrderCreatedData{Order: order1})
 
-	order2 := &domain.Order{ID: 2, UserID: 102, Symbol: "ETHUSDT", Price: 3000, Quantity: 10.0, Type: domain.Limit, Side: domain.Sell}
+	order2 := &domain.Order{ID: 2, UserID: 102, Symbol: "ETHUSDT", Price: decimal.FromInt(3000), Quantity: decimal.FromInt(10), Type: domain.Limit, Side: domain.Sell}
 	event2 := snapshot.NewEvent(2, snapshot.EventOrderCreated, snapshot.OrderCreatedData{Order: order2})
 
 	// 2. Apply events and append to store
@@ -56,7 +57,7 @@ func TestSnapshotAndReplay(t *testing.T) {
 	}
 
 	// 4. Create more events after snapshot
-	order3 := &domain.Order{ID: 3, UserID: 103, Symbol: "BTCUSDT", Price: 51000, Quantity: 0.5, Type: domain.Limit, Side: domain.Sell}
+	order3 := &domain.Order{ID: 3, UserID: 103, Symbol: "BTCUSDT", Price: decimal.FromInt(51000), Quantity: decimal.MustParse("0.5"), Type: domain.Limit, Side: domain.Sell}
 	event3 := snapshot.NewEvent(3, snapshot.EventOrderCreated, snapshot.OrderCreatedData{Order: order3})
 
 	if err := systemState.ApplyEvent(event3); err != nil {
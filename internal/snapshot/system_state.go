@@ -8,19 +8,31 @@ import (
 
 // SystemState represents the complete state of the OMS system
 type SystemState struct {
-	OrderBook    *memory.OrderBook    `json:"-"`
-	PositionBook *memory.PositionBook `json:"-"`
-	LastEventID  int64                `json:"last_event_id"`
-	Timestamp    int64                `json:"timestamp"` // Unix timestamp
+	OrderBook      *memory.OrderBook      `json:"-"`
+	PositionBook   *memory.PositionBook   `json:"-"`
+	InstrumentBook *memory.InstrumentBook `json:"-"`
+	AccountBook    *memory.AccountBook    `json:"-"`
+	MarkPriceBook  *memory.MarkPriceBook  `json:"-"`
+	FundingBook    *memory.FundingBook    `json:"-"`
+	TradeBook      *memory.TradeBook      `json:"-"`
+	StopBook       *memory.StopBook       `json:"-"`
+	LastEventID    int64                  `json:"last_event_id"`
+	Timestamp      int64                  `json:"timestamp"` // Unix timestamp
 }
 
 // NewSystemState creates a new system state
 func NewSystemState() *SystemState {
 	return &SystemState{
-		OrderBook:    memory.NewOrderBook(),
-		PositionBook: memory.NewPositionBook(),
-		LastEventID:  0,
-		Timestamp:    0,
+		OrderBook:      memory.NewOrderBook(),
+		PositionBook:   memory.NewPositionBook(),
+		InstrumentBook: memory.NewInstrumentBook(),
+		AccountBook:    memory.NewAccountBook(),
+		MarkPriceBook:  memory.NewMarkPriceBook(),
+		FundingBook:    memory.NewFundingBook(),
+		TradeBook:      memory.NewTradeBook(),
+		StopBook:       memory.NewStopBook(),
+		LastEventID:    0,
+		Timestamp:      0,
 	}
 }
 
@@ -33,13 +45,40 @@ func (ss *SystemState) ApplyEvent(event *Event) error {
 	case EventOrderCreated:
 		return ss.applyOrderCreated(event)
 	// case EventOrderFilled: // To be implemented if specialized logic needed
-	// case EventOrderCanceled: // To be implemented
+	case EventOrderCanceled:
+		return ss.applyOrderCanceled(event)
+	case EventOrderReplaced:
+		return ss.applyOrderReplaced(event)
 	case EventTradeExecuted:
 		return ss.applyTradeExecuted(event)
 	case EventPositionOpened, EventPositionUpdated, EventPositionClosed:
 		return ss.applyPositionUpdated(event)
 	case EventLiquidation:
 		return ss.applyLiquidation(event)
+	case EventInstrumentUpdated:
+		return ss.applyInstrumentUpdated(event)
+	case EventLedgerPosted:
+		return ss.applyLedgerPosted(event)
+	case EventPositionModeSet:
+		return ss.applyPositionModeSet(event)
+	case EventMarginModeSet:
+		return ss.applyMarginModeSet(event)
+	case EventMarkPriceUpdated:
+		return ss.applyMarkPriceUpdated(event)
+	case EventPositionStateChanged:
+		return ss.applyPositionStateChanged(event)
+	case EventRiskLimitSet:
+		return ss.applyRiskLimitSet(event)
+	case EventFundingSettled:
+		return ss.applyFundingSettled(event)
+	case EventStopOrderCreated:
+		return ss.applyStopOrderCreated(event)
+	case EventStopOrderCanceled:
+		return ss.applyStopOrderCanceled(event)
+	case EventStopOrderTriggered:
+		return ss.applyStopOrderTriggered(event)
+	case EventStopOrderRejected:
+		return ss.applyStopOrderRejected(event)
 	default:
 		// Unknown or unhandled event type for state reconstruction, skip
 		return nil
@@ -55,31 +94,59 @@ func (ss *SystemState) applyOrderCreated(event *Event) error {
 
 	if data.Order != nil {
 		ss.OrderBook.Add(data.Order)
+		if data.Order.StopID != 0 {
+			ss.StopBook.Activate(data.Order.StopID, data.Order.ID)
+		}
 	}
 	return nil
 }
 
-// applyTradeExecuted applies a TRADE_EXECUTED event
+// applyOrderCanceled applies an ORDER_CANCELED event
+func (ss *SystemState) applyOrderCanceled(event *Event) error {
+	var data OrderCanceledData
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+
+	if o, ok := ss.OrderBook.Get(data.OrderID); ok {
+		o.Cancel()
+	}
+	return nil
+}
+
+// applyOrderReplaced applies an ORDER_REPLACED event
+func (ss *SystemState) applyOrderReplaced(event *Event) error {
+	var data OrderReplacedData
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+
+	if o, ok := ss.OrderBook.Get(data.OrderID); ok {
+		o.Price = data.Price
+		o.Quantity = data.Quantity
+		o.CreatedAt = data.CreatedAt
+		o.FrozenMargin = data.FrozenMargin
+	}
+	return nil
+}
+
+// applyTradeExecuted applies a TRADE_EXECUTED event.
+// Position changes are carried by their own POSITION_* events; here only
+// the filled quantity and status of the traded order are updated.
 func (ss *SystemState) applyTradeExecuted(event *Event) error {
 	var data TradeExecutedData
 	if err := json.Unmarshal(event.Data, &data); err != nil {
 		return err
 	}
 
-	// For trade execution, we might need to update the order status
-	// But simply adding the order again (if it contains updated state) works for snapshot
-	// However, usually trade event contains Trade info, not full Order info.
-	// If EventTradeExecuted only has Trade, we need to know how it affects Order/Position
-	// verification depends on what's in the event.
-	// For this implementation, we assume the event might carry updated order state or we just log it.
-	// The crucial part for state is the OrderBook and PositionBook.
-	// If the event sourcing model relies on re-calculating state from inputs, that's one way.
-	// If events carry the *result* state (e.g. "Order updated to Filled"), we just apply that.
-
-	// Let's assume for this simple contract, we rely on the fact that standard flow
-	// produces Order/Position updates which should be captured.
-	// If TradeExecuted is just an output, maybe it doesn't change state directly unless it implies order update.
-	// We'll leave it empty for now unless we change Event definitions to carry resulting state.
+	if data.Trade == nil {
+		return nil
+	}
+	ss.TradeBook.Add(data.Trade)
+	// Liquidation orders are not tracked in the order book
+	if o, ok := ss.OrderBook.Get(data.Trade.OrderID); ok {
+		o.Fill(data.Trade.Qty)
+	}
 	return nil
 }
 
@@ -104,6 +171,153 @@ func (ss *SystemState) applyLiquidation(event *Event) error {
 	return nil
 }
 
+// applyInstrumentUpdated applies an INSTRUMENT_UPDATED event
+func (ss *SystemState) applyInstrumentUpdated(event *Event) error {
+	var data InstrumentUpdatedData
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+
+	if data.Instrument != nil {
+		ss.InstrumentBook.Save(data.Instrument)
+	}
+	return nil
+}
+
+// applyLedgerPosted applies a LEDGER_POSTED event
+func (ss *SystemState) applyLedgerPosted(event *Event) error {
+	var data LedgerPostedData
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+
+	for _, e := range data.Entries {
+		ss.AccountBook.Post(e)
+	}
+	return nil
+}
+
+// applyPositionModeSet applies a POSITION_MODE_SET event
+func (ss *SystemState) applyPositionModeSet(event *Event) error {
+	var data PositionModeSetData
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+
+	ss.PositionBook.SetMode(data.UserID, data.Mode)
+	return nil
+}
+
+// applyMarginModeSet applies a MARGIN_MODE_SET event
+func (ss *SystemState) applyMarginModeSet(event *Event) error {
+	var data MarginModeSetData
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+
+	ss.PositionBook.SetMarginMode(data.UserID, data.Mode)
+	return nil
+}
+
+// applyMarkPriceUpdated applies a MARK_PRICE_UPDATED event
+func (ss *SystemState) applyMarkPriceUpdated(event *Event) error {
+	var data MarkPriceUpdatedData
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+
+	if m := data.MarkPrice; m != nil {
+		ss.MarkPriceBook.Save(m)
+		if m.IndexPrice > 0 {
+			ss.FundingBook.Sample(m.Symbol, m.Premium(), m.UpdatedAt)
+		}
+	}
+	return nil
+}
+
+// applyPositionStateChanged applies a POSITION_STATE_CHANGED event
+func (ss *SystemState) applyPositionStateChanged(event *Event) error {
+	var data PositionStateChangedData
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+
+	ss.PositionBook.SetState(data.UserID, data.Symbol, data.Side, data.To)
+	return nil
+}
+
+// applyRiskLimitSet applies a RISK_LIMIT_SET event
+func (ss *SystemState) applyRiskLimitSet(event *Event) error {
+	var data RiskLimitSetData
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+
+	ss.PositionBook.SetRiskLimit(data.UserID, data.Symbol, data.Tier)
+	return nil
+}
+
+// applyFundingSettled applies a FUNDING_SETTLED event. The payments it
+// lists are booked by the LEDGER_POSTED and POSITION_UPDATED events that
+// follow it.
+func (ss *SystemState) applyFundingSettled(event *Event) error {
+	var data FundingSettledData
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+
+	if data.Rate != nil {
+		ss.FundingBook.Settle(data.Rate)
+	}
+	return nil
+}
+
+// applyStopOrderCreated applies a STOP_ORDER_CREATED event
+func (ss *SystemState) applyStopOrderCreated(event *Event) error {
+	var data StopOrderCreatedData
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+
+	if data.Order != nil {
+		ss.StopBook.Add(data.Order)
+	}
+	return nil
+}
+
+// applyStopOrderCanceled applies a STOP_ORDER_CANCELED event
+func (ss *SystemState) applyStopOrderCanceled(event *Event) error {
+	var data StopOrderCanceledData
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+
+	ss.StopBook.Cancel(data.StopID)
+	return nil
+}
+
+// applyStopOrderTriggered applies a STOP_ORDER_TRIGGERED event
+func (ss *SystemState) applyStopOrderTriggered(event *Event) error {
+	var data StopOrderTriggeredData
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+
+	ss.StopBook.Trigger(data.StopID, data.Price, data.TriggeredAt)
+	return nil
+}
+
+// applyStopOrderRejected applies a STOP_ORDER_REJECTED event
+func (ss *SystemState) applyStopOrderRejected(event *Event) error {
+	var data StopOrderRejectedData
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+
+	ss.StopBook.Reject(data.StopID, data.Reason)
+	return nil
+}
+
 // Clone creates a deep copy of the system state
 func (ss *SystemState) Clone() *SystemState {
 	newState := NewSystemState()
@@ -123,6 +337,49 @@ func (ss *SystemState) Clone() *SystemState {
 		posCopy := *p
 		newState.PositionBook.Save(&posCopy)
 	}
+	for uid, mode := range ss.PositionBook.Modes() {
+		newState.PositionBook.SetMode(uid, mode)
+	}
+	for uid, mode := range ss.PositionBook.MarginModes() {
+		newState.PositionBook.SetMarginMode(uid, mode)
+	}
+	for uid, limits := range ss.PositionBook.RiskLimits() {
+		for symbol, tier := range limits {
+			newState.PositionBook.SetRiskLimit(uid, symbol, tier)
+		}
+	}
+
+	// Deep copy instruments
+	for _, i := range ss.InstrumentBook.GetAll() {
+		instCopy := *i
+		instCopy.RiskTiers = append([]domain.RiskTier(nil), i.RiskTiers...)
+		newState.InstrumentBook.Save(&instCopy)
+	}
+
+	// Deep copy mark prices
+	for _, m := range ss.MarkPriceBook.GetAll() {
+		markCopy := *m
+		newState.MarkPriceBook.Save(&markCopy)
+	}
+
+	// Deep copy funding rates and premium samples
+	for _, r := range ss.FundingBook.GetAll() {
+		rateCopy := *r
+		newState.FundingBook.Save(&rateCopy)
+	}
+	newState.FundingBook.RestorePremiums(ss.FundingBook.Premiums())
+
+	// Deep copy stop orders
+	for _, o := range ss.StopBook.GetAll() {
+		stopCopy := *o
+		newState.StopBook.Add(&stopCopy)
+	}
+
+	// Deep copy accounts and journal
+	newState.AccountBook.Restore(ss.AccountBook.State())
+
+	// Deep copy trade history and volume
+	newState.TradeBook.Restore(ss.TradeBook.State())
 
 	return newState
 }
@@ -136,17 +393,41 @@ func (ss *SystemState) Checksum() (string, error) {
 
 	orders := ss.OrderBook.GetAll()
 	positions := ss.PositionBook.GetAll()
+	instruments := ss.InstrumentBook.GetAll()
+	accounts := ss.AccountBook.State()
 
 	stateData := struct {
-		LastEventID int64                       `json:"last_event_id"`
-		Timestamp   int64                       `json:"timestamp"`
-		Orders      map[int64]*domain.Order     `json:"orders"`
-		Positions   map[string]*domain.Position `json:"positions"`
+		LastEventID int64                         `json:"last_event_id"`
+		Timestamp   int64                         `json:"timestamp"`
+		Orders      map[int64]*domain.Order       `json:"orders"`
+		Positions   map[string]*domain.Position   `json:"positions"`
+		Instruments map[string]*domain.Instrument `json:"instruments"`
+		Accounts    *memory.AccountBookState      `json:"accounts"`
+
+		PositionModes map[int64]domain.PositionMode    `json:"position_modes"`
+		MarginModes   map[int64]domain.MarginMode      `json:"margin_modes"`
+		MarkPrices    map[string]*domain.MarkPrice     `json:"mark_prices"`
+		RiskLimits    map[int64]map[string]int         `json:"risk_limits"`
+		FundingRates  map[string]*domain.FundingRate   `json:"funding_rates"`
+		Premiums      map[string]memory.PremiumSamples `json:"premiums"`
+		Trades        *memory.TradeBookState           `json:"trades"`
+		StopOrders    map[int64]*domain.StopOrder      `json:"stop_orders"`
 	}{
 		LastEventID: ss.LastEventID,
 		Timestamp:   ss.Timestamp,
 		Orders:      orders,
 		Positions:   positions,
+		Instruments: instruments,
+		Accounts:    accounts,
+
+		PositionModes: ss.PositionBook.Modes(),
+		MarginModes:   ss.PositionBook.MarginModes(),
+		MarkPrices:    ss.MarkPriceBook.GetAll(),
+		RiskLimits:    ss.PositionBook.RiskLimits(),
+		FundingRates:  ss.FundingBook.GetAll(),
+		Premiums:      ss.FundingBook.Premiums(),
+		Trades:        ss.TradeBook.State(),
+		StopOrders:    ss.StopBook.GetAll(),
 	}
 
 	return CalculateChecksum(stateData)
@@ -157,10 +438,21 @@ func (ss *SystemState) ToSnapshot() *Snapshot {
 	checksum, _ := ss.Checksum()
 
 	return &Snapshot{
-		SequenceID: ss.LastEventID,
-		Timestamp:  ss.Timestamp,
-		Orders:     ss.OrderBook.GetAll(),
-		Positions:  ss.PositionBook.GetAll(),
-		Checksum:   checksum,
+		SequenceID:  ss.LastEventID,
+		Timestamp:   ss.Timestamp,
+		Orders:      ss.OrderBook.GetAll(),
+		Positions:   ss.PositionBook.GetAll(),
+		Instruments: ss.InstrumentBook.GetAll(),
+		Accounts:    ss.AccountBook.State(),
+		Checksum:    checksum,
+
+		PositionModes: ss.PositionBook.Modes(),
+		MarginModes:   ss.PositionBook.MarginModes(),
+		MarkPrices:    ss.MarkPriceBook.GetAll(),
+		RiskLimits:    ss.PositionBook.RiskLimits(),
+		FundingRates:  ss.FundingBook.GetAll(),
+		Premiums:      ss.FundingBook.Premiums(),
+		Trades:        ss.TradeBook.State(),
+		StopOrders:    ss.StopBook.GetAll(),
 	}
 }
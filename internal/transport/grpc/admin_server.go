@@ -0,0 +1,278 @@
+package grpc
+
+import (
+	"context"
+	"sort"
+
+	omsv1 "oms-contract/api/proto"
+	"oms-contract/internal/domain"
+	"oms-contract/internal/service"
+	"oms-contract/pkg/decimal"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AdminServer implements the OMSAdmin gRPC service
+type AdminServer struct {
+	omsv1.UnimplementedOMSAdminServer
+	instrumentService *service.InstrumentService
+	accountService    *service.AccountService
+	insuranceService  *service.InsuranceService
+	feeService        *service.FeeService
+}
+
+// NewAdminServer creates a new admin gRPC server instance
+func NewAdminServer(is *service.InstrumentService, as *service.AccountService, ins *service.InsuranceService, fees *service.FeeService) *AdminServer {
+	return &AdminServer{
+		instrumentService: is,
+		accountService:    as,
+		insuranceService:  ins,
+		feeService:        fees,
+	}
+}
+
+// UpsertInstrument creates or replaces an instrument spec
+func (s *AdminServer) UpsertInstrument(ctx context.Context, req *omsv1.UpsertInstrumentRequest) (*omsv1.UpsertInstrumentResponse, error) {
+	if req.Instrument == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing instrument")
+	}
+	inst, err := fromProtoInstrument(req.Instrument)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.instrumentService.Upsert(inst); err != nil {
+		return nil, mapServiceError(err)
+	}
+
+	return &omsv1.UpsertInstrumentResponse{Instrument: toProtoInstrument(inst)}, nil
+}
+
+// GetInstrument retrieves an instrument spec by symbol
+func (s *AdminServer) GetInstrument(ctx context.Context, req *omsv1.GetInstrumentRequest) (*omsv1.GetInstrumentResponse, error) {
+	inst, ok := s.instrumentService.Get(req.Symbol)
+	if !ok {
+		return nil, status.Error(codes.NotFound, "instrument not found")
+	}
+	return &omsv1.GetInstrumentResponse{Instrument: toProtoInstrument(inst)}, nil
+}
+
+// ListInstruments returns every instrument sorted by symbol
+func (s *AdminServer) ListInstruments(ctx context.Context, req *omsv1.ListInstrumentsRequest) (*omsv1.ListInstrumentsResponse, error) {
+	list := s.instrumentService.List()
+	sort.Slice(list, func(i, j int) bool { return list[i].Symbol < list[j].Symbol })
+
+	resp := &omsv1.ListInstrumentsResponse{}
+	for _, inst := range list {
+		resp.Instruments = append(resp.Instruments, toProtoInstrument(inst))
+	}
+	return resp, nil
+}
+
+// SetInstrumentStatus halts, resumes or delists a symbol
+func (s *AdminServer) SetInstrumentStatus(ctx context.Context, req *omsv1.SetInstrumentStatusRequest) (*omsv1.SetInstrumentStatusResponse, error) {
+	st, ok := mapInstrumentStatus(req.Status)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid status")
+	}
+
+	if err := s.instrumentService.SetStatus(req.Symbol, st); err != nil {
+		return nil, mapServiceError(err)
+	}
+
+	inst, _ := s.instrumentService.Get(req.Symbol)
+	return &omsv1.SetInstrumentStatusResponse{Instrument: toProtoInstrument(inst)}, nil
+}
+
+// Deposit credits a user's available balance from outside the venue
+func (s *AdminServer) Deposit(ctx context.Context, req *omsv1.TransferRequest) (*omsv1.TransferResponse, error) {
+	return s.transfer(req, s.accountService.Deposit)
+}
+
+// Withdraw debits a user's available balance to outside the venue
+func (s *AdminServer) Withdraw(ctx context.Context, req *omsv1.TransferRequest) (*omsv1.TransferResponse, error) {
+	return s.transfer(req, s.accountService.Withdraw)
+}
+
+func (s *AdminServer) transfer(req *omsv1.TransferRequest, fn func(int64, string, decimal.Decimal) error) (*omsv1.TransferResponse, error) {
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	if req.Asset == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid asset")
+	}
+	amount, err := decimal.Parse(req.Amount)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid amount")
+	}
+
+	if err := fn(req.UserId, req.Asset, amount); err != nil {
+		return nil, mapServiceError(err)
+	}
+
+	a, _ := s.accountService.Get(req.UserId, req.Asset)
+	return &omsv1.TransferResponse{Balance: toProtoBalance(a)}, nil
+}
+
+// GetInsuranceFund returns the insurance fund balance of an asset and its
+// most recent movements
+func (s *AdminServer) GetInsuranceFund(ctx context.Context, req *omsv1.GetInsuranceFundRequest) (*omsv1.GetInsuranceFundResponse, error) {
+	if req.Asset == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid asset")
+	}
+
+	resp := &omsv1.GetInsuranceFundResponse{
+		Asset:   req.Asset,
+		Balance: s.insuranceService.Balance(req.Asset).String(),
+	}
+	if req.Limit > 0 {
+		for _, e := range s.insuranceService.History(req.Asset, int(req.Limit)) {
+			resp.History = append(resp.History, toProtoJournalEntry(e))
+		}
+	}
+	return resp, nil
+}
+
+// GetFeeIncome returns the fee revenue of an asset net of maker rebates
+// and its most recent movements
+func (s *AdminServer) GetFeeIncome(ctx context.Context, req *omsv1.GetFeeIncomeRequest) (*omsv1.GetFeeIncomeResponse, error) {
+	if req.Asset == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid asset")
+	}
+
+	resp := &omsv1.GetFeeIncomeResponse{
+		Asset:   req.Asset,
+		Balance: s.feeService.Income(req.Asset).String(),
+	}
+	if req.Limit > 0 {
+		for _, e := range s.feeService.IncomeHistory(req.Asset, int(req.Limit)) {
+			resp.History = append(resp.History, toProtoJournalEntry(e))
+		}
+	}
+	return resp, nil
+}
+
+// Map helpers
+func fromProtoInstrument(p *omsv1.Instrument) (*domain.Instrument, error) {
+	st, ok := mapInstrumentStatus(p.Status)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid status")
+	}
+
+	inst := &domain.Instrument{
+		Symbol:        p.Symbol,
+		BaseAsset:     p.BaseAsset,
+		SettleAsset:   p.SettleAsset,
+		PriceDecimals: p.PriceDecimals,
+		QtyDecimals:   p.QtyDecimals,
+		Status:        st,
+	}
+
+	fields := []struct {
+		name  string
+		value string
+		dst   *decimal.Decimal
+	}{
+		{"tick_size", p.TickSize, &inst.TickSize},
+		{"lot_size", p.LotSize, &inst.LotSize},
+		{"min_qty", p.MinQty, &inst.MinQty},
+		{"max_qty", p.MaxQty, &inst.MaxQty},
+		{"min_notional", p.MinNotional, &inst.MinNotional},
+		{"max_leverage", p.MaxLeverage, &inst.MaxLeverage},
+		{"maintenance_margin_rate", p.MaintenanceMarginRate, &inst.MaintenanceMarginRate},
+	}
+	// The rest are optional and default to zero
+	optional := []struct {
+		name  string
+		value string
+		dst   *decimal.Decimal
+	}{
+		{"liquidation_step", p.LiquidationStep, &inst.LiquidationStep},
+		{"funding_rate_cap", p.FundingRateCap, &inst.FundingRateCap},
+		{"max_price", p.MaxPrice, &inst.MaxPrice},
+		{"maker_fee_rate", p.MakerFeeRate, &inst.MakerFeeRate},
+		{"taker_fee_rate", p.TakerFeeRate, &inst.TakerFeeRate},
+	}
+	for _, f := range optional {
+		if f.value != "" {
+			fields = append(fields, f)
+		}
+	}
+	for _, f := range fields {
+		v, err := decimal.Parse(f.value)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid %s", f.name)
+		}
+		*f.dst = v
+	}
+	for _, t := range p.RiskTiers {
+		var tier domain.RiskTier
+		for _, f := range []struct {
+			name  string
+			value string
+			dst   *decimal.Decimal
+		}{
+			{"max_notional", t.MaxNotional, &tier.MaxNotional},
+			{"maintenance_margin_rate", t.MaintenanceMarginRate, &tier.MaintenanceMarginRate},
+			{"maintenance_amount", t.MaintenanceAmount, &tier.MaintenanceAmount},
+			{"max_leverage", t.MaxLeverage, &tier.MaxLeverage},
+		} {
+			v, err := decimal.Parse(f.value)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid risk tier %d %s", t.Tier, f.name)
+			}
+			*f.dst = v
+		}
+		inst.RiskTiers = append(inst.RiskTiers, tier)
+	}
+	return inst, nil
+}
+
+func toProtoInstrument(i *domain.Instrument) *omsv1.Instrument {
+	return &omsv1.Instrument{
+		Symbol:                i.Symbol,
+		BaseAsset:             i.BaseAsset,
+		SettleAsset:           i.SettleAsset,
+		TickSize:              i.TickSize.String(),
+		LotSize:               i.LotSize.String(),
+		MinQty:                i.MinQty.String(),
+		MaxQty:                i.MaxQty.String(),
+		MinNotional:           i.MinNotional.String(),
+		MaxPrice:              i.MaxPrice.String(),
+		PriceDecimals:         i.PriceDecimals,
+		QtyDecimals:           i.QtyDecimals,
+		MaxLeverage:           i.MaxLeverage.String(),
+		MaintenanceMarginRate: i.MaintenanceMarginRate.String(),
+		LiquidationStep:       i.LiquidationStep.String(),
+		RiskTiers:             toProtoRiskTiers(i.RiskTiers),
+		FundingRateCap:        i.FundingRateCap.String(),
+		MakerFeeRate:          i.MakerFeeRate.String(),
+		TakerFeeRate:          i.TakerFeeRate.String(),
+		Status:                toProtoInstrumentStatus(i.Status),
+	}
+}
+
+func mapInstrumentStatus(s omsv1.InstrumentStatus) (domain.InstrumentStatus, bool) {
+	switch s {
+	case omsv1.InstrumentStatus_INSTRUMENT_STATUS_TRADING:
+		return domain.InstrumentTrading, true
+	case omsv1.InstrumentStatus_INSTRUMENT_STATUS_HALTED:
+		return domain.InstrumentHalted, true
+	case omsv1.InstrumentStatus_INSTRUMENT_STATUS_DELISTED:
+		return domain.InstrumentDelisted, true
+	}
+	return "", false
+}
+
+func toProtoInstrumentStatus(s domain.InstrumentStatus) omsv1.InstrumentStatus {
+	switch s {
+	case domain.InstrumentTrading:
+		return omsv1.InstrumentStatus_INSTRUMENT_STATUS_TRADING
+	case domain.InstrumentHalted:
+		return omsv1.InstrumentStatus_INSTRUMENT_STATUS_HALTED
+	case domain.InstrumentDelisted:
+		return omsv1.InstrumentStatus_INSTRUMENT_STATUS_DELISTED
+	}
+	return omsv1.InstrumentStatus_INSTRUMENT_STATUS_UNSPECIFIED
+}
@@ -0,0 +1,24 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecoverUnary turns a panic in a handler into an Internal error, so a
+// single bad request cannot take the OMS down. Handlers release their
+// locks in defers, which still run while the panic unwinds.
+func RecoverUnary(ctx context.Context, req any, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("panic in %s: %v\n%s", info.FullMethod, r, debug.Stack())
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(ctx, req)
+}
@@ -2,27 +2,41 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	omsv1 "oms-contract/api/proto"
 	"oms-contract/internal/domain"
 	"oms-contract/internal/service"
+	"oms-contract/pkg/decimal"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Server implements the OMS gRPC service
 type Server struct {
 	omsv1.UnimplementedOMSServer
 	orderService    *service.OrderService
+	instruments     *service.InstrumentService
 	positionService *service.PositionService
+	accountService  *service.AccountService
+	markService     *service.MarkPriceService
+	fundingService  *service.FundingService
+	feeService      *service.FeeService
 }
 
 // NewServer creates a new gRPC server instance
-func NewServer(os *service.OrderService, ps *service.PositionService) *Server {
+func NewServer(os *service.OrderService, is *service.InstrumentService, ps *service.PositionService, as *service.AccountService, ms *service.MarkPriceService, fs *service.FundingService, fees *service.FeeService) *Server {
 	return &Server{
 		orderService:    os,
+		instruments:     is,
 		positionService: ps,
+		accountService:  as,
+		markService:     ms,
+		fundingService:  fs,
+		feeService:      fees,
 	}
 }
 
@@ -34,8 +48,21 @@ func (s *Server) CreateOrder(ctx context.Context, req *omsv1.CreateOrderRequest)
 	if req.Symbol == "" {
 		return nil, status.Error(codes.InvalidArgument, "invalid symbol")
 	}
-	if req.Quantity <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "invalid quantity")
+	var (
+		quantity, price decimal.Decimal
+		err             error
+	)
+	priceScale, qtyScale := s.scale(req.Symbol)
+	// A close-position order is sized by the service
+	if !req.ClosePosition {
+		if quantity, err = decimal.ParseScale(req.Quantity, qtyScale); err != nil || quantity <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid quantity")
+		}
+	}
+	if req.Price != "" {
+		if price, err = decimal.ParseScale(req.Price, priceScale); err != nil || price < 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid price")
+		}
 	}
 
 	order := &domain.Order{
@@ -43,20 +70,104 @@ func (s *Server) CreateOrder(ctx context.Context, req *omsv1.CreateOrderRequest)
 		Symbol:   req.Symbol,
 		Side:     mapSide(req.Side),
 		Type:     mapOrderType(req.Type),
-		Price:    req.Price,
-		Quantity: req.Quantity,
+		Price:    price,
+		Quantity: quantity,
 		// ID will be generated by the service/idgen
+
+		PositionSide:  mapPositionSide(req.PositionSide),
+		ReduceOnly:    req.ReduceOnly,
+		ClosePosition: req.ClosePosition,
+	}
+	if order.Type == domain.StopMarket || order.Type == domain.StopLimit {
+		return s.createStopOrder(req, order)
 	}
 
 	// ID is generated and returned by CreateOrder
-	orderID := s.orderService.CreateOrder(order)
+	orderID, err := s.orderService.CreateOrder(order)
+	if err != nil {
+		// Business rejections are a normal outcome the client must see
+		if order.Status == domain.Rejected {
+			return &omsv1.CreateOrderResponse{
+				Status:       omsv1.OrderStatus_ORDER_STATUS_REJECTED,
+				RejectReason: err.Error(),
+				RejectCode:   toProtoRejectCode(service.RejectCodeOf(err)),
+			}, nil
+		}
+		return nil, mapServiceError(err)
+	}
 
 	return &omsv1.CreateOrderResponse{
 		OrderId: orderID,
+		Status:  mapOrderStatus(order.Status),
+	}, nil
+}
+
+// createStopOrder places a stop order; the ID returned is the stop order's
+// until it triggers
+func (s *Server) createStopOrder(req *omsv1.CreateOrderRequest, o *domain.Order) (*omsv1.CreateOrderResponse, error) {
+	priceScale, _ := s.scale(o.Symbol)
+	stopPrice, err := decimal.ParseScale(req.StopPrice, priceScale)
+	if err != nil || stopPrice <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid stop_price")
+	}
+	if o.Type == domain.StopLimit && o.Price <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid price")
+	}
+
+	stop := &domain.StopOrder{
+		UserID:        o.UserID,
+		Symbol:        o.Symbol,
+		Side:          o.Side,
+		Type:          o.Type,
+		PositionSide:  o.PositionSide,
+		StopPrice:     stopPrice,
+		TriggerBy:     mapTriggerPrice(req.TriggerBy),
+		Price:         o.Price,
+		Quantity:      o.Quantity,
+		ReduceOnly:    o.ReduceOnly,
+		ClosePosition: o.ClosePosition,
+	}
+	stopID, err := s.orderService.PlaceStopOrder(stop)
+	if err != nil {
+		if code := service.RejectCodeOf(err); code != domain.RejectNone {
+			return &omsv1.CreateOrderResponse{
+				Status:       omsv1.OrderStatus_ORDER_STATUS_REJECTED,
+				RejectReason: err.Error(),
+				RejectCode:   toProtoRejectCode(code),
+			}, nil
+		}
+		return nil, mapServiceError(err)
+	}
+
+	return &omsv1.CreateOrderResponse{
+		OrderId: stopID,
 		Status:  omsv1.OrderStatus_ORDER_STATUS_SUBMITTED,
 	}, nil
 }
 
+// scale returns the decimals a symbol's prices and quantities are quoted in.
+// An unknown symbol gets full precision and is turned away by the service.
+func (s *Server) scale(symbol string) (price, qty int32) {
+	if inst, ok := s.instruments.Get(symbol); ok {
+		return inst.PriceScale(), inst.QtyScale()
+	}
+	return decimal.Precision, decimal.Precision
+}
+
+// GetStopOrders lists a user's stop orders still waiting for their trigger
+func (s *Server) GetStopOrders(ctx context.Context, req *omsv1.GetStopOrdersRequest) (*omsv1.GetStopOrdersResponse, error) {
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	resp := &omsv1.GetStopOrdersResponse{}
+	for _, o := range s.orderService.StopOrders(req.UserId, req.Symbol) {
+		priceScale, qtyScale := s.scale(o.Symbol)
+		resp.StopOrders = append(resp.StopOrders, toProtoStopOrder(o, priceScale, qtyScale))
+	}
+	return resp, nil
+}
+
 // GetOrder retrieves an order (Not implemented in service yet, but let's add placeholder)
 func (s *Server) GetOrder(ctx context.Context, req *omsv1.GetOrderRequest) (*omsv1.GetOrderResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetOrder not implemented")
@@ -64,29 +175,435 @@ func (s *Server) GetOrder(ctx context.Context, req *omsv1.GetOrderRequest) (*oms
 
 // CancelOrder handles cancel requests
 func (s *Server) CancelOrder(ctx context.Context, req *omsv1.CancelOrderRequest) (*omsv1.CancelOrderResponse, error) {
-	// Not implemented in service yet
-	return nil, status.Error(codes.Unimplemented, "method CancelOrder not implemented")
+	if req.OrderId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid order_id")
+	}
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	if err := s.orderService.CancelOrder(req.OrderId, req.UserId); err != nil {
+		return nil, mapServiceError(err)
+	}
+
+	return &omsv1.CancelOrderResponse{Success: true}, nil
+}
+
+// AmendOrder handles cancel-replace requests
+func (s *Server) AmendOrder(ctx context.Context, req *omsv1.AmendOrderRequest) (*omsv1.AmendOrderResponse, error) {
+	if req.OrderId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid order_id")
+	}
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	// The request carries no symbol; the service's tick and lot checks hold
+	// the new price and quantity to the instrument's scale
+	price, err := decimal.Parse(req.Price)
+	if err != nil || price <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid price")
+	}
+	quantity, err := decimal.Parse(req.Quantity)
+	if err != nil || quantity <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid quantity")
+	}
+
+	order, err := s.orderService.ReplaceOrder(req.OrderId, req.UserId, price, quantity)
+	if err != nil {
+		return nil, mapServiceError(err)
+	}
+
+	return &omsv1.AmendOrderResponse{
+		OrderId: order.ID,
+		Status:  mapOrderStatus(order.Status),
+	}, nil
 }
 
 // GetPosition retrieves a position
 func (s *Server) GetPosition(ctx context.Context, req *omsv1.GetPositionRequest) (*omsv1.GetPositionResponse, error) {
-	position, ok := s.positionService.Get(req.UserId, req.Symbol)
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	position, ok := s.orderService.GetPosition(req.UserId, req.Symbol, mapPositionSide(req.PositionSide))
 	if !ok {
 		return nil, status.Error(codes.NotFound, "position not found")
 	}
 
-	return &omsv1.GetPositionResponse{
-		UserId:        position.UserID,
-		Symbol:        position.Symbol,
-		Quantity:      position.Qty,
-		EntryPrice:    position.EntryPrice,
-		Margin:        position.Margin,
-		Leverage:      position.Leverage,
-		UnrealizedPnl: position.UnrealizedPnL,
+	_, qtyScale := s.scale(req.Symbol)
+	resp := &omsv1.GetPositionResponse{
+		UserId:           position.UserID,
+		Symbol:           position.Symbol,
+		Quantity:         position.Qty.StringScale(qtyScale),
+		EntryPrice:       position.EntryPrice.String(),
+		Margin:           position.Margin.String(),
+		Leverage:         position.Leverage.String(),
+		UnrealizedPnl:    position.UnrealizedPnL.String(),
+		RealizedPnl:      position.RealizedPnL.String(),
+		PositionSide:     toProtoPositionSide(position.Side),
+		LiquidationPrice: position.LiquidationPrice.String(),
+		BankruptcyPrice:  position.BankruptcyPrice.String(),
+		AdlQuantile:      int32(position.ADLQuantile),
+		LiquidationState: string(position.State),
+	}
+	if position.State == "" {
+		resp.LiquidationState = string(domain.PositionNormal)
+	}
+	if !position.MarkPrice.IsZero() {
+		resp.MarkPrice = position.MarkPrice.String()
+	}
+	return resp, nil
+}
+
+// SetLeverage changes a user's leverage for a symbol
+func (s *Server) SetLeverage(ctx context.Context, req *omsv1.SetLeverageRequest) (*omsv1.SetLeverageResponse, error) {
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	leverage, err := decimal.Parse(req.Leverage)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid leverage")
+	}
+
+	positions, err := s.orderService.SetLeverage(req.UserId, req.Symbol, leverage)
+	if err != nil {
+		return nil, mapServiceError(err)
+	}
+
+	var margin decimal.Decimal
+	for _, p := range positions {
+		margin += p.Margin
+	}
+
+	return &omsv1.SetLeverageResponse{
+		UserId:   req.UserId,
+		Symbol:   req.Symbol,
+		Leverage: leverage.String(),
+		Margin:   margin.String(),
 	}, nil
 }
 
+// SetPositionMode switches a user between one-way and hedge mode
+func (s *Server) SetPositionMode(ctx context.Context, req *omsv1.SetPositionModeRequest) (*omsv1.SetPositionModeResponse, error) {
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	mode, ok := mapPositionMode(req.Mode)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid mode")
+	}
+
+	if err := s.orderService.SetPositionMode(req.UserId, mode); err != nil {
+		return nil, mapServiceError(err)
+	}
+
+	return &omsv1.SetPositionModeResponse{UserId: req.UserId, Mode: req.Mode}, nil
+}
+
+// SetMarginMode switches a user between isolated and cross margin
+func (s *Server) SetMarginMode(ctx context.Context, req *omsv1.SetMarginModeRequest) (*omsv1.SetMarginModeResponse, error) {
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	mode, ok := mapMarginMode(req.Mode)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid mode")
+	}
+
+	if err := s.orderService.SetMarginMode(req.UserId, mode); err != nil {
+		return nil, mapServiceError(err)
+	}
+
+	return &omsv1.SetMarginModeResponse{UserId: req.UserId, Mode: req.Mode}, nil
+}
+
+// GetRiskLimit returns a symbol's risk tiers with the user's selected and current tier
+func (s *Server) GetRiskLimit(ctx context.Context, req *omsv1.GetRiskLimitRequest) (*omsv1.GetRiskLimitResponse, error) {
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	if req.Symbol == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid symbol")
+	}
+
+	tiers, selected, current, err := s.orderService.RiskLimit(req.UserId, req.Symbol)
+	if err != nil {
+		return nil, mapServiceError(err)
+	}
+
+	return &omsv1.GetRiskLimitResponse{
+		UserId:       req.UserId,
+		Symbol:       req.Symbol,
+		Tiers:        toProtoRiskTiers(tiers),
+		SelectedTier: int32(selected),
+		CurrentTier:  int32(current),
+	}, nil
+}
+
+// SetRiskLimit caps the user's positions in a symbol at a risk tier
+func (s *Server) SetRiskLimit(ctx context.Context, req *omsv1.SetRiskLimitRequest) (*omsv1.SetRiskLimitResponse, error) {
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	if req.Symbol == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid symbol")
+	}
+
+	if err := s.orderService.SetRiskLimit(req.UserId, req.Symbol, int(req.Tier)); err != nil {
+		return nil, mapServiceError(err)
+	}
+
+	return &omsv1.SetRiskLimitResponse{UserId: req.UserId, Symbol: req.Symbol, Tier: req.Tier}, nil
+}
+
+// GetAccount returns a user's balances and recent ledger entries
+func (s *Server) GetAccount(ctx context.Context, req *omsv1.GetAccountRequest) (*omsv1.GetAccountResponse, error) {
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	resp := &omsv1.GetAccountResponse{
+		UserId:     req.UserId,
+		MarginMode: toProtoMarginMode(s.positionService.MarginMode(req.UserId)),
+	}
+	for _, a := range s.accountService.List(req.UserId) {
+		resp.Balances = append(resp.Balances, toProtoBalance(a))
+	}
+	if req.JournalLimit > 0 {
+		for _, e := range s.accountService.Journal(req.UserId, int(req.JournalLimit)) {
+			resp.Journal = append(resp.Journal, toProtoJournalEntry(e))
+		}
+	}
+	return resp, nil
+}
+
+// GetTrades returns a user's most recent fills with their fees
+func (s *Server) GetTrades(ctx context.Context, req *omsv1.GetTradesRequest) (*omsv1.GetTradesResponse, error) {
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	resp := &omsv1.GetTradesResponse{}
+	for _, t := range s.feeService.Trades(req.UserId, req.Symbol, int(req.Limit)) {
+		priceScale, qtyScale := s.scale(t.Symbol)
+		resp.Trades = append(resp.Trades, toProtoTrade(t, priceScale, qtyScale))
+	}
+	return resp, nil
+}
+
+// GetFeeRate returns a user's VIP level and the rates it pays on a symbol
+func (s *Server) GetFeeRate(ctx context.Context, req *omsv1.GetFeeRateRequest) (*omsv1.GetFeeRateResponse, error) {
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	if req.Symbol == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid symbol")
+	}
+
+	now := time.Now()
+	level, _ := s.feeService.Level(req.UserId, now)
+	maker, taker := s.feeService.Rates(req.UserId, req.Symbol, now)
+	return &omsv1.GetFeeRateResponse{
+		UserId:       req.UserId,
+		Symbol:       req.Symbol,
+		VipLevel:     int32(level),
+		Volume_30D:   s.feeService.Volume(req.UserId, now).String(),
+		MakerFeeRate: maker.String(),
+		TakerFeeRate: taker.String(),
+	}, nil
+}
+
+// GetMarkPrice returns the current index and mark price of a symbol
+func (s *Server) GetMarkPrice(ctx context.Context, req *omsv1.GetMarkPriceRequest) (*omsv1.GetMarkPriceResponse, error) {
+	if req.Symbol == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid symbol")
+	}
+
+	m, ok := s.markService.Get(req.Symbol)
+	if !ok {
+		return nil, status.Error(codes.NotFound, "mark price not found")
+	}
+
+	return &omsv1.GetMarkPriceResponse{
+		Symbol:     m.Symbol,
+		IndexPrice: m.IndexPrice.String(),
+		MarkPrice:  m.Price.String(),
+		Basis:      m.Basis.String(),
+		UpdatedAt:  timestamppb.New(m.UpdatedAt),
+	}, nil
+}
+
+// GetFundingRate returns the predicted funding rate of the current
+// interval and the last settled one
+func (s *Server) GetFundingRate(ctx context.Context, req *omsv1.GetFundingRateRequest) (*omsv1.GetFundingRateResponse, error) {
+	if req.Symbol == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid symbol")
+	}
+	if _, ok := s.markService.Get(req.Symbol); !ok {
+		return nil, status.Error(codes.NotFound, "mark price not found")
+	}
+
+	now := time.Now()
+	resp := &omsv1.GetFundingRateResponse{
+		Symbol:          req.Symbol,
+		PredictedRate:   s.fundingService.Predicted(req.Symbol, now).String(),
+		PremiumIndex:    s.fundingService.PremiumIndex(req.Symbol, now).String(),
+		NextFundingTime: timestamppb.New(s.fundingService.NextFundingTime(now)),
+	}
+	if last, ok := s.fundingService.Last(req.Symbol); ok {
+		resp.LastRate = last.Rate.String()
+		resp.LastFundingTime = timestamppb.New(last.FundingTime)
+		resp.LastMarkPrice = last.MarkPrice.String()
+	}
+	return resp, nil
+}
+
 // Map helpers
+func toProtoBalance(a *domain.Account) *omsv1.Balance {
+	return &omsv1.Balance{
+		Asset:     a.Asset,
+		Available: a.Available.String(),
+		Frozen:    a.Frozen.String(),
+		Margin:    a.Margin.String(),
+		Total:     a.Total().String(),
+	}
+}
+
+func toProtoJournalEntry(e *domain.JournalEntry) *omsv1.JournalEntry {
+	return &omsv1.JournalEntry{
+		Id:        e.ID,
+		Type:      string(e.Type),
+		Asset:     e.Asset,
+		Amount:    e.Amount.String(),
+		Debit:     e.Debit.String(),
+		Credit:    e.Credit.String(),
+		RefId:     e.RefID,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+	}
+}
+
+func toProtoTrade(t *domain.Trade, priceScale, qtyScale int32) *omsv1.Trade {
+	return &omsv1.Trade{
+		TradeId:      t.TradeID,
+		OrderId:      t.OrderID,
+		Symbol:       t.Symbol,
+		Side:         toProtoSide(t.Side),
+		PositionSide: toProtoPositionSide(t.PositionSide),
+		Price:        t.Price.StringScale(priceScale),
+		Quantity:     t.Qty.StringScale(qtyScale),
+		IsMaker:      t.IsMaker,
+		Fee:          t.Fee.String(),
+		FeeAsset:     t.FeeAsset,
+		CreatedAt:    timestamppb.New(t.CreatedAt),
+	}
+}
+
+func toProtoStopOrder(o *domain.StopOrder, priceScale, qtyScale int32) *omsv1.StopOrder {
+	return &omsv1.StopOrder{
+		StopId:        o.ID,
+		UserId:        o.UserID,
+		Symbol:        o.Symbol,
+		Side:          toProtoSide(o.Side),
+		Type:          toProtoOrderType(o.Type),
+		PositionSide:  toProtoPositionSide(o.PositionSide),
+		StopPrice:     o.StopPrice.StringScale(priceScale),
+		TriggerBy:     toProtoTriggerPrice(o.TriggerBy),
+		Price:         o.Price.StringScale(priceScale),
+		Quantity:      o.Quantity.StringScale(qtyScale),
+		ReduceOnly:    o.ReduceOnly,
+		ClosePosition: o.ClosePosition,
+		Status:        toProtoStopStatus(o.Status),
+		CreatedAt:     timestamppb.New(o.CreatedAt),
+	}
+}
+
+func toProtoRiskTiers(tiers []domain.RiskTier) []*omsv1.RiskTier {
+	out := make([]*omsv1.RiskTier, 0, len(tiers))
+	for n, t := range tiers {
+		out = append(out, &omsv1.RiskTier{
+			Tier:                  int32(n + 1),
+			MaxNotional:           t.MaxNotional.String(),
+			MaintenanceMarginRate: t.MaintenanceMarginRate.String(),
+			MaintenanceAmount:     t.MaintenanceAmount.String(),
+			MaxLeverage:           t.MaxLeverage.String(),
+		})
+	}
+	return out
+}
+
+func mapServiceError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrOrderNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrOrderNotOwned):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrOrderNotActive):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrInvalidAmend),
+		errors.Is(err, service.ErrUnknownSymbol),
+		errors.Is(err, service.ErrInvalidTickSize),
+		errors.Is(err, service.ErrInvalidLotSize),
+		errors.Is(err, service.ErrQuantityTooSmall),
+		errors.Is(err, service.ErrQuantityTooLarge),
+		errors.Is(err, service.ErrNotionalTooSmall),
+		errors.Is(err, service.ErrPriceTooHigh),
+		errors.Is(err, service.ErrNotionalTooLarge),
+		errors.Is(err, service.ErrInvalidInstrument),
+		errors.Is(err, service.ErrInvalidLeverage),
+		errors.Is(err, service.ErrInvalidPositionSide),
+		errors.Is(err, service.ErrCloseExceedsPosition),
+		errors.Is(err, service.ErrReduceOnly),
+		errors.Is(err, service.ErrInvalidRiskTier),
+		errors.Is(err, service.ErrOrderQtyExceeded),
+		errors.Is(err, service.ErrOrderNotionalExceeded),
+		errors.Is(err, service.ErrPriceOutOfBand),
+		errors.Is(err, service.ErrInvalidStopOrder),
+		errors.Is(err, service.ErrStopWouldTrigger):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrInvalidAmount):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrInsufficientBalance),
+		errors.Is(err, service.ErrLeverageTooHigh),
+		errors.Is(err, service.ErrPositionModeChange),
+		errors.Is(err, service.ErrMarginModeChange),
+		errors.Is(err, service.ErrPositionLiquidating),
+		errors.Is(err, service.ErrRiskLimitExceeded),
+		errors.Is(err, service.ErrTooManyOpenOrders):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrSymbolNotTrading):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func toProtoRejectCode(c domain.RejectCode) omsv1.RejectCode {
+	switch c {
+	case domain.RejectInstrument:
+		return omsv1.RejectCode_REJECT_CODE_INSTRUMENT
+	case domain.RejectPositionSide:
+		return omsv1.RejectCode_REJECT_CODE_POSITION_SIDE
+	case domain.RejectReduceOnly:
+		return omsv1.RejectCode_REJECT_CODE_REDUCE_ONLY
+	case domain.RejectMaxOrderQty:
+		return omsv1.RejectCode_REJECT_CODE_MAX_ORDER_QTY
+	case domain.RejectMaxOrderNotional:
+		return omsv1.RejectCode_REJECT_CODE_MAX_ORDER_NOTIONAL
+	case domain.RejectPriceBand:
+		return omsv1.RejectCode_REJECT_CODE_PRICE_BAND
+	case domain.RejectMaxOpenOrders:
+		return omsv1.RejectCode_REJECT_CODE_MAX_OPEN_ORDERS
+	case domain.RejectRiskLimit:
+		return omsv1.RejectCode_REJECT_CODE_RISK_LIMIT
+	case domain.RejectMargin:
+		return omsv1.RejectCode_REJECT_CODE_MARGIN
+	case domain.RejectStopPrice:
+		return omsv1.RejectCode_REJECT_CODE_STOP_PRICE
+	case domain.RejectNone:
+		return omsv1.RejectCode_REJECT_CODE_UNSPECIFIED
+	}
+	return omsv1.RejectCode_REJECT_CODE_OTHER
+}
+
 func mapSide(s omsv1.Side) domain.Side {
 	switch s {
 	case omsv1.Side_SIDE_BUY:
@@ -98,12 +615,130 @@ func mapSide(s omsv1.Side) domain.Side {
 	return domain.Buy
 }
 
+func toProtoSide(s domain.Side) omsv1.Side {
+	if s == domain.Sell {
+		return omsv1.Side_SIDE_SELL
+	}
+	return omsv1.Side_SIDE_BUY
+}
+
 func mapOrderType(t omsv1.OrderType) domain.OrderType {
 	switch t {
 	case omsv1.OrderType_ORDER_TYPE_LIMIT:
 		return domain.Limit
 	case omsv1.OrderType_ORDER_TYPE_MARKET:
 		return domain.Market
+	case omsv1.OrderType_ORDER_TYPE_STOP_MARKET:
+		return domain.StopMarket
+	case omsv1.OrderType_ORDER_TYPE_STOP_LIMIT:
+		return domain.StopLimit
 	}
 	return domain.Limit
 }
+
+func toProtoOrderType(t domain.OrderType) omsv1.OrderType {
+	switch t {
+	case domain.Limit:
+		return omsv1.OrderType_ORDER_TYPE_LIMIT
+	case domain.Market:
+		return omsv1.OrderType_ORDER_TYPE_MARKET
+	case domain.StopMarket:
+		return omsv1.OrderType_ORDER_TYPE_STOP_MARKET
+	case domain.StopLimit:
+		return omsv1.OrderType_ORDER_TYPE_STOP_LIMIT
+	}
+	return omsv1.OrderType_ORDER_TYPE_UNSPECIFIED
+}
+
+func mapTriggerPrice(t omsv1.TriggerPriceType) domain.TriggerPrice {
+	if t == omsv1.TriggerPriceType_TRIGGER_PRICE_TYPE_MARK {
+		return domain.TriggerMarkPrice
+	}
+	return domain.TriggerLastPrice
+}
+
+func toProtoTriggerPrice(t domain.TriggerPrice) omsv1.TriggerPriceType {
+	if t == domain.TriggerMarkPrice {
+		return omsv1.TriggerPriceType_TRIGGER_PRICE_TYPE_MARK
+	}
+	return omsv1.TriggerPriceType_TRIGGER_PRICE_TYPE_LAST
+}
+
+func toProtoStopStatus(s domain.StopStatus) omsv1.StopOrderStatus {
+	switch s {
+	case domain.StopPending:
+		return omsv1.StopOrderStatus_STOP_ORDER_STATUS_PENDING
+	case domain.StopTriggered:
+		return omsv1.StopOrderStatus_STOP_ORDER_STATUS_TRIGGERED
+	case domain.StopActivated:
+		return omsv1.StopOrderStatus_STOP_ORDER_STATUS_ACTIVATED
+	case domain.StopRejected:
+		return omsv1.StopOrderStatus_STOP_ORDER_STATUS_REJECTED
+	case domain.StopCanceled:
+		return omsv1.StopOrderStatus_STOP_ORDER_STATUS_CANCELED
+	}
+	return omsv1.StopOrderStatus_STOP_ORDER_STATUS_UNSPECIFIED
+}
+
+func mapPositionSide(s omsv1.PositionSide) domain.PositionSide {
+	switch s {
+	case omsv1.PositionSide_POSITION_SIDE_LONG:
+		return domain.PositionLong
+	case omsv1.PositionSide_POSITION_SIDE_SHORT:
+		return domain.PositionShort
+	}
+	return domain.PositionBoth
+}
+
+func toProtoPositionSide(s domain.PositionSide) omsv1.PositionSide {
+	switch s {
+	case domain.PositionLong:
+		return omsv1.PositionSide_POSITION_SIDE_LONG
+	case domain.PositionShort:
+		return omsv1.PositionSide_POSITION_SIDE_SHORT
+	}
+	return omsv1.PositionSide_POSITION_SIDE_BOTH
+}
+
+func mapPositionMode(m omsv1.PositionMode) (domain.PositionMode, bool) {
+	switch m {
+	case omsv1.PositionMode_POSITION_MODE_ONE_WAY:
+		return domain.OneWayMode, true
+	case omsv1.PositionMode_POSITION_MODE_HEDGE:
+		return domain.HedgeMode, true
+	}
+	return "", false
+}
+
+func mapMarginMode(m omsv1.MarginMode) (domain.MarginMode, bool) {
+	switch m {
+	case omsv1.MarginMode_MARGIN_MODE_ISOLATED:
+		return domain.IsolatedMargin, true
+	case omsv1.MarginMode_MARGIN_MODE_CROSS:
+		return domain.CrossMargin, true
+	}
+	return "", false
+}
+
+func toProtoMarginMode(m domain.MarginMode) omsv1.MarginMode {
+	if m == domain.CrossMargin {
+		return omsv1.MarginMode_MARGIN_MODE_CROSS
+	}
+	return omsv1.MarginMode_MARGIN_MODE_ISOLATED
+}
+
+func mapOrderStatus(s domain.OrderStatus) omsv1.OrderStatus {
+	switch s {
+	case domain.Submitted:
+		return omsv1.OrderStatus_ORDER_STATUS_SUBMITTED
+	case domain.PartFilled:
+		return omsv1.OrderStatus_ORDER_STATUS_PARTIALLY_FILLED
+	case domain.Filled:
+		return omsv1.OrderStatus_ORDER_STATUS_FILLED
+	case domain.Canceled:
+		return omsv1.OrderStatus_ORDER_STATUS_CANCELED
+	case domain.Rejected:
+		return omsv1.OrderStatus_ORDER_STATUS_REJECTED
+	}
+	return omsv1.OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
@@ -0,0 +1,385 @@
+// Package decimal implements the fixed-point number used for every price,
+// quantity and monetary amount in the OMS.
+//
+// A Decimal is an int64 holding the value scaled by 10^Precision. Addition,
+// subtraction, comparison and multiplication/division by plain integers can
+// use the native Go operators; products and quotients of two Decimals must go
+// through Mul and Div, which use 128-bit intermediates and round half away
+// from zero, so that results are exact and identical on every replay.
+// Untyped constants are raw scaled units: write FromInt(10), never a bare 10.
+//
+// Precision is the storage scale shared by every symbol. Each instrument
+// carries its own, coarser scale for prices and quantities (for example 2
+// decimals for a BTC price): ParseScale enforces it on the way in and
+// StringScale renders it on the way out; use Round or Truncate to quantize.
+package decimal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// ErrOverflow is returned by the checked operations when a result cannot be
+// represented
+var ErrOverflow = errors.New("decimal: overflow")
+
+// Precision is the number of decimal places stored
+const Precision = 8
+
+// Scale is 10^Precision, the raw value of One
+const Scale = 100_000_000
+
+type Decimal int64
+
+const (
+	Zero Decimal = 0
+	One  Decimal = Scale
+)
+
+var pow10 = [...]int64{
+	1, 10, 100, 1_000, 10_000, 100_000, 1_000_000, 10_000_000, 100_000_000,
+}
+
+// New returns value × 10^exp, e.g. New(5, -3) == 0.005.
+// It panics if the result cannot be represented.
+func New(value int64, exp int32) Decimal {
+	shift := int(exp) + Precision
+	switch {
+	case shift < 0:
+		if -shift >= len(pow10) {
+			return Zero
+		}
+		return Decimal(roundDiv(value, pow10[-shift]))
+	case shift >= len(pow10):
+		panic(fmt.Sprintf("decimal: exponent %d out of range", exp))
+	}
+	hi, lo := bits.Mul64(uabs(value), uint64(pow10[shift]))
+	return Decimal(signed(hi, lo, value < 0))
+}
+
+// FromInt converts an integer
+func FromInt(i int64) Decimal {
+	return New(i, 0)
+}
+
+// FromFloat converts a float64, rounding to Precision places.
+// Only use it at system boundaries (config files, demos).
+func FromFloat(f float64) Decimal {
+	return Decimal(math.Round(f * Scale))
+}
+
+// Parse reads a plain decimal string such as "-42000.125".
+// More than Precision fractional digits is an error rather than a silent rounding.
+func Parse(s string) (Decimal, error) {
+	str := strings.TrimSpace(s)
+	if str == "" {
+		return Zero, fmt.Errorf("decimal: empty string")
+	}
+
+	neg := false
+	switch str[0] {
+	case '-':
+		neg = true
+		str = str[1:]
+	case '+':
+		str = str[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(str, ".")
+	if intPart == "" && fracPart == "" {
+		return Zero, fmt.Errorf("decimal: invalid %q", s)
+	}
+	if len(fracPart) > Precision {
+		return Zero, fmt.Errorf("decimal: %q has more than %d decimal places", s, Precision)
+	}
+
+	var whole, frac uint64
+	var err error
+	if intPart != "" {
+		if whole, err = strconv.ParseUint(intPart, 10, 63); err != nil {
+			return Zero, fmt.Errorf("decimal: invalid %q", s)
+		}
+	}
+	if fracPart != "" {
+		if frac, err = strconv.ParseUint(fracPart, 10, 63); err != nil {
+			return Zero, fmt.Errorf("decimal: invalid %q", s)
+		}
+		frac *= uint64(pow10[Precision-len(fracPart)])
+	}
+
+	hi, lo := bits.Mul64(whole, Scale)
+	lo, carry := bits.Add64(lo, frac, 0)
+	if hi != 0 || carry != 0 || lo > math.MaxInt64 {
+		return Zero, fmt.Errorf("decimal: %q out of range", s)
+	}
+
+	v := int64(lo)
+	if neg {
+		v = -v
+	}
+	return Decimal(v), nil
+}
+
+// ParseScale is Parse limited to places decimal places, such as the scale
+// of an instrument's prices
+func ParseScale(s string, places int32) (Decimal, error) {
+	d, err := Parse(s)
+	if err != nil {
+		return Zero, err
+	}
+	if d != d.Truncate(places) {
+		return Zero, fmt.Errorf("decimal: %q has more than %d decimal places", s, places)
+	}
+	return d, nil
+}
+
+// MustParse is Parse for constants; it panics on error
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// String renders the shortest exact representation, e.g. "0.005"
+func (d Decimal) String() string {
+	u := uabs(int64(d))
+	whole := strconv.FormatUint(u/Scale, 10)
+	frac := u % Scale
+
+	sign := ""
+	if d < 0 {
+		sign = "-"
+	}
+	if frac == 0 {
+		return sign + whole
+	}
+
+	fs := strconv.FormatUint(frac, 10)
+	fs = strings.Repeat("0", Precision-len(fs)) + fs
+	return sign + whole + "." + strings.TrimRight(fs, "0")
+}
+
+// StringScale renders d with at least places decimal places, e.g.
+// "30000.10" at 2. Digits beyond places are kept, never rounded away.
+func (d Decimal) StringScale(places int32) string {
+	s := d.String()
+	if places <= 0 {
+		return s
+	}
+	_, frac, ok := strings.Cut(s, ".")
+	if !ok {
+		s += "."
+	}
+	if n := int(places) - len(frac); n > 0 {
+		s += strings.Repeat("0", n)
+	}
+	return s
+}
+
+// Places returns the number of decimal places d needs, e.g. 1 for 0.5
+func (d Decimal) Places() int32 {
+	v := uabs(int64(d))
+	if v == 0 {
+		return 0
+	}
+	places := int32(Precision)
+	for places > 0 && v%10 == 0 {
+		v /= 10
+		places--
+	}
+	return places
+}
+
+// Float64 converts for display and metrics only
+func (d Decimal) Float64() float64 {
+	return float64(d) / Scale
+}
+
+// Mul returns d × o rounded to Precision places. It panics on overflow;
+// use MulChecked on values that have not been bounded.
+func (d Decimal) Mul(o Decimal) Decimal {
+	r, err := d.MulChecked(o)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// MulChecked is Mul returning ErrOverflow instead of panicking
+func (d Decimal) MulChecked(o Decimal) (Decimal, error) {
+	hi, lo := bits.Mul64(uabs(int64(d)), uabs(int64(o)))
+	if hi >= Scale {
+		return Zero, ErrOverflow
+	}
+	q, r := bits.Div64(hi, lo, Scale)
+	if r >= Scale-r {
+		q++
+	}
+	if q > math.MaxInt64 {
+		return Zero, ErrOverflow
+	}
+	if (d < 0) != (o < 0) {
+		return Decimal(-int64(q)), nil
+	}
+	return Decimal(q), nil
+}
+
+// Div returns d ÷ o rounded to Precision places. It panics if o is zero.
+func (d Decimal) Div(o Decimal) Decimal {
+	if o == 0 {
+		panic("decimal: division by zero")
+	}
+	hi, lo := bits.Mul64(uabs(int64(d)), Scale)
+	return Decimal(divRound128(hi, lo, uabs(int64(o)), (d < 0) != (o < 0)))
+}
+
+// Abs returns |d|
+func (d Decimal) Abs() Decimal {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// Neg returns -d
+func (d Decimal) Neg() Decimal {
+	return -d
+}
+
+// Sign returns -1, 0 or 1
+func (d Decimal) Sign() int {
+	switch {
+	case d < 0:
+		return -1
+	case d > 0:
+		return 1
+	}
+	return 0
+}
+
+// IsZero reports whether d == 0
+func (d Decimal) IsZero() bool {
+	return d == 0
+}
+
+// Round rounds half away from zero to the given number of decimal places
+func (d Decimal) Round(places int32) Decimal {
+	unit := unitFor(places)
+	return Decimal(roundDiv(int64(d), unit) * unit)
+}
+
+// Truncate drops digits beyond the given number of decimal places, towards zero
+func (d Decimal) Truncate(places int32) Decimal {
+	unit := unitFor(places)
+	return Decimal(int64(d) / unit * unit)
+}
+
+// IsMultipleOf reports whether d is an exact multiple of step (e.g. a tick size).
+// Every value is a multiple of a zero step.
+func (d Decimal) IsMultipleOf(step Decimal) bool {
+	if step == 0 {
+		return true
+	}
+	return int64(d)%int64(step) == 0
+}
+
+// Min returns the smaller of a and b
+func Min(a, b Decimal) Decimal {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Max returns the larger of a and b
+func Max(a, b Decimal) Decimal {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// MarshalJSON encodes as a JSON string so no float parsing is ever involved
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON accepts both "1.5" and 1.5
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		return nil
+	}
+	if len(s) > 0 && s[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+	}
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// ================= helpers =================
+
+func unitFor(places int32) int64 {
+	if places < 0 || places >= Precision {
+		return 1
+	}
+	return pow10[Precision-places]
+}
+
+func uabs(v int64) uint64 {
+	if v < 0 {
+		return uint64(-v)
+	}
+	return uint64(v)
+}
+
+func signed(hi, lo uint64, neg bool) int64 {
+	if hi != 0 || lo > math.MaxInt64 {
+		panic("decimal: overflow")
+	}
+	if neg {
+		return -int64(lo)
+	}
+	return int64(lo)
+}
+
+// roundDiv divides rounding half away from zero
+func roundDiv(v, by int64) int64 {
+	q, r := v/by, v%by
+	if r < 0 {
+		r = -r
+	}
+	if 2*r >= by {
+		if v < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return q
+}
+
+// divRound128 divides the unsigned 128-bit hi:lo by y, rounding half away from zero
+func divRound128(hi, lo, y uint64, neg bool) int64 {
+	if hi >= y {
+		panic("decimal: overflow")
+	}
+	q, r := bits.Div64(hi, lo, y)
+	if r >= y-r {
+		q++
+	}
+	return signed(0, q, neg)
+}
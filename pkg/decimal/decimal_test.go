@@ -0,0 +1,91 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"oms-contract/pkg/decimal"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAndString(t *testing.T) {
+	for _, s := range []string{"0", "1", "-1", "0.005", "42000.125", "-0.00000001", "92233720368"} {
+		d, err := decimal.Parse(s)
+		require.NoError(t, err, s)
+		require.Equal(t, s, d.String())
+	}
+
+	require.Equal(t, decimal.MustParse("0.005"), decimal.New(5, -3))
+	require.Equal(t, decimal.MustParse("1.5"), decimal.FromFloat(1.5))
+
+	for _, s := range []string{"", ".", "1.123456789", "abc", "1e5", "99999999999"} {
+		_, err := decimal.Parse(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestArithmeticIsExact(t *testing.T) {
+	// 0.1 + 0.2 == 0.3, unlike float64
+	require.Equal(t, decimal.MustParse("0.3"), decimal.MustParse("0.1")+decimal.MustParse("0.2"))
+
+	price := decimal.MustParse("42000.5")
+	qty := decimal.MustParse("1.5")
+	require.Equal(t, "63000.75", price.Mul(qty).String())
+	require.Equal(t, "-63000.75", price.Mul(qty.Neg()).String())
+
+	// Div rounds half away from zero at the last place
+	require.Equal(t, "0.33333333", decimal.One.Div(decimal.FromInt(3)).String())
+	require.Equal(t, "0.66666667", decimal.FromInt(2).Div(decimal.FromInt(3)).String())
+	require.Equal(t, "-0.66666667", decimal.FromInt(-2).Div(decimal.FromInt(3)).String())
+
+	// Large notional does not overflow the intermediate product
+	require.Equal(t, "5000000000", decimal.FromInt(100000).Mul(decimal.FromInt(50000)).String())
+
+	// A product out of range is an error from MulChecked and a panic from Mul
+	_, err := decimal.FromInt(90000000000).MulChecked(decimal.FromInt(100))
+	require.ErrorIs(t, err, decimal.ErrOverflow)
+	require.Panics(t, func() { decimal.FromInt(90000000000).Mul(decimal.FromInt(-100)) })
+	p, err := price.MulChecked(qty.Neg())
+	require.NoError(t, err)
+	require.Equal(t, "-63000.75", p.String())
+}
+
+func TestRoundingAndSteps(t *testing.T) {
+	d := decimal.MustParse("1.255")
+	require.Equal(t, "1.26", d.Round(2).String())
+	require.Equal(t, "1.25", d.Truncate(2).String())
+	require.Equal(t, "-1.26", d.Neg().Round(2).String())
+
+	tick := decimal.MustParse("0.5")
+	require.True(t, decimal.MustParse("100.5").IsMultipleOf(tick))
+	require.False(t, decimal.MustParse("100.25").IsMultipleOf(tick))
+	require.Equal(t, int32(1), tick.Places())
+	require.Equal(t, int32(0), decimal.FromInt(100).Places())
+	require.Equal(t, int32(8), decimal.MustParse("0.00000001").Places())
+}
+
+func TestScale(t *testing.T) {
+	d, err := decimal.ParseScale("30000.1", 2)
+	require.NoError(t, err)
+	require.Equal(t, "30000.10", d.StringScale(2))
+	_, err = decimal.ParseScale("30000.125", 2)
+	require.Error(t, err)
+	_, err = decimal.ParseScale("1.5", 0)
+	require.Error(t, err)
+
+	require.Equal(t, "3", decimal.FromInt(3).StringScale(0))
+	require.Equal(t, "3.000", decimal.FromInt(3).StringScale(3))
+	require.Equal(t, "-0.125", decimal.MustParse("-0.125").StringScale(2))
+}
+
+func TestJSON(t *testing.T) {
+	b, err := json.Marshal(struct{ P decimal.Decimal }{decimal.MustParse("0.1")})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"P":"0.1"}`, string(b))
+
+	var v struct{ P, Q decimal.Decimal }
+	require.NoError(t, json.Unmarshal([]byte(`{"P":"1.25","Q":2.5}`), &v))
+	require.Equal(t, decimal.MustParse("1.25"), v.P)
+	require.Equal(t, decimal.MustParse("2.5"), v.Q)
+}
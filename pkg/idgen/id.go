@@ -14,6 +14,15 @@ func New() *Generator {
 	return &Generator{id: 1000}
 }
 
+// NewFrom continues after last, e.g. the highest ID recovered by replay
+func NewFrom(last int64) *Generator {
+	g := New()
+	if last > g.id {
+		g.id = last
+	}
+	return g
+}
+
 func (g *Generator) Next() int64 {
 	return atomic.AddInt64(&g.id, 1)
 }